@@ -0,0 +1,29 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/canonical/microcluster/rest"
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/lxd/response"
+
+	"github.com/canonical/microovn/microovn/ovn"
+)
+
+// /1.0/backups endpoint.
+var backupsCmd = rest.Endpoint{
+	Path: "backups",
+
+	Get: rest.EndpointAction{Handler: cmdBackupsGet, ProxyTarget: true},
+}
+
+// cmdBackupsGet implements GET method for /1.0/backups endpoint. It returns backups of MicroOVN data present
+// on the member, along with information whether they can be used for recovery.
+func cmdBackupsGet(s *state.State, _ *http.Request) response.Response {
+	backups, err := ovn.ListBackups(s)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, backups)
+}
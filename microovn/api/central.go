@@ -0,0 +1,126 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/canonical/microcluster/client"
+	"github.com/canonical/microcluster/rest"
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/lxd/response"
+	"github.com/lxc/lxd/shared/logger"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	microovnClient "github.com/canonical/microovn/microovn/client"
+	"github.com/canonical/microovn/microovn/ovn"
+)
+
+// /1.0/central/scale endpoint.
+var centralScaleCmd = rest.Endpoint{
+	Path: "central/scale",
+
+	Post: rest.EndpointAction{Handler: cmdCentralScalePost, ProxyTarget: true},
+}
+
+// /1.0/central/demote endpoint.
+var centralDemoteCmd = rest.Endpoint{
+	Path: "central/demote",
+
+	Post: rest.EndpointAction{Handler: cmdCentralDemotePost, ProxyTarget: true},
+}
+
+// /1.0/central/safe-to-remove endpoint.
+var centralSafeToRemoveCmd = rest.Endpoint{
+	Path: "central/safe-to-remove",
+
+	Get: rest.EndpointAction{Handler: cmdCentralSafeToRemoveGet, ProxyTarget: true},
+}
+
+// /1.0/central/recover endpoint.
+var centralRecoverCmd = rest.Endpoint{
+	Path: "central/recover",
+
+	Post: rest.EndpointAction{Handler: cmdCentralRecoverPost, ProxyTarget: true},
+}
+
+// cmdCentralScalePost implements POST method for /1.0/central/scale endpoint. It reduces number of members
+// that run the "central" service to the requested target and returns list of demoted members. Request
+// must be handled by a member that runs the "central" service.
+func cmdCentralScalePost(s *state.State, r *http.Request) response.Response {
+	req := types.CentralScaleRequest{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	demoted, err := ovn.ScaleCentral(s, req.Target, req.Force)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, types.CentralScaleResult{Demoted: demoted})
+}
+
+// cmdCentralDemotePost implements POST method for /1.0/central/demote endpoint. It stops the "central"
+// service on the targeted member, which stays in the cluster.
+func cmdCentralDemotePost(s *state.State, _ *http.Request) response.Response {
+	err := ovn.DemoteCentral(s)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
+
+// cmdCentralSafeToRemoveGet implements GET method for /1.0/central/safe-to-remove endpoint. It reports, for
+// every member that runs the "central" service, whether it can be removed without losing quorum.
+func cmdCentralSafeToRemoveGet(s *state.State, _ *http.Request) response.Response {
+	safe, err := ovn.SafeToRemove(s)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, safe)
+}
+
+// cmdCentralRecoverPost implements POST method for /1.0/central/recover endpoint. It recovers OVN NB and SB
+// databases on the targeted member from a backup, selected either by name or by creation time, and then asks
+// every other member to refresh its configuration, so that surviving chassis connect to the recovered SB
+// database.
+func cmdCentralRecoverPost(s *state.State, r *http.Request) response.Response {
+	req := types.RecoverRequest{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if req.Backup == "" && req.Timestamp != 0 {
+		backup, err := ovn.FindBackup(s, req.Timestamp)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		req.Backup = backup.Name
+	}
+
+	err = ovn.Recover(s, req.Backup)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = ovn.QueryCluster(s, r, func(ctx context.Context, c *client.Client) error {
+		err := microovnClient.Refresh(ctx, c)
+		if err != nil {
+			clientURL := c.URL()
+			logger.Warnf("Failed to refresh configuration on cluster member %q: %s", clientURL.String(), err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
@@ -0,0 +1,53 @@
+package certificates
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/canonical/microcluster/rest"
+	"github.com/canonical/microcluster/state"
+	"github.com/gorilla/mux"
+	"github.com/lxc/lxd/lxd/response"
+
+	"github.com/canonical/microovn/microovn/ovn"
+)
+
+// ClientCertsEndpoint defines endpoint for /1.0/client-certificates
+var ClientCertsEndpoint = rest.Endpoint{
+	Path: "client-certificates",
+	Get:  rest.EndpointAction{Handler: clientCertsGet, AllowUntrusted: false, ProxyTarget: true},
+}
+
+// ClientCertEndpoint defines endpoint for /1.0/client-certificates/<serial>
+var ClientCertEndpoint = rest.Endpoint{
+	Path:   "client-certificates/{serial}",
+	Delete: rest.EndpointAction{Handler: clientCertDelete, AllowUntrusted: false, ProxyTarget: true},
+}
+
+// clientCertsGet implements GET method for /1.0/client-certificates endpoint. The function returns client
+// certificates issued for external clients of OVN databases.
+func clientCertsGet(s *state.State, _ *http.Request) response.Response {
+	certs, err := ovn.ListClientCerts(s)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, certs)
+}
+
+// clientCertDelete implements DELETE method for /1.0/client-certificates/<serial> endpoint. The function
+// revokes the client certificate and rotates cluster CA, so that OVN databases reject it. Response contains
+// certificates reissued for the remaining external clients.
+func clientCertDelete(s *state.State, r *http.Request) response.Response {
+	serial, err := url.PathUnescape(mux.Vars(r)["serial"])
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	reissued, err := ovn.RevokeClientCert(s, serial)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, reissued)
+}
@@ -0,0 +1,59 @@
+package certificates
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/canonical/microcluster/rest"
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/lxd/response"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/ovn"
+)
+
+// ExportPKIEndpoint defines endpoint for /1.0/pki/export
+var ExportPKIEndpoint = rest.Endpoint{
+	Path: "pki/export",
+	Post: rest.EndpointAction{Handler: exportPKIPost, AllowUntrusted: false, ProxyTarget: true},
+}
+
+// ImportPKIEndpoint defines endpoint for /1.0/pki/import
+var ImportPKIEndpoint = rest.Endpoint{
+	Path: "pki/import",
+	Post: rest.EndpointAction{Handler: importPKIPost, AllowUntrusted: false, ProxyTarget: true},
+}
+
+// exportPKIPost implements POST method for /1.0/pki/export endpoint. The function returns CA and service
+// certificates of the targeted member, encrypted with the passphrase from the request.
+func exportPKIPost(s *state.State, r *http.Request) response.Response {
+	req := types.PKIExportRequest{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	bundle, err := ovn.ExportPKI(s, req.Passphrase)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, types.PKIBundle{Bundle: bundle})
+}
+
+// importPKIPost implements POST method for /1.0/pki/import endpoint. The function replaces cluster CA with
+// the one from the bundle in the request and restores certificates of services on the targeted member.
+func importPKIPost(s *state.State, r *http.Request) response.Response {
+	req := types.PKIImportRequest{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = ovn.ImportPKI(s, req.Bundle, req.Passphrase)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
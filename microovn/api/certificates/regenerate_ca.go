@@ -41,15 +41,8 @@ func regenerateCaPut(s *state.State, r *http.Request) response.Response {
 			responseData.NewCa = true
 		}
 
-		// Get clients for rest of the cluster members
-		cluster, err := s.Cluster(r)
-		if err != nil {
-			logger.Errorf("Failed to get a client for every cluster member: %w", err)
-			return response.SyncResponse(false, &responseData)
-		}
-
 		// Bump rest of the cluster members to reissue their certificates with new CA
-		err = cluster.Query(s.Context, true, func(ctx context.Context, c *client.Client) error {
+		err = ovn.QueryCluster(s, r, func(ctx context.Context, c *client.Client) error {
 			logger.Infof("Requesting cluster member at '%s' to re-issue its OVN certificates", c.URL())
 			result, err := microovnClient.RegenerateCA(ctx, c)
 			if err != nil {
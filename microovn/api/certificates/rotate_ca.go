@@ -0,0 +1,54 @@
+package certificates
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/canonical/microcluster/rest"
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/lxd/response"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/ovn"
+)
+
+// RotateCaEndpoint defines endpoint for /1.0/ca/rotate
+var RotateCaEndpoint = rest.Endpoint{
+	Path: "ca/rotate",
+	Post: rest.EndpointAction{Handler: rotateCaPost, AllowUntrusted: false, ProxyTarget: true},
+}
+
+// RotateCaStepEndpoint defines endpoint for /1.0/ca/rotate/step
+var RotateCaStepEndpoint = rest.Endpoint{
+	Path: "ca/rotate/step",
+	Post: rest.EndpointAction{Handler: rotateCaStepPost, AllowUntrusted: false, ProxyTarget: true},
+}
+
+// rotateCaPost implements POST method for /1.0/ca/rotate endpoint. The function replaces cluster CA with a new
+// one and reissues certificates on every cluster member, without interrupting trust between them. Response
+// lists client certificates that are no longer trusted after the rotation.
+func rotateCaPost(s *state.State, _ *http.Request) response.Response {
+	untrusted, err := ovn.RotateCA(s)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, untrusted)
+}
+
+// rotateCaStepPost implements POST method for /1.0/ca/rotate/step endpoint. The function executes single
+// step of CA rotation on the targeted member.
+func rotateCaStepPost(s *state.State, r *http.Request) response.Response {
+	req := types.CARotationStepRequest{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = ovn.ApplyCARotationStep(s, req.Step)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/canonical/microcluster/rest"
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/lxd/response"
+
+	"github.com/canonical/microovn/microovn/ovn"
+)
+
+// /1.0/chassis endpoint.
+var chassisCmd = rest.Endpoint{
+	Path: "chassis",
+
+	Get: rest.EndpointAction{Handler: cmdChassisGet, ProxyTarget: true},
+}
+
+// /1.0/chassis/remote endpoint.
+var chassisRemoteCmd = rest.Endpoint{
+	Path: "chassis/remote",
+
+	Put: rest.EndpointAction{Handler: cmdChassisRemotePut, ProxyTarget: true},
+}
+
+// /1.0/chassis/pause endpoint.
+var chassisPauseCmd = rest.Endpoint{
+	Path: "chassis/pause",
+
+	Post: rest.EndpointAction{Handler: cmdChassisPausePost, ProxyTarget: true},
+}
+
+// /1.0/chassis/resume endpoint.
+var chassisResumeCmd = rest.Endpoint{
+	Path: "chassis/resume",
+
+	Post: rest.EndpointAction{Handler: cmdChassisResumePost, ProxyTarget: true},
+}
+
+// cmdChassisGet implements GET method for /1.0/chassis endpoint. It returns OVN chassis registered in
+// the OVN SB database along with their bindings. Request must be handled by a member that runs the
+// "central" service.
+func cmdChassisGet(s *state.State, _ *http.Request) response.Response {
+	chassis, err := ovn.ListChassis(s)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, chassis)
+}
+
+// cmdChassisRemotePut implements PUT method for /1.0/chassis/remote endpoint. It points local OVN chassis at
+// the OVN SB servers currently run by "central" members and waits until ovn-controller reconnects.
+func cmdChassisRemotePut(s *state.State, _ *http.Request) response.Response {
+	err := ovn.ReconfigureChassisRemote(s)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
+
+// cmdChassisPausePost implements POST method for /1.0/chassis/pause endpoint. It stops local OVN chassis
+// from installing new flows, while keeping it registered in the OVN SB database.
+func cmdChassisPausePost(s *state.State, _ *http.Request) response.Response {
+	err := ovn.PauseChassis(s)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
+
+// cmdChassisResumePost implements POST method for /1.0/chassis/resume endpoint. It resumes local OVN chassis
+// paused via /1.0/chassis/pause.
+func cmdChassisResumePost(s *state.State, _ *http.Request) response.Response {
+	err := ovn.ResumeChassis(s)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
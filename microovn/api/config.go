@@ -0,0 +1,142 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/canonical/microcluster/client"
+	"github.com/canonical/microcluster/rest"
+	"github.com/canonical/microcluster/state"
+	"github.com/gorilla/mux"
+	"github.com/lxc/lxd/lxd/response"
+	"github.com/lxc/lxd/shared/logger"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	microovnClient "github.com/canonical/microovn/microovn/client"
+	"github.com/canonical/microovn/microovn/ovn"
+)
+
+// /1.0/config endpoint.
+var configsCmd = rest.Endpoint{
+	Path: "config",
+
+	Get: rest.EndpointAction{Handler: cmdConfigsGet, ProxyTarget: true},
+}
+
+// /1.0/config/<key> endpoint.
+var configCmd = rest.Endpoint{
+	Path: "config/{key}",
+
+	Get:  rest.EndpointAction{Handler: cmdConfigGet, ProxyTarget: true},
+	Put:  rest.EndpointAction{Handler: cmdConfigPut, ProxyTarget: true},
+	Post: rest.EndpointAction{Handler: cmdConfigPost, ProxyTarget: true},
+}
+
+// /1.0/effective-config endpoint.
+var effectiveConfigCmd = rest.Endpoint{
+	Path: "effective-config",
+
+	Get: rest.EndpointAction{Handler: cmdEffectiveConfigGet, ProxyTarget: true},
+}
+
+// cmdConfigsGet implements GET method for /1.0/config endpoint. It returns current values of all
+// configuration options.
+func cmdConfigsGet(s *state.State, _ *http.Request) response.Response {
+	values, err := ovn.ListConfig(s)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, values)
+}
+
+// cmdConfigGet implements GET method for /1.0/config/<key> endpoint. It returns current value of
+// the requested configuration option.
+func cmdConfigGet(s *state.State, r *http.Request) response.Response {
+	key, err := url.PathUnescape(mux.Vars(r)["key"])
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	value, err := ovn.GetConfig(s, key)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, types.ConfigValue{Key: key, Value: value})
+}
+
+// cmdConfigPut implements PUT method for /1.0/config/<key> endpoint. It validates and stores new value
+// of the configuration option. Empty value resets the option to its default.
+func cmdConfigPut(s *state.State, r *http.Request) response.Response {
+	key, err := url.PathUnescape(mux.Vars(r)["key"])
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	req := types.ConfigValue{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = ovn.SetConfig(s, key, req.Value)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = ovn.ApplyConfig(s, key)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("configuration option was stored, but failed to apply: %w", err))
+	}
+
+	// Options shared by the whole cluster need to be applied on every member.
+	if ovn.IsMemberConfig(key) || client.IsForwardedRequest(r) {
+		return response.EmptySyncResponse
+	}
+
+	err = ovn.QueryCluster(s, r, func(ctx context.Context, c *client.Client) error {
+		err := microovnClient.ApplyConfig(ctx, c, key)
+		if err != nil {
+			clientURL := c.URL()
+			logger.Warnf("Failed to apply configuration option '%s' on cluster member %q: %s", key, clientURL.String(), err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
+
+// cmdConfigPost implements POST method for /1.0/config/<key> endpoint. It performs actions required for
+// the current value of the configuration option to take effect on the cluster member.
+func cmdConfigPost(s *state.State, r *http.Request) response.Response {
+	key, err := url.PathUnescape(mux.Vars(r)["key"])
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = ovn.ApplyConfig(s, key)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
+
+// cmdEffectiveConfigGet implements GET method for /1.0/effective-config endpoint. It returns OVN/OVS settings
+// of the member, comparing values derived from the stored configuration with the live ones.
+func cmdEffectiveConfigGet(s *state.State, _ *http.Request) response.Response {
+	values, err := ovn.EffectiveConfig(s)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, values)
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/canonical/microcluster/rest"
+	"github.com/canonical/microcluster/state"
+	"github.com/gorilla/mux"
+	"github.com/lxc/lxd/lxd/response"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/ovn"
+)
+
+// /1.0/databases/<name>/writable endpoint.
+var databaseWritableCmd = rest.Endpoint{
+	Path: "databases/{name}/writable",
+
+	Get: rest.EndpointAction{Handler: cmdDatabaseWritableGet, ProxyTarget: true},
+}
+
+// /1.0/databases/connections endpoint.
+var databaseConnectionsCmd = rest.Endpoint{
+	Path: "databases/connections",
+
+	Get: rest.EndpointAction{Handler: cmdDatabaseConnectionsGet, ProxyTarget: true},
+}
+
+// cmdDatabaseWritableGet implements GET method for /1.0/databases/<name>/writable endpoint. It reports
+// whether OVN database ("nb" or "sb") has quorum and is not in read-only mode.
+func cmdDatabaseWritableGet(s *state.State, r *http.Request) response.Response {
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	writable, err := ovn.CanWrite(s, name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, types.DatabaseWritable{Database: name, Writable: writable})
+}
+
+// cmdDatabaseConnectionsGet implements GET method for /1.0/databases/connections endpoint. It performs OVSDB
+// handshake with NB and SB servers of every "central" member and reports databases found on each endpoint.
+func cmdDatabaseConnectionsGet(s *state.State, _ *http.Request) response.Response {
+	checks, err := ovn.TestDatabaseConnections(s)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, checks)
+}
@@ -10,7 +10,52 @@ import (
 // Endpoints is a global list of all API endpoints on the /1.0 endpoint of microovn.
 var Endpoints = []rest.Endpoint{
 	servicesCmd,
+	serviceEntryCmd,
+	localServicesCmd,
+	leaveCmd,
+	memberAddressCmd,
+	memberLabelsCmd,
+	memberLabelCmd,
+	refreshCmd,
+	environmentCmd,
+	environmentAllCmd,
+	centralScaleCmd,
+	centralDemoteCmd,
+	centralSafeToRemoveCmd,
+	centralRecoverCmd,
+	backupsCmd,
+	routerRoutesCmd,
+	topologyCmd,
+	topologyValidateCmd,
+	databaseWritableCmd,
+	databaseConnectionsCmd,
+	northdCmd,
+	northdSyncCmd,
+	northdPauseCmd,
+	northdResumeCmd,
+	sbRebuildCmd,
+	sbRejoinCmd,
+	statusCmd,
+	footprintCmd,
+	healthCmd,
+	controlSocketsCmd,
+	resetCmd,
+	metricsCmd,
+	chassisCmd,
+	chassisRemoteCmd,
+	chassisPauseCmd,
+	chassisResumeCmd,
+	configsCmd,
+	configCmd,
+	effectiveConfigCmd,
+	lxdIntegrationCmd,
 	certificates.IssueCertificatesEndpoint,
 	certificates.IssueCertificatesAllEndpoint,
 	certificates.RegenerateCaEndpoint,
+	certificates.RotateCaEndpoint,
+	certificates.RotateCaStepEndpoint,
+	certificates.ClientCertsEndpoint,
+	certificates.ClientCertEndpoint,
+	certificates.ExportPKIEndpoint,
+	certificates.ImportPKIEndpoint,
 }
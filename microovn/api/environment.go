@@ -0,0 +1,47 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/canonical/microcluster/rest"
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/lxd/response"
+
+	"github.com/canonical/microovn/microovn/ovn"
+)
+
+// /1.0/environment endpoint.
+var environmentCmd = rest.Endpoint{
+	Path: "environment",
+
+	Post: rest.EndpointAction{Handler: cmdEnvironmentPost, ProxyTarget: true},
+}
+
+// /1.0/environment/all endpoint.
+var environmentAllCmd = rest.Endpoint{
+	Path: "environment/all",
+
+	Post: rest.EndpointAction{Handler: cmdEnvironmentAllPost},
+}
+
+// cmdEnvironmentPost implements POST method for /1.0/environment endpoint. It regenerates ovn.env of the
+// targeted member.
+func cmdEnvironmentPost(s *state.State, _ *http.Request) response.Response {
+	err := ovn.RegenerateEnvironment(s)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
+
+// cmdEnvironmentAllPost implements POST method for /1.0/environment/all endpoint. It regenerates ovn.env
+// on every cluster member.
+func cmdEnvironmentAllPost(s *state.State, _ *http.Request) response.Response {
+	err := ovn.RegenerateEnvironmentAll(s)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/canonical/microcluster/rest"
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/lxd/response"
+
+	"github.com/canonical/microovn/microovn/ovn"
+)
+
+// /1.0/integrations/lxd endpoint.
+var lxdIntegrationCmd = rest.Endpoint{
+	Path: "integrations/lxd",
+
+	Get:  rest.EndpointAction{Handler: cmdLXDIntegrationGet},
+	Post: rest.EndpointAction{Handler: cmdLXDIntegrationPost},
+}
+
+// cmdLXDIntegrationGet implements GET method for /1.0/integrations/lxd endpoint. It returns OVN NB connect
+// string and CA certificate in format expected by LXD's "network.ovn.*" configuration options.
+func cmdLXDIntegrationGet(s *state.State, _ *http.Request) response.Response {
+	info, err := ovn.LXDConnectionInfo(s, false)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, info)
+}
+
+// cmdLXDIntegrationPost implements POST method for /1.0/integrations/lxd endpoint. In addition to the
+// information returned by GET method, it issues new client certificate for LXD.
+func cmdLXDIntegrationPost(s *state.State, _ *http.Request) response.Response {
+	info, err := ovn.LXDConnectionInfo(s, true)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, info)
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/canonical/microcluster/rest"
+	"github.com/canonical/microcluster/state"
+	"github.com/gorilla/mux"
+	"github.com/lxc/lxd/lxd/response"
+	"github.com/lxc/lxd/shared/api"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/ovn"
+)
+
+// /1.0/members/<name>/labels endpoint.
+var memberLabelsCmd = rest.Endpoint{
+	Path: "members/{name}/labels",
+
+	Get: rest.EndpointAction{Handler: cmdMemberLabelsGet},
+}
+
+// /1.0/members/<name>/labels/<key> endpoint.
+var memberLabelCmd = rest.Endpoint{
+	Path: "members/{name}/labels/{key}",
+
+	Get: rest.EndpointAction{Handler: cmdMemberLabelGet},
+	Put: rest.EndpointAction{Handler: cmdMemberLabelPut},
+}
+
+// cmdMemberLabelsGet implements GET method for /1.0/members/<name>/labels endpoint. It returns all labels
+// attached to the member.
+func cmdMemberLabelsGet(s *state.State, r *http.Request) response.Response {
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	labels, err := ovn.GetMemberLabels(s, name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, labels)
+}
+
+// cmdMemberLabelGet implements GET method for /1.0/members/<name>/labels/<key> endpoint. It returns single
+// label attached to the member.
+func cmdMemberLabelGet(s *state.State, r *http.Request) response.Response {
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	key, err := url.PathUnescape(mux.Vars(r)["key"])
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	labels, err := ovn.GetMemberLabels(s, name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	value, ok := labels[key]
+	if !ok {
+		return response.SmartError(api.StatusErrorf(http.StatusNotFound, "label '%s' is not set on member '%s'", key, name))
+	}
+
+	return response.SyncResponse(true, types.MemberLabel{Key: key, Value: value})
+}
+
+// cmdMemberLabelPut implements PUT method for /1.0/members/<name>/labels/<key> endpoint. It attaches the
+// label to the member, or removes it if the requested value is empty.
+func cmdMemberLabelPut(s *state.State, r *http.Request) response.Response {
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	key, err := url.PathUnescape(mux.Vars(r)["key"])
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	req := types.MemberLabel{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = ovn.SetMemberLabel(s, name, key, req.Value)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
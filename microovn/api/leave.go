@@ -0,0 +1,37 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/canonical/microcluster/rest"
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/lxd/response"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/ovn"
+)
+
+// /1.0/leave endpoint.
+var leaveCmd = rest.Endpoint{
+	Path: "leave",
+
+	Post: rest.EndpointAction{Handler: cmdLeavePost},
+}
+
+// cmdLeavePost implements POST method for /1.0/leave endpoint. It removes requested members from the
+// cluster in an order that preserves quorum of OVN databases.
+func cmdLeavePost(s *state.State, r *http.Request) response.Response {
+	req := types.LeaveRequest{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = ovn.LeaveMany(s, req.Members)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/canonical/microcluster/rest"
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/lxd/response"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/ovn"
+)
+
+// /1.0/services/local endpoint.
+var localServicesCmd = rest.Endpoint{
+	Path: "services/local",
+
+	Put: rest.EndpointAction{Handler: cmdLocalServicesPut, ProxyTarget: true},
+}
+
+// cmdLocalServicesPut implements PUT method for /1.0/services/local endpoint. It starts or stops all OVN
+// services enabled on the cluster member, without changing its cluster membership.
+func cmdLocalServicesPut(s *state.State, r *http.Request) response.Response {
+	req := types.LocalServicesState{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if req.Running {
+		err = ovn.StartLocal(s)
+	} else {
+		err = ovn.StopLocal(s)
+	}
+
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/canonical/microcluster/client"
+	"github.com/canonical/microcluster/rest"
+	"github.com/canonical/microcluster/state"
+	"github.com/gorilla/mux"
+	"github.com/lxc/lxd/lxd/response"
+	"github.com/lxc/lxd/shared/logger"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	microovnClient "github.com/canonical/microovn/microovn/client"
+	"github.com/canonical/microovn/microovn/ovn"
+)
+
+// /1.0/members/<name>/address endpoint.
+var memberAddressCmd = rest.Endpoint{
+	Path: "members/{name}/address",
+
+	Put: rest.EndpointAction{Handler: cmdMemberAddressPut, ProxyTarget: true},
+}
+
+// /1.0/refresh endpoint.
+var refreshCmd = rest.Endpoint{
+	Path: "refresh",
+
+	Post: rest.EndpointAction{Handler: cmdRefreshPost, ProxyTarget: true},
+}
+
+// cmdMemberAddressPut implements PUT method for /1.0/members/<name>/address endpoint. It must be
+// targeted at the member whose address changed. After the member reconciles its own OVN configuration,
+// every other member is asked to refresh its configuration, as their connection strings reference the
+// old address.
+func cmdMemberAddressPut(s *state.State, r *http.Request) response.Response {
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	req := types.MemberAddress{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = ovn.UpdateMemberAddress(s, name, req.Address)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = ovn.QueryCluster(s, r, func(ctx context.Context, c *client.Client) error {
+		err := microovnClient.Refresh(ctx, c)
+		if err != nil {
+			clientURL := c.URL()
+			logger.Warnf("Failed to refresh configuration on cluster member %q: %s", clientURL.String(), err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
+
+// cmdRefreshPost implements POST method for /1.0/refresh endpoint. It regenerates OVN configuration of
+// the cluster member and restarts affected services in the background.
+func cmdRefreshPost(s *state.State, r *http.Request) response.Response {
+	err := ovn.Refresh(s)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
@@ -0,0 +1,29 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/canonical/microcluster/rest"
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/lxd/response"
+
+	"github.com/canonical/microovn/microovn/ovn"
+)
+
+// /1.0/metrics endpoint.
+var metricsCmd = rest.Endpoint{
+	Path: "metrics",
+
+	Get: rest.EndpointAction{Handler: cmdMetricsGet, ProxyTarget: true},
+}
+
+// cmdMetricsGet implements GET method for /1.0/metrics endpoint. It returns metrics of the cluster member
+// in the Prometheus text exposition format.
+func cmdMetricsGet(s *state.State, r *http.Request) response.Response {
+	metrics, err := ovn.GatherMetrics(s)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponsePlain(true, false, ovn.RenderPrometheusMetrics(metrics))
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/canonical/microcluster/rest"
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/lxd/response"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/ovn"
+)
+
+// /1.0/northd endpoint.
+var northdCmd = rest.Endpoint{
+	Path: "northd",
+
+	Get: rest.EndpointAction{Handler: cmdNorthdGet, ProxyTarget: true},
+}
+
+// /1.0/northd/sync endpoint.
+var northdSyncCmd = rest.Endpoint{
+	Path: "northd/sync",
+
+	Post: rest.EndpointAction{Handler: cmdNorthdSyncPost, ProxyTarget: true},
+}
+
+// /1.0/northd/pause endpoint.
+var northdPauseCmd = rest.Endpoint{
+	Path: "northd/pause",
+
+	Post: rest.EndpointAction{Handler: cmdNorthdPausePost, ProxyTarget: true},
+}
+
+// /1.0/northd/resume endpoint.
+var northdResumeCmd = rest.Endpoint{
+	Path: "northd/resume",
+
+	Post: rest.EndpointAction{Handler: cmdNorthdResumePost, ProxyTarget: true},
+}
+
+// cmdNorthdGet implements GET method for /1.0/northd endpoint. It returns state of ovn-northd running on
+// the member. Request fails if the member does not run the "central" service.
+func cmdNorthdGet(s *state.State, _ *http.Request) response.Response {
+	status, err := ovn.LocalNorthdStatus(s)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, status)
+}
+
+// cmdNorthdSyncPost implements POST method for /1.0/northd/sync endpoint. It returns once ovn-northd
+// propagated all NB changes made so far into SB, or fails when the requested timeout expires.
+func cmdNorthdSyncPost(s *state.State, r *http.Request) response.Response {
+	req := types.NorthdSyncRequest{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if req.Timeout <= 0 {
+		return response.BadRequest(fmt.Errorf("timeout must be a positive number of seconds"))
+	}
+
+	err = ovn.WaitForNorthdSync(s, time.Duration(req.Timeout)*time.Second)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
+
+// cmdNorthdPausePost implements POST method for /1.0/northd/pause endpoint. It pauses ovn-northd running on the
+// member until it's resumed, or until the requested timeout expires.
+func cmdNorthdPausePost(s *state.State, r *http.Request) response.Response {
+	req := types.NorthdPauseRequest{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if req.Timeout <= 0 {
+		return response.BadRequest(fmt.Errorf("timeout must be a positive number of seconds"))
+	}
+
+	err = ovn.PauseNorthd(s, time.Duration(req.Timeout)*time.Second)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
+
+// cmdNorthdResumePost implements POST method for /1.0/northd/resume endpoint. It resumes ovn-northd paused
+// by the /1.0/northd/pause endpoint.
+func cmdNorthdResumePost(s *state.State, _ *http.Request) response.Response {
+	err := ovn.ResumeNorthd(s)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
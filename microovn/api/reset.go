@@ -0,0 +1,31 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/canonical/microcluster/rest"
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/lxd/response"
+
+	"github.com/canonical/microovn/microovn/ovn"
+)
+
+// /1.0/reset endpoint.
+var resetCmd = rest.Endpoint{
+	Path: "reset",
+
+	Post: rest.EndpointAction{Handler: cmdResetPost},
+
+	AllowedBeforeInit: true,
+}
+
+// cmdResetPost implements POST method for /1.0/reset endpoint. It returns member, whose join failed, to
+// a clean pre-join state.
+func cmdResetPost(s *state.State, _ *http.Request) response.Response {
+	err := ovn.ResetNode(s)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/canonical/microcluster/rest"
+	"github.com/canonical/microcluster/state"
+	"github.com/gorilla/mux"
+	"github.com/lxc/lxd/lxd/response"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/ovn"
+)
+
+// /1.0/routers/<name>/routes endpoint.
+var routerRoutesCmd = rest.Endpoint{
+	Path: "routers/{name}/routes",
+
+	Get: rest.EndpointAction{Handler: cmdRouterRoutesGet, ProxyTarget: true},
+	Put: rest.EndpointAction{Handler: cmdRouterRoutesPut, ProxyTarget: true},
+}
+
+// cmdRouterRoutesGet implements GET method for /1.0/routers/<name>/routes endpoint. It returns static routes
+// currently configured on the logical router.
+func cmdRouterRoutesGet(s *state.State, r *http.Request) response.Response {
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	routes, err := ovn.ListStaticRoutes(s, name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, routes)
+}
+
+// cmdRouterRoutesPut implements PUT method for /1.0/routers/<name>/routes endpoint. It replaces static routes
+// of the logical router with the set from the request body. Request must be handled by a member that runs
+// the "central" service.
+func cmdRouterRoutesPut(s *state.State, r *http.Request) response.Response {
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	routes := []types.StaticRoute{}
+	err = json.NewDecoder(r.Body).Decode(&routes)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = ovn.EnsureStaticRoutes(s, name, routes)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
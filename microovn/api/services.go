@@ -2,9 +2,11 @@ package api
 
 import (
 	"net/http"
+	"net/url"
 
 	"github.com/canonical/microcluster/rest"
 	"github.com/canonical/microcluster/state"
+	"github.com/gorilla/mux"
 	"github.com/lxc/lxd/lxd/response"
 
 	"github.com/canonical/microovn/microovn/ovn"
@@ -17,6 +19,13 @@ var servicesCmd = rest.Endpoint{
 	Get: rest.EndpointAction{Handler: cmdServicesGet, ProxyTarget: true},
 }
 
+// /1.0/services/<member>/<service> endpoint.
+var serviceEntryCmd = rest.Endpoint{
+	Path: "services/{member}/{service}",
+
+	Delete: rest.EndpointAction{Handler: cmdServiceEntryDelete},
+}
+
 func cmdServicesGet(s *state.State, r *http.Request) response.Response {
 	services, err := ovn.ListServices(s)
 	if err != nil {
@@ -25,3 +34,24 @@ func cmdServicesGet(s *state.State, r *http.Request) response.Response {
 
 	return response.SyncResponse(true, services)
 }
+
+// cmdServiceEntryDelete implements DELETE method for /1.0/services/<member>/<service> endpoint. It removes
+// the service record from the services table, without affecting the member.
+func cmdServiceEntryDelete(s *state.State, r *http.Request) response.Response {
+	member, err := url.PathUnescape(mux.Vars(r)["member"])
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	service, err := url.PathUnescape(mux.Vars(r)["service"])
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = ovn.RemoveServiceEntry(s, member, service)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
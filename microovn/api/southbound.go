@@ -0,0 +1,78 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/canonical/microcluster/rest"
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/lxd/response"
+	"github.com/lxc/lxd/shared/logger"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	microovnClient "github.com/canonical/microovn/microovn/client"
+	"github.com/canonical/microovn/microovn/ovn"
+)
+
+// /1.0/sb/rebuild endpoint.
+var sbRebuildCmd = rest.Endpoint{
+	Path: "sb/rebuild",
+
+	Post: rest.EndpointAction{Handler: cmdSBRebuildPost, ProxyTarget: true},
+}
+
+// /1.0/sb/rejoin endpoint.
+var sbRejoinCmd = rest.Endpoint{
+	Path: "sb/rejoin",
+
+	Post: rest.EndpointAction{Handler: cmdSBRejoinPost, ProxyTarget: true},
+}
+
+// cmdSBRebuildPost implements POST method for /1.0/sb/rebuild endpoint. It rebuilds OVN SB database on
+// the targeted member and then makes every other member join the new SB cluster. Members are processed
+// one by one, so that the new cluster grows gradually.
+func cmdSBRebuildPost(s *state.State, r *http.Request) response.Response {
+	err := ovn.RebuildSB(s)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	cluster, err := s.Cluster(r)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("failed to get a client for every cluster member: %w", err))
+	}
+
+	var failed []string
+	for _, c := range cluster {
+		err = microovnClient.RejoinSB(s.Context, &c, s.Name())
+		if err != nil {
+			clientURL := c.URL()
+			logger.Errorf("Cluster member %q failed to join rebuilt SB database: %s", clientURL.String(), err)
+			failed = append(failed, clientURL.String())
+		}
+	}
+
+	if len(failed) > 0 {
+		return response.SmartError(fmt.Errorf("SB database was rebuilt, but following members failed to join it: %v", failed))
+	}
+
+	return response.EmptySyncResponse
+}
+
+// cmdSBRejoinPost implements POST method for /1.0/sb/rejoin endpoint. It makes the member join OVN SB
+// database rebuilt on the requested seed member.
+func cmdSBRejoinPost(s *state.State, r *http.Request) response.Response {
+	req := types.SBRejoinRequest{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = ovn.RejoinSB(s, req.Seed)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
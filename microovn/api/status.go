@@ -0,0 +1,83 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/canonical/microcluster/rest"
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/lxd/response"
+
+	"github.com/canonical/microovn/microovn/ovn"
+)
+
+// /1.0/status endpoint.
+var statusCmd = rest.Endpoint{
+	Path: "status",
+
+	Get: rest.EndpointAction{Handler: cmdStatusGet, ProxyTarget: true},
+}
+
+// /1.0/health endpoint.
+var healthCmd = rest.Endpoint{
+	Path: "health",
+
+	Get: rest.EndpointAction{Handler: cmdHealthGet, ProxyTarget: true},
+}
+
+// /1.0/control-sockets endpoint.
+var controlSocketsCmd = rest.Endpoint{
+	Path: "control-sockets",
+
+	Get: rest.EndpointAction{Handler: cmdControlSocketsGet, ProxyTarget: true},
+}
+
+// /1.0/footprint endpoint.
+var footprintCmd = rest.Endpoint{
+	Path: "footprint",
+
+	Get: rest.EndpointAction{Handler: cmdFootprintGet, ProxyTarget: true},
+}
+
+// cmdStatusGet implements GET method for /1.0/status endpoint. It returns state of OVN components running
+// on the cluster member that handles the request.
+func cmdStatusGet(s *state.State, _ *http.Request) response.Response {
+	status, err := ovn.Status(s)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	return response.SyncResponse(true, status)
+}
+
+// cmdHealthGet implements GET method for /1.0/health endpoint. It reports whether OVN databases of the
+// cluster member that handles the request are able to serve clients.
+func cmdHealthGet(s *state.State, _ *http.Request) response.Response {
+	health, err := ovn.HealthCheck(s)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, health)
+}
+
+// cmdControlSocketsGet implements GET method for /1.0/control-sockets endpoint. It returns status of control
+// sockets of OVN and OVS daemons running on the cluster member that handles the request.
+func cmdControlSocketsGet(s *state.State, _ *http.Request) response.Response {
+	sockets, err := ovn.ControlSockets(s)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, sockets)
+}
+
+// cmdFootprintGet implements GET method for /1.0/footprint endpoint. It returns resources used by OVN across
+// the whole cluster. Request must be handled by a member that runs the "central" service.
+func cmdFootprintGet(s *state.State, _ *http.Request) response.Response {
+	footprint, err := ovn.ClusterFootprint(s)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, footprint)
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/canonical/microcluster/rest"
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/lxd/response"
+
+	"github.com/canonical/microovn/microovn/ovn"
+)
+
+// /1.0/topology endpoint.
+var topologyCmd = rest.Endpoint{
+	Path: "topology",
+
+	Get: rest.EndpointAction{Handler: cmdTopologyGet, ProxyTarget: true},
+}
+
+// /1.0/topology/validate endpoint.
+var topologyValidateCmd = rest.Endpoint{
+	Path: "topology/validate",
+
+	Post: rest.EndpointAction{Handler: cmdTopologyValidatePost, ProxyTarget: true},
+}
+
+// cmdTopologyGet implements GET method for /1.0/topology endpoint. It returns descriptor of the current
+// layout of OVN services in the cluster.
+func cmdTopologyGet(s *state.State, _ *http.Request) response.Response {
+	descriptor, err := ovn.ExportTopology(s)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, json.RawMessage(descriptor))
+}
+
+// cmdTopologyValidatePost implements POST method for /1.0/topology/validate endpoint. It compares the
+// cluster with the topology descriptor sent in the request body and returns list of differences.
+func cmdTopologyValidatePost(s *state.State, r *http.Request) response.Response {
+	descriptor, err := io.ReadAll(r.Body)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	diffs, err := ovn.ValidateTopology(s, descriptor)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, diffs)
+}
@@ -0,0 +1,18 @@
+// Package types provides shared types and structs.
+package types
+
+import "time"
+
+// BackupInfo is a structure that describes single backup of MicroOVN data created when member leaves the cluster.
+type BackupInfo struct {
+	Name      string    `json:"name" yaml:"name"`           // Name of the backup directory
+	Path      string    `json:"path" yaml:"path"`           // Full path to the backup directory
+	Timestamp int64     `json:"timestamp" yaml:"timestamp"` // Unix timestamp of the backup creation
+	Created   time.Time `json:"created" yaml:"created"`     // Time of the backup creation
+	Size      int64     `json:"size" yaml:"size"`           // Total size of all files in the backup (in bytes)
+	Contents  []string  `json:"contents" yaml:"contents"`   // List of directories contained in the backup
+
+	Complete   bool     `json:"complete" yaml:"complete"`     // Backup contains data required for recovery
+	Compatible bool     `json:"compatible" yaml:"compatible"` // Databases in the backup can be used by the installed OVN
+	Problems   []string `json:"problems" yaml:"problems"`     // Reasons why the backup can't be used for recovery
+}
@@ -1,7 +1,10 @@
 // Package types provides shared types and structs.
 package types
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // IssueCertificateResponse is a structure that models response to requests for issuance
 // of OVN certificates.
@@ -85,3 +88,44 @@ func NewRegenerateCaResponse() *RegenerateCaResponse {
 		Errors:               make([]string, 0),
 	}
 }
+
+// CARotationStepRequest is a structure used to request execution of a single CA rotation step on a member.
+type CARotationStepRequest struct {
+	Step string `json:"step" yaml:"step"` // Name of the step, "trust" or "reissue"
+}
+
+// PKIExportRequest is a structure that models request to export cluster PKI.
+type PKIExportRequest struct {
+	Passphrase string `json:"passphrase" yaml:"passphrase"` // Passphrase used to encrypt the bundle
+}
+
+// PKIBundle is a structure that models encrypted bundle with cluster PKI.
+type PKIBundle struct {
+	Bundle []byte `json:"bundle" yaml:"bundle"` // Encrypted CA and service certificates with private keys
+}
+
+// PKIImportRequest is a structure that models request to import cluster PKI from the encrypted bundle.
+type PKIImportRequest struct {
+	Bundle     []byte `json:"bundle" yaml:"bundle"`         // Bundle produced by PKI export
+	Passphrase string `json:"passphrase" yaml:"passphrase"` // Passphrase used to encrypt the bundle
+}
+
+// ClientCertificate is a structure that describes client certificate issued for an external client of OVN databases.
+type ClientCertificate struct {
+	CN        string     `json:"cn" yaml:"cn"`                                   // CN of the certificate
+	Serial    string     `json:"serial" yaml:"serial"`                           // Hexadecimal serial number of the certificate
+	NotAfter  time.Time  `json:"notAfter" yaml:"notAfter"`                       // Expiry of the certificate
+	Revoked   bool       `json:"revoked" yaml:"revoked"`                         // Certificate was revoked
+	RevokedAt *time.Time `json:"revokedAt,omitempty" yaml:"revokedAt,omitempty"` // Time of the revocation
+
+	AuthorityKeyID string `json:"authorityKeyId,omitempty" yaml:"authorityKeyId,omitempty"` // Hexadecimal key ID of the issuing CA
+}
+
+// ReissuedClientCertificate is a structure that holds client certificate issued to replace one that is no longer
+// trusted after its CA was rotated.
+type ReissuedClientCertificate struct {
+	CN             string `json:"cn" yaml:"cn"`                         // CN of the certificate
+	PreviousSerial string `json:"previousSerial" yaml:"previousSerial"` // Hexadecimal serial number of the replaced certificate
+	Cert           string `json:"cert" yaml:"cert"`                     // PEM encoded client certificate
+	Key            string `json:"key" yaml:"key"`                       // PEM encoded client private key
+}
@@ -0,0 +1,28 @@
+// Package types provides shared types and structs.
+package types
+
+// ChassisInfo is a structure that describes OVN chassis registered in the OVN Southbound database.
+type ChassisInfo struct {
+	Name     string         `json:"name" yaml:"name"`         // Name (system-id) of the chassis
+	Hostname string         `json:"hostname" yaml:"hostname"` // Hostname reported by the chassis
+	Member   string         `json:"member" yaml:"member"`     // MicroOVN member that owns the chassis, empty if unknown
+	Encaps   []ChassisEncap `json:"encaps" yaml:"encaps"`     // Tunnel encapsulations supported by the chassis
+	Ports    []ChassisPort  `json:"ports" yaml:"ports"`       // Logical ports bound to the chassis
+
+	Labels map[string]string `json:"labels" yaml:"labels"` // Labels attached to the owning member
+
+	Gateway           bool           `json:"gateway" yaml:"gateway"`                     // Chassis is a gateway candidate
+	GatewayPriorities map[string]int `json:"gatewayPriorities" yaml:"gatewayPriorities"` // Priority of the chassis in each HA chassis group it belongs to
+}
+
+// ChassisEncap is a structure that describes single tunnel encapsulation of OVN chassis.
+type ChassisEncap struct {
+	Type string `json:"type" yaml:"type"` // Encapsulation type, e.g. "geneve"
+	IP   string `json:"ip" yaml:"ip"`     // Tunnel endpoint IP address
+}
+
+// ChassisPort is a structure that describes logical port bound to OVN chassis.
+type ChassisPort struct {
+	Name string `json:"name" yaml:"name"` // Name of the logical port
+	Type string `json:"type" yaml:"type"` // Type of the port binding, e.g. "chassisredirect" for gateway ports. Empty for VIFs.
+}
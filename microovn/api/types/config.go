@@ -0,0 +1,18 @@
+// Package types provides shared types and structs.
+package types
+
+// ConfigValue is a structure that models value of a single MicroOVN configuration option.
+type ConfigValue struct {
+	Key   string `json:"key" yaml:"key"`     // Name of the configuration option
+	Value string `json:"value" yaml:"value"` // Value of the configuration option. Empty if not set.
+}
+
+// EffectiveConfigValue is a structure that compares value of OVN/OVS setting derived from the stored MicroOVN
+// configuration with the value currently applied to the local services.
+type EffectiveConfigValue struct {
+	Key      string `json:"key" yaml:"key"`           // Name of the setting
+	Stored   string `json:"stored" yaml:"stored"`     // Value derived from the stored configuration. Empty if not set.
+	Live     string `json:"live" yaml:"live"`         // Value applied to the local service
+	Diverged bool   `json:"diverged" yaml:"diverged"` // Live value does not match the stored one
+	Error    string `json:"error" yaml:"error"`       // Reason why the live value couldn't be read
+}
@@ -0,0 +1,11 @@
+// Package types provides shared types and structs.
+package types
+
+// LXDConnectionInfo is a structure that holds information required by LXD to use MicroOVN as its OVN
+// backend. Field names match names of LXD server configuration options.
+type LXDConnectionInfo struct {
+	NorthboundConnection string `json:"network.ovn.northbound_connection" yaml:"network.ovn.northbound_connection"` // OVN NB connect string
+	CACert               string `json:"network.ovn.ca_cert" yaml:"network.ovn.ca_cert"`                             // PEM encoded CA certificate
+	ClientCert           string `json:"network.ovn.client_cert,omitempty" yaml:"network.ovn.client_cert,omitempty"` // PEM encoded client certificate
+	ClientKey            string `json:"network.ovn.client_key,omitempty" yaml:"network.ovn.client_key,omitempty"`   // PEM encoded client private key
+}
@@ -0,0 +1,58 @@
+// Package types provides shared types and structs.
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ReportVersion is a version of the format of machine-readable reports produced by MarshalReport. It's
+// increased whenever a field of a reported structure is renamed, removed or changes its meaning. Adding new
+// fields does not change the version.
+const ReportVersion = 1
+
+// Kinds of machine-readable reports.
+const (
+	ReportKindStatus          = "status"           // Data is a map of member names to MemberStatus
+	ReportKindHealth          = "health"           // Data is a HealthStatus
+	ReportKindEffectiveConfig = "effective-config" // Data is a list of EffectiveConfigValue
+	ReportKindTopology        = "topology"         // Data is a Topology
+)
+
+// Report is a structure that wraps data returned by MicroOVN APIs into a versioned machine-readable
+// document.
+type Report struct {
+	Version int    `json:"version" yaml:"version"` // Version of the report format, see ReportVersion
+	Kind    string `json:"kind" yaml:"kind"`       // Kind of the reported data
+	Data    any    `json:"data" yaml:"data"`       // Reported data
+}
+
+// MarshalReport returns JSON document with "data" of the report "kind", wrapped in Report.
+func MarshalReport(kind string, data any) ([]byte, error) {
+	return json.Marshal(Report{Version: ReportVersion, Kind: kind, Data: data})
+}
+
+// UnmarshalReport parses JSON "document" produced by MarshalReport and stores its data in the value pointed
+// to by "data". Error is returned if the document has different "kind" or unsupported version.
+func UnmarshalReport(document []byte, kind string, data any) error {
+	report := struct {
+		Version int             `json:"version"`
+		Kind    string          `json:"kind"`
+		Data    json.RawMessage `json:"data"`
+	}{}
+
+	err := json.Unmarshal(document, &report)
+	if err != nil {
+		return err
+	}
+
+	if report.Version != ReportVersion {
+		return fmt.Errorf("unsupported report version %d", report.Version)
+	}
+
+	if report.Kind != kind {
+		return fmt.Errorf("expected report of kind '%s', got '%s'", kind, report.Kind)
+	}
+
+	return json.Unmarshal(report.Data, data)
+}
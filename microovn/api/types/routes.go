@@ -0,0 +1,11 @@
+// Package types provides shared types and structs.
+package types
+
+// StaticRoute is a structure that describes single static route of OVN logical router. Multiple routes with
+// the same prefix and policy, but different next hops, form an ECMP route.
+type StaticRoute struct {
+	Prefix     string `json:"prefix" yaml:"prefix"`                             // Destination (or source, see Policy) IP prefix
+	NextHop    string `json:"nextHop" yaml:"nextHop"`                           // IP address of the next hop, or "discard"
+	OutputPort string `json:"outputPort,omitempty" yaml:"outputPort,omitempty"` // Logical router port used to reach the next hop
+	Policy     string `json:"policy,omitempty" yaml:"policy,omitempty"`         // "dst-ip" (default) or "src-ip"
+}
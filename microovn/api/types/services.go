@@ -6,3 +6,61 @@ type Service struct {
 	Service  string `json:"service" yaml:"service"`
 	Location string `json:"location" yaml:"location"`
 }
+
+// LocalServicesState is a structure used to request change of state of all OVN services enabled on a cluster member.
+type LocalServicesState struct {
+	Running bool `json:"running" yaml:"running"` // Desired state of the services
+}
+
+// LeaveRequest is a structure used to request coordinated removal of multiple cluster members.
+type LeaveRequest struct {
+	Members []string `json:"members" yaml:"members"` // Names of members to remove
+}
+
+// MemberAddress is a structure used to notify cluster member about change of its network address.
+type MemberAddress struct {
+	Address string `json:"address" yaml:"address"` // New address of the member that OVN services move to
+}
+
+// MemberLabel is a structure that models single label attached to a cluster member.
+type MemberLabel struct {
+	Key   string `json:"key" yaml:"key"`     // Key of the label
+	Value string `json:"value" yaml:"value"` // Value of the label. Empty value removes the label.
+}
+
+// SBRejoinRequest is a structure used to request cluster member to join OVN SB database rebuilt on the "Seed" member.
+type SBRejoinRequest struct {
+	Seed string `json:"seed" yaml:"seed"` // Name of the member that rebuilt SB database
+}
+
+// RecoverRequest is a structure used to request recovery of OVN NB and SB databases from a backup.
+type RecoverRequest struct {
+	Backup    string `json:"backup" yaml:"backup"`       // Name of the backup directory, or absolute path to it
+	Timestamp int64  `json:"timestamp" yaml:"timestamp"` // Creation time of the backup, used if Backup is empty
+}
+
+// CentralScaleRequest is a structure used to request reduction of the number of members that run the "central" service.
+type CentralScaleRequest struct {
+	Target int  `json:"target" yaml:"target"` // Desired number of members running the "central" service
+	Force  bool `json:"force" yaml:"force"`   // Allow target that does not provide high availability
+}
+
+// CentralScaleResult is a structure that lists members whose "central" service was removed by scaling.
+type CentralScaleResult struct {
+	Demoted []string `json:"demoted" yaml:"demoted"` // Members that no longer run the "central" service
+}
+
+// DatabaseWritable is a structure that reports whether OVN database accepts writes.
+type DatabaseWritable struct {
+	Database string `json:"database" yaml:"database"` // Name of the database ("nb" or "sb")
+	Writable bool   `json:"writable" yaml:"writable"` // Database has quorum and is not in read-only mode
+}
+
+// DatabaseEndpointCheck is a structure that describes result of OVSDB handshake with OVN database endpoint.
+type DatabaseEndpointCheck struct {
+	Endpoint  string   `json:"endpoint" yaml:"endpoint"`   // OVN connection string of the endpoint
+	Expected  string   `json:"expected" yaml:"expected"`   // Database that the endpoint should serve
+	Databases []string `json:"databases" yaml:"databases"` // Databases served by the endpoint
+	OK        bool     `json:"ok" yaml:"ok"`               // Endpoint serves the expected database
+	Error     string   `json:"error" yaml:"error"`         // Reason why the check failed
+}
@@ -0,0 +1,138 @@
+// Package types provides shared types and structs.
+package types
+
+// MemberStatus is a structure that describes state of OVN components running on a single MicroOVN
+// cluster member.
+type MemberStatus struct {
+	Central CentralStatus `json:"central" yaml:"central"` // Status of local OVN central services
+	TLS     TLSStatus     `json:"tls" yaml:"tls"`         // Status of TLS configuration on the member
+	Switch  SwitchStatus  `json:"switch" yaml:"switch"`   // Status of local OVS instance
+
+	Environment EnvironmentStatus `json:"environment" yaml:"environment"` // Status of the local ovn.env file
+	Chassis     ChassisStatus     `json:"chassis" yaml:"chassis"`         // Status of local OVN chassis
+
+	Labels map[string]string `json:"labels" yaml:"labels"` // Labels attached to the member
+
+	Errors []string `json:"errors,omitempty" yaml:"errors,omitempty"` // Probes that failed, fields they fill are left empty
+}
+
+// CentralStatus is a structure that describes configuration currently applied to the local OVN central services.
+type CentralStatus struct {
+	NorthdThreads  string `json:"northdThreads" yaml:"northdThreads"`   // Number of threads used by ovn-northd
+	NorthdState    string `json:"northdState" yaml:"northdState"`       // State of local ovn-northd (active, standby, paused or external)
+	NorthdStandby  bool   `json:"northdStandby" yaml:"northdStandby"`   // Local ovn-northd is held back in standby
+	NBReadOnly     bool   `json:"nbReadOnly" yaml:"nbReadOnly"`         // OVN NB database rejects writes from remote clients
+	NBDatabaseSize int64  `json:"nbDatabaseSize" yaml:"nbDatabaseSize"` // On-disk size of OVN NB database in bytes
+	SBDatabaseSize int64  `json:"sbDatabaseSize" yaml:"sbDatabaseSize"` // On-disk size of OVN SB database in bytes
+	NBMemoryRSS    int64  `json:"nbMemoryRss" yaml:"nbMemoryRss"`       // Resident memory of OVN NB database server in bytes
+	SBMemoryRSS    int64  `json:"sbMemoryRss" yaml:"sbMemoryRss"`       // Resident memory of OVN SB database server in bytes
+	MemoryLimit    string `json:"memoryLimit" yaml:"memoryLimit"`       // Memory usage (MiB) that triggers compaction
+	MemoryTotal    int64  `json:"memoryTotal" yaml:"memoryTotal"`       // Amount of RAM on the member in bytes
+	NBState        string `json:"nbState" yaml:"nbState"`               // Raft state of local OVN NB database
+	SBState        string `json:"sbState" yaml:"sbState"`               // Raft state of local OVN SB database
+
+	PreferredLeaders []string `json:"preferredLeaders" yaml:"preferredLeaders"` // Members preferred as NB/SB raft leaders
+	NBLeader         string   `json:"nbLeader" yaml:"nbLeader"`                 // Member that leads OVN NB raft cluster
+	SBLeader         string   `json:"sbLeader" yaml:"sbLeader"`                 // Member that leads OVN SB raft cluster
+}
+
+// ChassisStatus is a structure that describes configuration currently applied to the local OVN chassis.
+type ChassisStatus struct {
+	EncapIP  string `json:"encapIp" yaml:"encapIp"`   // IP address used for OVN tunnel traffic
+	EncapTos string `json:"encapTos" yaml:"encapTos"` // ToS/DSCP value applied to OVN tunnel traffic
+
+	RemoteProbeInterval string `json:"remoteProbeInterval" yaml:"remoteProbeInterval"` // Interval (ms) of probes towards OVN SB
+	MonitorAll          string `json:"monitorAll" yaml:"monitorAll"`                   // Chassis monitors whole OVN SB, empty if not set
+	GatewayPriority     string `json:"gatewayPriority" yaml:"gatewayPriority"`         // Configured gateway priority, empty if chassis is not a gateway candidate
+
+	Paused bool `json:"paused" yaml:"paused"` // ovn-controller is paused and does not install new flows
+}
+
+// SwitchStatus is a structure that describes state of the local OVS instance run by the "switch" service.
+type SwitchStatus struct {
+	Enabled                bool   `json:"enabled" yaml:"enabled"`                               // "switch" service is enabled on the member
+	OvsdbRunning           bool   `json:"ovsdbRunning" yaml:"ovsdbRunning"`                     // OVS database server responds
+	VswitchdRunning        bool   `json:"vswitchdRunning" yaml:"vswitchdRunning"`               // ovs-vswitchd responds
+	IntegrationBridge      string `json:"integrationBridge" yaml:"integrationBridge"`           // Name of the integration bridge, empty if it doesn't exist
+	DatapathType           string `json:"datapathType" yaml:"datapathType"`                     // Datapath type of the integration bridge
+	ConfiguredDatapathType string `json:"configuredDatapathType" yaml:"configuredDatapathType"` // Datapath type that new integration bridge is created with
+	Sampling               string `json:"sampling" yaml:"sampling"`                             // Active traffic sampling protocol, empty if disabled
+}
+
+// EnvironmentStatus is a structure that describes whether ovn.env file on the member matches current cluster state.
+type EnvironmentStatus struct {
+	InSync bool   `json:"inSync" yaml:"inSync"`                 // ovn.env content matches current cluster state
+	Diff   string `json:"diff,omitempty" yaml:"diff,omitempty"` // Differences between ovn.env and expected content
+
+	Stale []EnvironmentDrift `json:"stale,omitempty" yaml:"stale,omitempty"` // Running daemons that don't use configuration from ovn.env
+}
+
+// EnvironmentDrift is a structure that describes single setting of a running daemon that differs from ovn.env.
+type EnvironmentDrift struct {
+	Service  string `json:"service" yaml:"service"`   // Service that runs the daemon and needs to be restarted
+	Daemon   string `json:"daemon" yaml:"daemon"`     // Name of the daemon
+	Setting  string `json:"setting" yaml:"setting"`   // Setting that differs, "ovn.env" if the daemon started before ovn.env changed
+	Expected string `json:"expected" yaml:"expected"` // Value from ovn.env, or its modification time
+	Live     string `json:"live" yaml:"live"`         // Value used by the daemon, or its start time
+}
+
+// TLSStatus is a structure that describes TLS configuration of a single MicroOVN cluster member.
+type TLSStatus struct {
+	Protocol            string   `json:"protocol" yaml:"protocol"`                                           // Protocol that member expects OVN services to use
+	ListenProtocol      string   `json:"listenProtocol,omitempty" yaml:"listenProtocol,omitempty"`           // Protocol that local OVN databases listen on
+	MissingCertificates []string `json:"missingCertificates,omitempty" yaml:"missingCertificates,omitempty"` // Expected certificate files that are missing
+	CertificateProblems []string `json:"certificateProblems,omitempty" yaml:"certificateProblems,omitempty"` // Problems found when verifying certificate chain
+}
+
+// NorthdStatus is a structure that describes state of ovn-northd instance running on the member.
+type NorthdStatus struct {
+	State    string `json:"state" yaml:"state"`       // State of ovn-northd (active, standby, paused or external)
+	HeldBack bool   `json:"heldBack" yaml:"heldBack"` // Instance is configured to stay in standby
+}
+
+// NorthdSyncRequest is a structure used to request waiting until ovn-northd propagates NB changes into SB.
+type NorthdSyncRequest struct {
+	Timeout int `json:"timeout" yaml:"timeout"` // Maximum time to wait, in seconds
+}
+
+// NorthdPauseRequest is a structure used to request pausing of ovn-northd for a limited time.
+type NorthdPauseRequest struct {
+	Timeout int `json:"timeout" yaml:"timeout"` // Time after which ovn-northd is resumed automatically, in seconds
+}
+
+// HealthStatus is a structure that describes whether OVN databases of a member are able to serve clients.
+type HealthStatus struct {
+	Healthy   bool             `json:"healthy" yaml:"healthy"`     // All databases of the member are healthy
+	Databases []DatabaseHealth `json:"databases" yaml:"databases"` // Health of individual databases
+
+	CentralMembers   int  `json:"centralMembers" yaml:"centralMembers"`     // Number of members that run the "central" service
+	ExpectedCentrals int  `json:"expectedCentrals" yaml:"expectedCentrals"` // Declared number of central members, 0 if not declared
+	UnderProvisioned bool `json:"underProvisioned" yaml:"underProvisioned"` // Fewer central members than declared, not a failure of the member
+}
+
+// DatabaseHealth is a structure that describes whether a single OVN database of a member is able to serve clients.
+type DatabaseHealth struct {
+	Database string `json:"database" yaml:"database"`                 // Name of the database ("nb" or "sb")
+	Healthy  bool   `json:"healthy" yaml:"healthy"`                   // Database is joined to a cluster with quorum
+	Reason   string `json:"reason,omitempty" yaml:"reason,omitempty"` // Why the database is not healthy
+}
+
+// SocketStatus is a structure that describes control socket of a local OVN or OVS daemon.
+type SocketStatus struct {
+	Path       string `json:"path" yaml:"path"`                       // Path to the control socket
+	Exists     bool   `json:"exists" yaml:"exists"`                   // Control socket exists
+	Responding bool   `json:"responding" yaml:"responding"`           // Daemon responds to commands sent to the socket
+	Error      string `json:"error,omitempty" yaml:"error,omitempty"` // Reason why the daemon doesn't respond
+}
+
+// Footprint is a structure that describes resources used by OVN across the whole cluster.
+type Footprint struct {
+	NBDatabaseSize int64 `json:"nbDatabaseSize" yaml:"nbDatabaseSize"` // Total on-disk size of OVN NB database on all central members in bytes
+	SBDatabaseSize int64 `json:"sbDatabaseSize" yaml:"sbDatabaseSize"` // Total on-disk size of OVN SB database on all central members in bytes
+	CentralMembers int   `json:"centralMembers" yaml:"centralMembers"` // Number of central members whose database sizes are included
+	Chassis        int   `json:"chassis" yaml:"chassis"`               // Number of chassis registered in OVN SB
+	LogicalFlows   int   `json:"logicalFlows" yaml:"logicalFlows"`     // Number of logical flows in OVN SB
+	Ports          int   `json:"ports" yaml:"ports"`                   // Number of logical ports bound in OVN SB
+
+	Unreachable []string `json:"unreachable,omitempty" yaml:"unreachable,omitempty"` // Central members whose database sizes could not be retrieved
+}
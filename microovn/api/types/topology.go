@@ -0,0 +1,22 @@
+// Package types provides shared types and structs.
+package types
+
+// Topology is a structure that describes layout of OVN services in a MicroOVN cluster.
+type Topology struct {
+	Members []TopologyMember `json:"members" yaml:"members"` // Cluster members, sorted by name
+}
+
+// TopologyMember is a structure that describes services running on a single cluster member.
+type TopologyMember struct {
+	Name     string   `json:"name" yaml:"name"`                           // Name of the member
+	Address  string   `json:"address,omitempty" yaml:"address,omitempty"` // Management address of the member, not validated if empty
+	Services []string `json:"services" yaml:"services"`                   // Services running on the member, sorted by name
+}
+
+// TopologyDiff is a structure that describes single difference between expected and actual cluster topology.
+type TopologyDiff struct {
+	Member   string `json:"member" yaml:"member"`     // Name of the member that differs
+	Field    string `json:"field" yaml:"field"`       // Differing property of the member ("member", "address" or "services")
+	Expected string `json:"expected" yaml:"expected"` // Value described by the expected topology
+	Actual   string `json:"actual" yaml:"actual"`     // Value found in the cluster
+}
@@ -3,6 +3,7 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -73,3 +74,737 @@ func RegenerateCA(ctx context.Context, c *client.Client) (types.RegenerateCaResp
 	return *response, nil
 
 }
+
+// RotateCA requests cluster member to replace cluster CA with a new one and reissue all OVN service
+// certificates across the cluster, keeping the previous CA trusted until every member uses the new one.
+// Client certificates issued for external clients that are no longer trusted after the rotation are returned.
+func RotateCA(ctx context.Context, c *client.Client) ([]types.ClientCertificate, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Minute*5)
+	defer cancel()
+
+	untrusted := []types.ClientCertificate{}
+	err := c.Query(queryCtx, "POST", api.NewURL().Path("ca", "rotate"), nil, &untrusted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate CA: %w", err)
+	}
+
+	return untrusted, nil
+}
+
+// ApplyCARotationStep requests cluster member to execute single "step" of CA rotation.
+func ApplyCARotationStep(ctx context.Context, c *client.Client, step string) error {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	data := types.CARotationStepRequest{Step: step}
+
+	err := c.Query(queryCtx, "POST", api.NewURL().Path("ca", "rotate", "step"), data, nil)
+	if err != nil {
+		return fmt.Errorf("failed to execute CA rotation step '%s': %w", step, err)
+	}
+
+	return nil
+}
+
+// ListClientCerts returns client certificates issued for external clients of OVN databases.
+func ListClientCerts(ctx context.Context, c *client.Client) ([]types.ClientCertificate, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	certs := []types.ClientCertificate{}
+
+	err := c.Query(queryCtx, "GET", api.NewURL().Path("client-certificates"), nil, &certs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list client certificates: %w", err)
+	}
+
+	return certs, nil
+}
+
+// RevokeClientCert requests cluster member to revoke client certificate with hexadecimal serial number "serial".
+// Revocation rotates cluster CA, certificates reissued for the remaining external clients are returned.
+func RevokeClientCert(ctx context.Context, c *client.Client, serial string) ([]types.ReissuedClientCertificate, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Minute*5)
+	defer cancel()
+
+	reissued := []types.ReissuedClientCertificate{}
+	err := c.Query(queryCtx, "DELETE", api.NewURL().Path("client-certificates", serial), nil, &reissued)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke client certificate '%s': %w", serial, err)
+	}
+
+	return reissued, nil
+}
+
+// GetClusterFootprint returns resources used by OVN across the whole cluster. Client must target a member
+// that runs the "central" service.
+func GetClusterFootprint(ctx context.Context, c *client.Client) (types.Footprint, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	footprint := types.Footprint{}
+
+	err := c.Query(queryCtx, "GET", api.NewURL().Path("footprint"), nil, &footprint)
+	if err != nil {
+		return types.Footprint{}, fmt.Errorf("failed to get cluster footprint: %w", err)
+	}
+
+	return footprint, nil
+}
+
+// GetStatus returns state of OVN components running on the cluster member targeted by the client.
+func GetStatus(ctx context.Context, c *client.Client) (types.MemberStatus, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	status := types.MemberStatus{}
+
+	err := c.Query(queryCtx, "GET", api.NewURL().Path("status"), nil, &status)
+	if err != nil {
+		return status, fmt.Errorf("failed to get member status: %w", err)
+	}
+
+	return status, nil
+}
+
+// HealthCheck returns health of OVN databases of the cluster member targeted by the client.
+func HealthCheck(ctx context.Context, c *client.Client) (types.HealthStatus, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	health := types.HealthStatus{}
+
+	err := c.Query(queryCtx, "GET", api.NewURL().Path("health"), nil, &health)
+	if err != nil {
+		return health, fmt.Errorf("failed to get member health: %w", err)
+	}
+
+	return health, nil
+}
+
+// ControlSockets returns status of control sockets of OVN and OVS daemons running on the cluster member
+// targeted by the client, keyed by daemon name.
+func ControlSockets(ctx context.Context, c *client.Client) (map[string]types.SocketStatus, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	sockets := map[string]types.SocketStatus{}
+
+	err := c.Query(queryCtx, "GET", api.NewURL().Path("control-sockets"), nil, &sockets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get control sockets: %w", err)
+	}
+
+	return sockets, nil
+}
+
+// GetConfig returns current value of MicroOVN configuration option "key".
+func GetConfig(ctx context.Context, c *client.Client, key string) (types.ConfigValue, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	value := types.ConfigValue{}
+
+	err := c.Query(queryCtx, "GET", api.NewURL().Path("config", key), nil, &value)
+	if err != nil {
+		return value, fmt.Errorf("failed to get configuration option: %w", err)
+	}
+
+	return value, nil
+}
+
+// ListConfig returns current values of all MicroOVN configuration options.
+func ListConfig(ctx context.Context, c *client.Client) ([]types.ConfigValue, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	values := []types.ConfigValue{}
+
+	err := c.Query(queryCtx, "GET", api.NewURL().Path("config"), nil, &values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list configuration options: %w", err)
+	}
+
+	return values, nil
+}
+
+// GetEffectiveConfig returns OVN/OVS settings of the cluster member, comparing values derived from the stored
+// configuration with values currently applied to its services.
+func GetEffectiveConfig(ctx context.Context, c *client.Client) ([]types.EffectiveConfigValue, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	values := []types.EffectiveConfigValue{}
+
+	err := c.Query(queryCtx, "GET", api.NewURL().Path("effective-config"), nil, &values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get effective configuration: %w", err)
+	}
+
+	return values, nil
+}
+
+// SetConfig stores new value of MicroOVN configuration option "key" and applies it. Empty value resets
+// the option to its default.
+func SetConfig(ctx context.Context, c *client.Client, key string, value string) error {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*60)
+	defer cancel()
+
+	data := types.ConfigValue{Key: key, Value: value}
+
+	err := c.Query(queryCtx, "PUT", api.NewURL().Path("config", key), data, nil)
+	if err != nil {
+		return fmt.Errorf("failed to set configuration option: %w", err)
+	}
+
+	return nil
+}
+
+// ApplyConfig requests cluster member to apply current value of MicroOVN configuration option "key".
+func ApplyConfig(ctx context.Context, c *client.Client, key string) error {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	err := c.Query(queryCtx, "POST", api.NewURL().Path("config", key), nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to apply configuration option: %w", err)
+	}
+
+	return nil
+}
+
+// SetLocalServicesRunning starts or stops all OVN services enabled on the cluster member, without changing
+// its cluster membership.
+func SetLocalServicesRunning(ctx context.Context, c *client.Client, running bool) error {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*60)
+	defer cancel()
+
+	data := types.LocalServicesState{Running: running}
+
+	err := c.Query(queryCtx, "PUT", api.NewURL().Path("services", "local"), data, nil)
+	if err != nil {
+		return fmt.Errorf("failed to change state of local services: %w", err)
+	}
+
+	return nil
+}
+
+// LeaveMany removes "members" from the cluster in an order that preserves quorum of OVN databases.
+func LeaveMany(ctx context.Context, c *client.Client, members []string) error {
+	data := types.LeaveRequest{Members: members}
+
+	err := c.Query(ctx, "POST", api.NewURL().Path("leave"), data, nil)
+	if err != nil {
+		return fmt.Errorf("failed to remove cluster members: %w", err)
+	}
+
+	return nil
+}
+
+// GetLXDConnectionInfo returns information required by LXD to use MicroOVN as its OVN backend. If
+// "withClientCert" is true, new client certificate for LXD is issued and included in the response.
+func GetLXDConnectionInfo(ctx context.Context, c *client.Client, withClientCert bool) (types.LXDConnectionInfo, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	method := "GET"
+	if withClientCert {
+		method = "POST"
+	}
+
+	info := types.LXDConnectionInfo{}
+	err := c.Query(queryCtx, method, api.NewURL().Path("integrations", "lxd"), nil, &info)
+	if err != nil {
+		return info, fmt.Errorf("failed to get LXD connection information: %w", err)
+	}
+
+	return info, nil
+}
+
+// UpdateMemberAddress notifies cluster "member" that its network address changed to "address". Client must
+// target the member whose address changed. The member moves its OVN services to the new address and asks
+// other members to refresh their configuration. Management address of the member is not changed.
+func UpdateMemberAddress(ctx context.Context, c *client.Client, member string, address string) error {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*180)
+	defer cancel()
+
+	data := types.MemberAddress{Address: address}
+
+	err := c.Query(queryCtx, "PUT", api.NewURL().Path("members", member, "address"), data, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update member address: %w", err)
+	}
+
+	return nil
+}
+
+// Refresh requests cluster member to regenerate its OVN configuration and restart affected services.
+func Refresh(ctx context.Context, c *client.Client) error {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	err := c.Query(queryCtx, "POST", api.NewURL().Path("refresh"), nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to refresh configuration: %w", err)
+	}
+
+	return nil
+}
+
+// RegenerateEnvironment requests cluster member to regenerate its ovn.env file. Services are not restarted.
+func RegenerateEnvironment(ctx context.Context, c *client.Client) error {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	err := c.Query(queryCtx, "POST", api.NewURL().Path("environment"), nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to regenerate environment: %w", err)
+	}
+
+	return nil
+}
+
+// RegenerateEnvironmentAll requests cluster member to regenerate ovn.env file on every cluster member.
+func RegenerateEnvironmentAll(ctx context.Context, c *client.Client) error {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*60)
+	defer cancel()
+
+	err := c.Query(queryCtx, "POST", api.NewURL().Path("environment", "all"), nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to regenerate environment on cluster members: %w", err)
+	}
+
+	return nil
+}
+
+// ScaleCentral requests cluster member to reduce number of members that run the "central" service to
+// "target". Client must target a member that runs the "central" service. Names of members whose "central"
+// service was removed are returned.
+func ScaleCentral(ctx context.Context, c *client.Client, target int, force bool) ([]string, error) {
+	data := types.CentralScaleRequest{Target: target, Force: force}
+	result := types.CentralScaleResult{}
+
+	err := c.Query(ctx, "POST", api.NewURL().Path("central", "scale"), data, &result)
+	if err != nil {
+		return result.Demoted, fmt.Errorf("failed to scale central service: %w", err)
+	}
+
+	return result.Demoted, nil
+}
+
+// DemoteCentral requests cluster member to stop running the "central" service while staying in the cluster.
+func DemoteCentral(ctx context.Context, c *client.Client) error {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*180)
+	defer cancel()
+
+	err := c.Query(queryCtx, "POST", api.NewURL().Path("central", "demote"), nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to remove central service: %w", err)
+	}
+
+	return nil
+}
+
+// SafeToRemove returns, for every member that runs the "central" service, whether it can be removed at this
+// moment without OVN NB or SB cluster losing quorum.
+func SafeToRemove(ctx context.Context, c *client.Client) (map[string]bool, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*60)
+	defer cancel()
+
+	result := map[string]bool{}
+
+	err := c.Query(queryCtx, "GET", api.NewURL().Path("central", "safe-to-remove"), nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check which central members are safe to remove: %w", err)
+	}
+
+	return result, nil
+}
+
+// CanWrite returns true if OVN database "db" ("nb" or "sb") currently accepts writes, as seen by the
+// cluster member.
+func CanWrite(ctx context.Context, c *client.Client, db string) (bool, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	result := types.DatabaseWritable{}
+
+	err := c.Query(queryCtx, "GET", api.NewURL().Path("databases", db, "writable"), nil, &result)
+	if err != nil {
+		return false, fmt.Errorf("failed to check whether database is writable: %w", err)
+	}
+
+	return result.Writable, nil
+}
+
+// GetNorthdStatus returns state of ovn-northd running on the cluster member. Client must target a member
+// that runs the "central" service.
+func GetNorthdStatus(ctx context.Context, c *client.Client) (*types.NorthdStatus, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	status := types.NorthdStatus{}
+
+	err := c.Query(queryCtx, "GET", api.NewURL().Path("northd"), nil, &status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ovn-northd status: %w", err)
+	}
+
+	return &status, nil
+}
+
+// WaitForNorthdSync requests cluster member to wait until ovn-northd propagates all changes made to OVN NB
+// database so far into OVN SB database. Client must target a member that runs the "central" service.
+func WaitForNorthdSync(ctx context.Context, c *client.Client, timeout time.Duration) error {
+	queryCtx, cancel := context.WithTimeout(ctx, timeout+time.Second*5)
+	defer cancel()
+
+	req := types.NorthdSyncRequest{Timeout: int(timeout.Seconds())}
+
+	err := c.Query(queryCtx, "POST", api.NewURL().Path("northd", "sync"), req, nil)
+	if err != nil {
+		return fmt.Errorf("failed to wait for ovn-northd: %w", err)
+	}
+
+	return nil
+}
+
+// PauseNorthd requests cluster member to pause its ovn-northd. The ovn-northd is resumed automatically after
+// "timeout", unless ResumeNorthd is called sooner.
+func PauseNorthd(ctx context.Context, c *client.Client, timeout time.Duration) error {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	req := types.NorthdPauseRequest{Timeout: int(timeout.Seconds())}
+
+	err := c.Query(queryCtx, "POST", api.NewURL().Path("northd", "pause"), req, nil)
+	if err != nil {
+		return fmt.Errorf("failed to pause ovn-northd: %w", err)
+	}
+
+	return nil
+}
+
+// ResumeNorthd requests cluster member to resume its ovn-northd paused by PauseNorthd.
+func ResumeNorthd(ctx context.Context, c *client.Client) error {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	err := c.Query(queryCtx, "POST", api.NewURL().Path("northd", "resume"), nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to resume ovn-northd: %w", err)
+	}
+
+	return nil
+}
+
+// TestDatabaseConnections requests cluster member to perform OVSDB handshake with NB and SB servers of every
+// "central" member and returns the result for each endpoint.
+func TestDatabaseConnections(ctx context.Context, c *client.Client) ([]types.DatabaseEndpointCheck, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*60)
+	defer cancel()
+
+	checks := []types.DatabaseEndpointCheck{}
+
+	err := c.Query(queryCtx, "GET", api.NewURL().Path("databases", "connections"), nil, &checks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to test database connections: %w", err)
+	}
+
+	return checks, nil
+}
+
+// RemoveServiceEntry removes record of "service" on cluster "member" from the services table, without
+// affecting the member itself.
+func RemoveServiceEntry(ctx context.Context, c *client.Client, member string, service string) error {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*60)
+	defer cancel()
+
+	err := c.Query(queryCtx, "DELETE", api.NewURL().Path("services", member, service), nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to remove service record: %w", err)
+	}
+
+	return nil
+}
+
+// ExportPKI requests cluster member to export cluster CA and certificates of its services in a bundle
+// encrypted with "passphrase".
+func ExportPKI(ctx context.Context, c *client.Client, passphrase string) ([]byte, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	data := types.PKIExportRequest{Passphrase: passphrase}
+	result := types.PKIBundle{}
+
+	err := c.Query(queryCtx, "POST", api.NewURL().Path("pki", "export"), data, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export PKI: %w", err)
+	}
+
+	return result.Bundle, nil
+}
+
+// ImportPKI requests cluster member to replace cluster CA with the one from "bundle", encrypted with
+// "passphrase", and to restore certificates of its services.
+func ImportPKI(ctx context.Context, c *client.Client, bundle []byte, passphrase string) error {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*60)
+	defer cancel()
+
+	data := types.PKIImportRequest{Bundle: bundle, Passphrase: passphrase}
+
+	err := c.Query(queryCtx, "POST", api.NewURL().Path("pki", "import"), data, nil)
+	if err != nil {
+		return fmt.Errorf("failed to import PKI: %w", err)
+	}
+
+	return nil
+}
+
+// ResetNode requests member, whose join failed, to return to a clean pre-join state.
+func ResetNode(ctx context.Context, c *client.Client) error {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*300)
+	defer cancel()
+
+	err := c.Query(queryCtx, "POST", api.NewURL().Path("reset"), nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to reset member: %w", err)
+	}
+
+	return nil
+}
+
+// ListBackups returns backups of MicroOVN data present on the cluster member, along with information whether
+// they can be used to recover OVN databases.
+func ListBackups(ctx context.Context, c *client.Client) ([]types.BackupInfo, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	backups := []types.BackupInfo{}
+
+	err := c.Query(queryCtx, "GET", api.NewURL().Path("backups"), nil, &backups)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backups: %w", err)
+	}
+
+	return backups, nil
+}
+
+// Recover requests cluster member to recover OVN NB and SB databases from the backup "backup" after data of
+// every central member was lost. Client must target a member that runs the "central" service.
+func Recover(ctx context.Context, c *client.Client, backup string) error {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*120)
+	defer cancel()
+
+	data := types.RecoverRequest{Backup: backup}
+
+	err := c.Query(queryCtx, "POST", api.NewURL().Path("central", "recover"), data, nil)
+	if err != nil {
+		return fmt.Errorf("failed to recover OVN databases: %w", err)
+	}
+
+	return nil
+}
+
+// RecoverFromTimestamp requests cluster member to recover OVN NB and SB databases from the backup created at
+// unix "timestamp", as reported by ListBackups. Client must target a member that runs the "central" service.
+func RecoverFromTimestamp(ctx context.Context, c *client.Client, timestamp int64) error {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*120)
+	defer cancel()
+
+	data := types.RecoverRequest{Timestamp: timestamp}
+
+	err := c.Query(queryCtx, "POST", api.NewURL().Path("central", "recover"), data, nil)
+	if err != nil {
+		return fmt.Errorf("failed to recover OVN databases: %w", err)
+	}
+
+	return nil
+}
+
+// ExportTopology returns descriptor of the current layout of OVN services in the cluster.
+func ExportTopology(ctx context.Context, c *client.Client) ([]byte, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	descriptor := json.RawMessage{}
+	err := c.Query(queryCtx, "GET", api.NewURL().Path("topology"), nil, &descriptor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export topology: %w", err)
+	}
+
+	return descriptor, nil
+}
+
+// ValidateTopology compares the cluster with topology "descriptor" and returns list of differences.
+func ValidateTopology(ctx context.Context, c *client.Client, descriptor []byte) ([]types.TopologyDiff, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	diffs := []types.TopologyDiff{}
+	err := c.Query(queryCtx, "POST", api.NewURL().Path("topology", "validate"), json.RawMessage(descriptor), &diffs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate topology: %w", err)
+	}
+
+	return diffs, nil
+}
+
+// RebuildSB requests cluster member to rebuild OVN SB database and make every other member join it. The
+// "central" service has to be stopped on other central members first.
+func RebuildSB(ctx context.Context, c *client.Client) error {
+	err := c.Query(ctx, "POST", api.NewURL().Path("sb", "rebuild"), nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to rebuild SB database: %w", err)
+	}
+
+	return nil
+}
+
+// RejoinSB requests cluster member to join OVN SB database that was rebuilt on the member "seed".
+func RejoinSB(ctx context.Context, c *client.Client, seed string) error {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*120)
+	defer cancel()
+
+	data := types.SBRejoinRequest{Seed: seed}
+
+	err := c.Query(queryCtx, "POST", api.NewURL().Path("sb", "rejoin"), data, nil)
+	if err != nil {
+		return fmt.Errorf("failed to join rebuilt SB database: %w", err)
+	}
+
+	return nil
+}
+
+// ListChassis returns OVN chassis registered in the OVN SB database along with their bindings. Client must
+// target a member that runs the "central" service.
+func ListChassis(ctx context.Context, c *client.Client) ([]types.ChassisInfo, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	chassis := []types.ChassisInfo{}
+
+	err := c.Query(queryCtx, "GET", api.NewURL().Path("chassis"), nil, &chassis)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list OVN chassis: %w", err)
+	}
+
+	return chassis, nil
+}
+
+// ReconfigureChassisRemote requests cluster member to point its OVN chassis at the OVN SB servers currently
+// run by "central" members. Client must target a member that runs the "switch" service.
+func ReconfigureChassisRemote(ctx context.Context, c *client.Client) error {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*90)
+	defer cancel()
+
+	err := c.Query(queryCtx, "PUT", api.NewURL().Path("chassis", "remote"), nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to reconfigure OVN chassis remote: %w", err)
+	}
+
+	return nil
+}
+
+// PauseChassis requests cluster member to stop its OVN chassis from installing new flows, while keeping it
+// registered in OVN SB database. Client must target a member that runs the "switch" service.
+func PauseChassis(ctx context.Context, c *client.Client) error {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	err := c.Query(queryCtx, "POST", api.NewURL().Path("chassis", "pause"), nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to pause OVN chassis: %w", err)
+	}
+
+	return nil
+}
+
+// ResumeChassis requests cluster member to resume its OVN chassis paused by PauseChassis.
+func ResumeChassis(ctx context.Context, c *client.Client) error {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	err := c.Query(queryCtx, "POST", api.NewURL().Path("chassis", "resume"), nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to resume OVN chassis: %w", err)
+	}
+
+	return nil
+}
+
+// GetMemberLabels returns all labels attached to cluster "member".
+func GetMemberLabels(ctx context.Context, c *client.Client, member string) (map[string]string, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	labels := map[string]string{}
+
+	err := c.Query(queryCtx, "GET", api.NewURL().Path("members", member, "labels"), nil, &labels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get member labels: %w", err)
+	}
+
+	return labels, nil
+}
+
+// GetMemberLabel returns label "key" attached to cluster "member".
+func GetMemberLabel(ctx context.Context, c *client.Client, member string, key string) (types.MemberLabel, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	label := types.MemberLabel{}
+
+	err := c.Query(queryCtx, "GET", api.NewURL().Path("members", member, "labels", key), nil, &label)
+	if err != nil {
+		return label, fmt.Errorf("failed to get member label: %w", err)
+	}
+
+	return label, nil
+}
+
+// SetMemberLabel attaches label "key" with "value" to cluster "member". Empty value removes the label.
+func SetMemberLabel(ctx context.Context, c *client.Client, member string, key string, value string) error {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	data := types.MemberLabel{Key: key, Value: value}
+
+	err := c.Query(queryCtx, "PUT", api.NewURL().Path("members", member, "labels", key), data, nil)
+	if err != nil {
+		return fmt.Errorf("failed to set member label: %w", err)
+	}
+
+	return nil
+}
+
+// GetStaticRoutes returns static routes configured on OVN logical router "router". Client must target a
+// member that runs the "central" service.
+func GetStaticRoutes(ctx context.Context, c *client.Client, router string) ([]types.StaticRoute, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	routes := []types.StaticRoute{}
+
+	err := c.Query(queryCtx, "GET", api.NewURL().Path("routers", router, "routes"), nil, &routes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get static routes: %w", err)
+	}
+
+	return routes, nil
+}
+
+// EnsureStaticRoutes requests cluster member to make static routes of OVN logical router "router" match
+// exactly "routes". Routes with the same prefix and different next hops are configured as ECMP routes.
+// Client must target a member that runs the "central" service.
+func EnsureStaticRoutes(ctx context.Context, c *client.Client, router string, routes []types.StaticRoute) error {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	err := c.Query(queryCtx, "PUT", api.NewURL().Path("routers", router, "routes"), routes, nil)
+	if err != nil {
+		return fmt.Errorf("failed to apply static routes: %w", err)
+	}
+
+	return nil
+}
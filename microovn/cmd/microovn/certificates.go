@@ -24,5 +24,8 @@ func (c *cmdCertificates) Command() *cobra.Command {
 	certificatesRegenerateCa := cmdCertificatesRegenerateCa{common: c.common, certificates: c}
 	cmd.AddCommand(certificatesRegenerateCa.Command())
 
+	certificatesRotateCa := cmdCertificatesRotateCa{common: c.common, certificates: c}
+	cmd.AddCommand(certificatesRotateCa.Command())
+
 	return cmd
 }
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/canonical/microcluster/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdCertificatesRotateCa struct {
+	common       *CmdControl
+	certificates *cmdCertificates
+}
+
+// Command method returns definition for "microovn certificates rotate-ca" subcommand
+func (c *cmdCertificatesRotateCa) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate-ca",
+		Short: "Replace CA certificate and re-issue certificates across whole cluster, keeping the previous CA trusted until rotation completes.",
+		RunE:  c.Run,
+	}
+
+	return cmd
+}
+
+// Run method is an implementation of "microovn certificates rotate-ca" subcommand. It requests cluster to
+// rotate its CA. Interrupted rotation is resumed by running the command again.
+func (c *cmdCertificatesRotateCa) Run(_ *cobra.Command, _ []string) error {
+	m, err := microcluster.App(context.Background(), microcluster.Args{StateDir: c.common.FlagStateDir, Verbose: c.common.FlagLogVerbose, Debug: c.common.FlagLogDebug})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	untrusted, err := client.RotateCA(context.Background(), cli)
+	if err != nil {
+		return fmt.Errorf("command failed: %s", err)
+	}
+
+	fmt.Println("CA rotated, certificates of all cluster members were re-issued.")
+	if len(untrusted) > 0 {
+		fmt.Println("\nFollowing client certificates were issued by the previous CA and are no longer trusted:")
+		for _, cert := range untrusted {
+			fmt.Printf("%s (serial %s)\n", cert.CN, cert.Serial)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+
+	microclusterClient "github.com/canonical/microcluster/client"
+	"github.com/canonical/microcluster/microcluster"
+	"github.com/spf13/cobra"
+)
+
+type cmdConfig struct {
+	common *CmdControl
+
+	flagMember string
+}
+
+// Command returns definition for "microovn config" subcommand
+func (c *cmdConfig) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage MicroOVN configuration",
+	}
+
+	cmd.PersistentFlags().StringVar(&c.flagMember, "member", "", "Cluster member to manage, for options configured separately for each member")
+
+	configListCmd := cmdConfigList{common: c.common, config: c}
+	cmd.AddCommand(configListCmd.Command())
+
+	configGetCmd := cmdConfigGet{common: c.common, config: c}
+	cmd.AddCommand(configGetCmd.Command())
+
+	configSetCmd := cmdConfigSet{common: c.common, config: c}
+	cmd.AddCommand(configSetCmd.Command())
+
+	configUnsetCmd := cmdConfigUnset{common: c.common, config: c}
+	cmd.AddCommand(configUnsetCmd.Command())
+
+	configShowCmd := cmdConfigShow{common: c.common, config: c}
+	cmd.AddCommand(configShowCmd.Command())
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, args []string) { _ = cmd.Usage() }
+
+	return cmd
+}
+
+// client returns MicroOVN client targeting cluster member selected by the "--member" flag, or local
+// cluster member if the flag is not set.
+func (c *cmdConfig) client() (*microclusterClient.Client, error) {
+	m, err := microcluster.App(context.Background(), microcluster.Args{StateDir: c.common.FlagStateDir, Verbose: c.common.FlagLogVerbose, Debug: c.common.FlagLogDebug})
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.flagMember != "" {
+		cli = cli.UseTarget(c.flagMember)
+	}
+
+	return cli, nil
+}
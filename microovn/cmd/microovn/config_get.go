@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdConfigGet struct {
+	common *CmdControl
+	config *cmdConfig
+}
+
+// Command method returns definition for "microovn config get" subcommand
+func (c *cmdConfigGet) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get <KEY>",
+		Short: "Print value of MicroOVN configuration option",
+		Args:  cobra.ExactArgs(1),
+		RunE:  c.Run,
+	}
+
+	return cmd
+}
+
+// Run method is an implementation of "microovn config get" subcommand
+func (c *cmdConfigGet) Run(_ *cobra.Command, args []string) error {
+	cli, err := c.config.client()
+	if err != nil {
+		return err
+	}
+
+	value, err := client.GetConfig(context.Background(), cli, args[0])
+	if err != nil {
+		return fmt.Errorf("command failed: %s", err)
+	}
+
+	fmt.Println(value.Value)
+	return nil
+}
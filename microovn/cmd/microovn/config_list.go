@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdConfigList struct {
+	common *CmdControl
+	config *cmdConfig
+}
+
+// Command method returns definition for "microovn config list" subcommand
+func (c *cmdConfigList) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List all MicroOVN configuration options and their values",
+		Args:  cobra.NoArgs,
+		RunE:  c.Run,
+	}
+
+	return cmd
+}
+
+// Run method is an implementation of "microovn config list" subcommand
+func (c *cmdConfigList) Run(_ *cobra.Command, _ []string) error {
+	cli, err := c.config.client()
+	if err != nil {
+		return err
+	}
+
+	values, err := client.ListConfig(context.Background(), cli)
+	if err != nil {
+		return fmt.Errorf("command failed: %s", err)
+	}
+
+	for _, value := range values {
+		fmt.Printf("%s: %s\n", value.Key, value.Value)
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdConfigSet struct {
+	common *CmdControl
+	config *cmdConfig
+}
+
+// Command method returns definition for "microovn config set" subcommand
+func (c *cmdConfigSet) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set <KEY> <VALUE>",
+		Short: "Set value of MicroOVN configuration option",
+		Args:  cobra.ExactArgs(2),
+		RunE:  c.Run,
+	}
+
+	return cmd
+}
+
+// Run method is an implementation of "microovn config set" subcommand
+func (c *cmdConfigSet) Run(_ *cobra.Command, args []string) error {
+	cli, err := c.config.client()
+	if err != nil {
+		return err
+	}
+
+	err = client.SetConfig(context.Background(), cli, args[0], args[1])
+	if err != nil {
+		return fmt.Errorf("command failed: %s", err)
+	}
+
+	return nil
+}
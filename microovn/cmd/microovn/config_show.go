@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdConfigShow struct {
+	common *CmdControl
+	config *cmdConfig
+
+	FormatFlag string
+}
+
+// Command method returns definition for "microovn config show" subcommand
+func (c *cmdConfigShow) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show OVN settings of a member, comparing stored and live values",
+		Args:  cobra.NoArgs,
+		RunE:  c.Run,
+	}
+
+	cmd.Flags().StringVarP(
+		&c.FormatFlag,
+		"format",
+		"f",
+		"text",
+		fmt.Sprintf("Output format selector. (Allowed formats: %s)", strings.Join(outputFormats, ", ")),
+	)
+
+	return cmd
+}
+
+// Run method is an implementation of "microovn config show" subcommand
+func (c *cmdConfigShow) Run(_ *cobra.Command, _ []string) error {
+	cli, err := c.config.client()
+	if err != nil {
+		return err
+	}
+
+	values, err := client.GetEffectiveConfig(context.Background(), cli)
+	if err != nil {
+		return fmt.Errorf("command failed: %s", err)
+	}
+
+	switch c.FormatFlag {
+	case "text":
+	case "json":
+		report, err := types.MarshalReport(types.ReportKindEffectiveConfig, values)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(report))
+		return nil
+	default:
+		return fmt.Errorf("unknown output format specified: %s", c.FormatFlag)
+	}
+
+	diverged := 0
+	for _, value := range values {
+		fmt.Printf("%s: stored '%s', live '%s'", value.Key, value.Stored, value.Live)
+		if value.Error != "" {
+			fmt.Printf(" (failed to read: %s)", value.Error)
+		}
+
+		if value.Diverged {
+			diverged++
+			fmt.Print(" [DIVERGED]")
+		}
+
+		fmt.Println()
+	}
+
+	if diverged > 0 {
+		fmt.Printf("\n%d setting(s) diverged from the stored configuration, it might have failed to apply.\n", diverged)
+	}
+
+	return nil
+}
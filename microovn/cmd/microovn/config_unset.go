@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdConfigUnset struct {
+	common *CmdControl
+	config *cmdConfig
+}
+
+// Command method returns definition for "microovn config unset" subcommand
+func (c *cmdConfigUnset) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unset <KEY>",
+		Short: "Reset MicroOVN configuration option to its default value",
+		Args:  cobra.ExactArgs(1),
+		RunE:  c.Run,
+	}
+
+	return cmd
+}
+
+// Run method is an implementation of "microovn config unset" subcommand
+func (c *cmdConfigUnset) Run(_ *cobra.Command, args []string) error {
+	cli, err := c.config.client()
+	if err != nil {
+		return err
+	}
+
+	err = client.SetConfig(context.Background(), cli, args[0], "")
+	if err != nil {
+		return fmt.Errorf("command failed: %s", err)
+	}
+
+	return nil
+}
@@ -6,14 +6,18 @@ import (
 	"sort"
 	"strings"
 
+	microclusterClient "github.com/canonical/microcluster/client"
 	"github.com/canonical/microcluster/microcluster"
 	"github.com/spf13/cobra"
 
+	"github.com/canonical/microovn/microovn/api/types"
 	"github.com/canonical/microovn/microovn/client"
 )
 
 type cmdStatus struct {
 	common *CmdControl
+
+	FormatFlag string
 }
 
 func (c *cmdStatus) Command() *cobra.Command {
@@ -23,6 +27,14 @@ func (c *cmdStatus) Command() *cobra.Command {
 		RunE:  c.Run,
 	}
 
+	cmd.Flags().StringVarP(
+		&c.FormatFlag,
+		"format",
+		"f",
+		"text",
+		fmt.Sprintf("Output format selector. (Allowed formats: %s)", strings.Join(outputFormats, ", ")),
+	)
+
 	return cmd
 }
 
@@ -49,8 +61,21 @@ func (c *cmdStatus) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if c.FormatFlag == "json" {
+		names := make([]string, 0, len(clusterMembers))
+		for _, server := range clusterMembers {
+			names = append(names, server.Name)
+		}
+
+		return printStatusJSON(cli, names)
+	} else if c.FormatFlag != "text" {
+		return fmt.Errorf("unknown output format specified: %s", c.FormatFlag)
+	}
+
 	fmt.Println("MicroOVN deployment summary:")
 
+	statuses := map[string]types.MemberStatus{}
+
 	for _, server := range clusterMembers {
 		// Services.
 		srvServices := []string{}
@@ -65,7 +90,233 @@ func (c *cmdStatus) Run(cmd *cobra.Command, args []string) error {
 
 		fmt.Printf("- %s (%s)\n", server.Name, server.Address.Addr().String())
 		fmt.Printf("  Services: %s\n", strings.Join(srvServices, ", "))
+
+		// Member status.
+		status, err := client.GetStatus(context.Background(), cli.UseTarget(server.Name))
+		if err != nil {
+			fmt.Printf("  Status: unavailable (%s)\n", err)
+			continue
+		}
+
+		printMemberStatus(&status)
+		statuses[server.Name] = status
+	}
+
+	printTLSWarnings(statuses)
+	printFootprint(cli, services)
+
+	return nil
+}
+
+// printStatusJSON prints status of every cluster member in "members" as a types.ReportKindStatus report. Members whose
+// status can't be retrieved are reported with the error in their "errors" list and other fields left empty.
+func printStatusJSON(cli *microclusterClient.Client, members []string) error {
+	statuses := map[string]types.MemberStatus{}
+	for _, member := range members {
+		status, err := client.GetStatus(context.Background(), cli.UseTarget(member))
+		if err != nil {
+			status = types.MemberStatus{Errors: []string{fmt.Sprintf("status unavailable: %s", err)}}
+		}
+
+		statuses[member] = status
 	}
 
+	report, err := types.MarshalReport(types.ReportKindStatus, statuses)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(report))
 	return nil
 }
+
+// printMemberStatus prints details about OVN components running on a cluster member. Values
+// that are not set are omitted.
+func printMemberStatus(status *types.MemberStatus) {
+	for _, probeErr := range status.Errors {
+		fmt.Printf("  Status: incomplete (%s)\n", probeErr)
+	}
+
+	if !status.Environment.InSync {
+		fmt.Println("  Environment: ovn.env is out of date")
+	}
+
+	for _, drift := range status.Environment.Stale {
+		fmt.Printf("  Environment: %s runs with stale %s, restart %s service\n", drift.Daemon, drift.Setting, drift.Service)
+	}
+
+	if len(status.Labels) > 0 {
+		labels := make([]string, 0, len(status.Labels))
+		for key, value := range status.Labels {
+			labels = append(labels, fmt.Sprintf("%s=%s", key, value))
+		}
+
+		sort.Strings(labels)
+		fmt.Printf("  Labels: %s\n", strings.Join(labels, ", "))
+	}
+
+	if status.Switch.Enabled {
+		fmt.Printf("  OVS: ovsdb-server %s, ovs-vswitchd %s\n", runningState(status.Switch.OvsdbRunning), runningState(status.Switch.VswitchdRunning))
+	}
+
+	if status.Switch.IntegrationBridge != "" {
+		fmt.Printf("  Integration bridge: %s (%s)", status.Switch.IntegrationBridge, status.Switch.DatapathType)
+		if status.Switch.ConfiguredDatapathType != "" && status.Switch.ConfiguredDatapathType != status.Switch.DatapathType {
+			fmt.Printf(", configured '%s' datapath is applied only when the bridge is recreated", status.Switch.ConfiguredDatapathType)
+		}
+
+		fmt.Println()
+	}
+
+	if status.Switch.Sampling != "" {
+		fmt.Printf("  Traffic sampling: %s\n", status.Switch.Sampling)
+	}
+
+	if status.Central.NBReadOnly {
+		fmt.Println("  Northbound database: read-only, status written back by ovn-northd is frozen")
+	}
+
+	if status.Central.NBState != "" || status.Central.SBState != "" {
+		fmt.Printf("  Database state: NB %s, SB %s\n", status.Central.NBState, status.Central.SBState)
+	}
+
+	if status.Central.NBLeader != "" || status.Central.SBLeader != "" {
+		fmt.Printf("  Raft leaders: NB %s, SB %s\n", status.Central.NBLeader, status.Central.SBLeader)
+	}
+
+	if len(status.Central.PreferredLeaders) > 0 {
+		fmt.Printf("  Preferred leaders: %s\n", strings.Join(status.Central.PreferredLeaders, ", "))
+	}
+
+	if status.Central.NorthdState == "external" {
+		fmt.Println("  Northd: externally managed")
+	} else if status.Central.NorthdState != "" {
+		fmt.Printf("  Northd: %s", status.Central.NorthdState)
+		if status.Central.NorthdStandby {
+			fmt.Print(" (held in standby, becomes active only if no other instance is)")
+		}
+
+		fmt.Println()
+	}
+
+	if status.Central.NorthdThreads != "" {
+		fmt.Printf("  Northd threads: %s\n", status.Central.NorthdThreads)
+	}
+
+	if status.Central.NBDatabaseSize > 0 || status.Central.SBDatabaseSize > 0 {
+		fmt.Printf("  Database size: NB %d B, SB %d B\n", status.Central.NBDatabaseSize, status.Central.SBDatabaseSize)
+	}
+
+	if status.Central.NBMemoryRSS > 0 || status.Central.SBMemoryRSS > 0 {
+		fmt.Printf("  Database memory: NB %d B, SB %d B", status.Central.NBMemoryRSS, status.Central.SBMemoryRSS)
+		if status.Central.MemoryLimit != "" {
+			fmt.Printf(" (limit %s MiB each)", status.Central.MemoryLimit)
+		}
+
+		fmt.Println()
+	}
+
+	if status.Chassis.EncapIP != "" {
+		fmt.Printf("  Encap IP: %s\n", status.Chassis.EncapIP)
+	}
+
+	if status.Chassis.EncapTos != "" {
+		fmt.Printf("  Encap ToS: %s\n", status.Chassis.EncapTos)
+	}
+
+	if status.Chassis.RemoteProbeInterval != "" {
+		fmt.Printf("  SB probe interval: %s ms\n", status.Chassis.RemoteProbeInterval)
+	}
+
+	if status.Chassis.MonitorAll == "true" {
+		fmt.Println("  SB monitoring: all datapaths")
+	} else if status.Switch.Enabled {
+		fmt.Println("  SB monitoring: conditional")
+	}
+
+	if status.Chassis.GatewayPriority != "" {
+		fmt.Printf("  Gateway priority: %s\n", status.Chassis.GatewayPriority)
+	}
+
+	if status.Chassis.Paused {
+		fmt.Println("  Chassis: paused, no new flows are installed until it's resumed")
+	}
+}
+
+// runningState returns human-readable representation of the "running" flag.
+func runningState(running bool) string {
+	if running {
+		return "running"
+	}
+
+	return "not running"
+}
+
+// printFootprint prints resources used by OVN across the whole cluster, as gathered by the first member in
+// "services" that runs the "central" service. Nothing is printed if no such member responds.
+func printFootprint(cli *microclusterClient.Client, services types.Services) {
+	for _, service := range services {
+		if service.Service != "central" {
+			continue
+		}
+
+		footprint, err := client.GetClusterFootprint(context.Background(), cli.UseTarget(service.Location))
+		if err != nil {
+			continue
+		}
+
+		fmt.Println("Cluster footprint:")
+		fmt.Printf("  Database size: NB %d B, SB %d B (total of %d central members)\n", footprint.NBDatabaseSize, footprint.SBDatabaseSize, footprint.CentralMembers)
+		fmt.Printf("  Chassis: %d, logical flows: %d, ports: %d\n", footprint.Chassis, footprint.LogicalFlows, footprint.Ports)
+		if len(footprint.Unreachable) > 0 {
+			fmt.Printf("  Not included: %s\n", strings.Join(footprint.Unreachable, ", "))
+		}
+
+		return
+	}
+}
+
+// printTLSWarnings reports cluster members whose TLS configuration is out of sync with the rest of the
+// cluster, for example members that still listen on plain TCP after TLS was enabled, or members that
+// are missing certificates.
+func printTLSWarnings(statuses map[string]types.MemberStatus) {
+	protocols := map[string]bool{}
+	for _, status := range statuses {
+		protocols[status.TLS.Protocol] = true
+	}
+
+	names := make([]string, 0, len(statuses))
+	for name := range statuses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	warnings := []string{}
+	for _, name := range names {
+		status := statuses[name]
+		if len(protocols) > 1 {
+			warnings = append(warnings, fmt.Sprintf("%s: expects OVN services to use '%s'", name, status.TLS.Protocol))
+		}
+
+		if status.TLS.ListenProtocol != "" && status.TLS.ListenProtocol != status.TLS.Protocol {
+			warnings = append(warnings, fmt.Sprintf("%s: OVN databases listen on '%s' instead of '%s'", name, status.TLS.ListenProtocol, status.TLS.Protocol))
+		}
+
+		for _, path := range status.TLS.MissingCertificates {
+			warnings = append(warnings, fmt.Sprintf("%s: missing certificate file %s", name, path))
+		}
+
+		for _, problem := range status.TLS.CertificateProblems {
+			warnings = append(warnings, fmt.Sprintf("%s: %s", name, problem))
+		}
+	}
+
+	if len(warnings) == 0 {
+		return
+	}
+
+	fmt.Println("\nWARNING: TLS configuration is not consistent across cluster members:")
+	for _, warning := range warnings {
+		fmt.Printf("- %s\n", warning)
+	}
+}
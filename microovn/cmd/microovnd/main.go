@@ -44,7 +44,8 @@ func (c *cmdGlobal) Run(cmd *cobra.Command, args []string) error {
 type cmdDaemon struct {
 	global *cmdGlobal
 
-	flagStateDir string
+	flagStateDir    string
+	flagStrictLeave bool
 }
 
 func (c *cmdDaemon) Command() *cobra.Command {
@@ -70,6 +71,9 @@ func (c *cmdDaemon) Run(cmd *cobra.Command, args []string) error {
 	h.PreJoin = ovn.Join
 	h.OnNewMember = ovn.Refresh
 	h.PreRemove = ovn.Leave
+	if c.flagStrictLeave {
+		h.PreRemove = ovn.LeaveStrict
+	}
 	h.PostRemove = ovn.Refresh
 	h.OnStart = ovn.Start
 
@@ -92,6 +96,7 @@ func main() {
 	app.PersistentFlags().BoolVarP(&daemonCmd.global.flagLogVerbose, "verbose", "v", false, "Show all information messages")
 
 	app.PersistentFlags().StringVar(&daemonCmd.flagStateDir, "state-dir", "", "Path to store state information"+"``")
+	app.PersistentFlags().BoolVar(&daemonCmd.flagStrictLeave, "strict-leave", false, "Report failures encountered while leaving OVN cluster to the caller")
 
 	app.SetVersionTemplate("{{.Version}}\n")
 
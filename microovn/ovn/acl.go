@@ -0,0 +1,212 @@
+package ovn
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/api"
+)
+
+// portGroupName is a pattern that names of port groups must match. OVN requires port group names to be
+// valid identifiers, because they are referenced in ACL matches as "@<name>".
+var portGroupName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// ACL describes single OVN ACL applied to a port group.
+type ACL struct {
+	Direction string // "from-lport" or "to-lport"
+	Priority  int    // Priority in range 0-32767
+	Match     string // OVN match expression
+	Action    string // "allow", "allow-related", "allow-stateless", "drop", "reject" or "pass"
+}
+
+// key returns string that uniquely identifies the ACL within a port group.
+func (a ACL) key() string {
+	return fmt.Sprintf("%s|%d|%s|%s", a.Direction, a.Priority, a.Match, a.Action)
+}
+
+// validate verifies that the ACL can be applied to OVN NB database.
+func (a ACL) validate() error {
+	if a.Direction != "from-lport" && a.Direction != "to-lport" {
+		return fmt.Errorf("invalid ACL direction '%s'", a.Direction)
+	}
+
+	if a.Priority < 0 || a.Priority > 32767 {
+		return fmt.Errorf("invalid ACL priority %d, expected value in range 0-32767", a.Priority)
+	}
+
+	if a.Match == "" {
+		return fmt.Errorf("ACL match can't be empty")
+	}
+
+	switch a.Action {
+	case "allow", "allow-related", "allow-stateless", "drop", "reject", "pass":
+		return nil
+	default:
+		return fmt.Errorf("invalid ACL action '%s'", a.Action)
+	}
+}
+
+// EnsurePortGroup makes sure that port group "name" exists in OVN Northbound database and that it contains
+// exactly the logical switch ports listed in "ports". Calling this function repeatedly with the same
+// arguments has no further effect.
+//
+// This function must be executed on a member that runs the "central" service and that can reach the
+// Northbound cluster leader.
+func EnsurePortGroup(s *state.State, name string, ports []string) error {
+	if !portGroupName.MatchString(name) {
+		return api.StatusErrorf(http.StatusBadRequest, "invalid port group name '%s'", name)
+	}
+
+	err := ensureNBLeaderReachable(s)
+	if err != nil {
+		return err
+	}
+
+	exists, err := portGroupExists(s, name)
+	if err != nil {
+		return err
+	}
+
+	var args []string
+	if exists {
+		args = append([]string{"pg-set-ports", name}, ports...)
+	} else {
+		args = append([]string{"pg-add", name}, ports...)
+	}
+
+	_, err = localNBCtl(s, args...)
+	if err != nil {
+		return fmt.Errorf("failed to apply port group '%s': %w", name, err)
+	}
+
+	return nil
+}
+
+// EnsureACLs makes sure that ACLs applied to port group "portGroup" in OVN Northbound database match exactly
+// the desired set "acls". ACLs that are not in the desired set are removed, missing ones are added. If the
+// port group already has the desired ACLs, the database is not modified, so re-running this function does
+// not cause duplicates or unnecessary churn. All changes are applied in a single transaction.
+//
+// This function must be executed on a member that runs the "central" service and that can reach the
+// Northbound cluster leader.
+func EnsureACLs(s *state.State, portGroup string, acls []ACL) error {
+	if !portGroupName.MatchString(portGroup) {
+		return api.StatusErrorf(http.StatusBadRequest, "invalid port group name '%s'", portGroup)
+	}
+
+	desired := map[string]ACL{}
+	for _, acl := range acls {
+		err := acl.validate()
+		if err != nil {
+			return api.StatusErrorf(http.StatusBadRequest, "%s", err)
+		}
+
+		desired[acl.key()] = acl
+	}
+
+	err := ensureNBLeaderReachable(s)
+	if err != nil {
+		return err
+	}
+
+	current, err := listPortGroupACLs(s, portGroup)
+	if err != nil {
+		return err
+	}
+
+	if len(current) == len(desired) {
+		inSync := true
+		for _, acl := range current {
+			_, ok := desired[acl.key()]
+			if !ok {
+				inSync = false
+				break
+			}
+		}
+
+		if inSync {
+			return nil
+		}
+	}
+
+	keys := make([]string, 0, len(desired))
+	for key := range desired {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	args := []string{"acl-del", "--type=port-group", portGroup}
+	for _, key := range keys {
+		acl := desired[key]
+		args = append(
+			args,
+			"--",
+			"acl-add",
+			"--type=port-group",
+			portGroup,
+			acl.Direction,
+			strconv.Itoa(acl.Priority),
+			acl.Match,
+			acl.Action,
+		)
+	}
+
+	_, err = localNBCtl(s, args...)
+	if err != nil {
+		return fmt.Errorf("failed to apply ACLs to port group '%s': %w", portGroup, err)
+	}
+
+	return nil
+}
+
+// portGroupExists returns true if port group "name" exists in OVN Northbound database.
+func portGroupExists(s *state.State, name string) (bool, error) {
+	output, err := localNBCtl(s, "--data=bare", "--no-headings", "--columns=name", "find", "Port_Group", fmt.Sprintf("name=%s", strconv.Quote(name)))
+	if err != nil {
+		return false, fmt.Errorf("failed to look up port group '%s': %w", name, err)
+	}
+
+	return strings.TrimSpace(output) != "", nil
+}
+
+// listPortGroupACLs returns ACLs currently applied to port group "portGroup".
+func listPortGroupACLs(s *state.State, portGroup string) ([]ACL, error) {
+	output, err := localNBCtl(s, "--data=bare", "--no-headings", "get", "Port_Group", portGroup, "acls")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ACLs of port group '%s': %w", portGroup, err)
+	}
+
+	acls := []ACL{}
+	for _, uuid := range strings.Fields(output) {
+		output, err = localNBCtl(s, "--format=csv", "--data=bare", "--no-headings", "--columns=direction,priority,match,action", "list", "ACL", uuid)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ACL %s: %w", uuid, err)
+		}
+
+		records, err := csv.NewReader(strings.NewReader(output)).ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ACL %s: %w", uuid, err)
+		}
+
+		for _, record := range records {
+			if len(record) != 4 {
+				continue
+			}
+
+			priority, err := strconv.Atoi(record[1])
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse priority of ACL %s: %w", uuid, err)
+			}
+
+			acls = append(acls, ACL{Direction: record[0], Priority: priority, Match: record[2], Action: record[3]})
+		}
+	}
+
+	return acls, nil
+}
@@ -0,0 +1,228 @@
+package ovn
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"os"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared"
+	"github.com/lxc/lxd/shared/api"
+	"github.com/lxc/lxd/shared/logger"
+
+	"github.com/canonical/microovn/microovn/database"
+)
+
+// raftPorts maps clustered OVN databases to ports used by their raft servers.
+var raftPorts = map[OvsdbType]int{
+	OvsdbTypeNBLocal: 6643,
+	OvsdbTypeSBLocal: 6644,
+}
+
+// raftAddress returns address, in the OVN connection string format, of the raft server of database "dbType"
+// running on the host with IP address "addr". Raft traffic between central members always uses SSL.
+func raftAddress(addr netip.Addr, dbType OvsdbType) string {
+	return fmt.Sprintf("ssl:%s", netip.AddrPortFrom(addr, uint16(raftPorts[dbType])).String())
+}
+
+// UpdateMemberAddress moves OVN services of this member to address "newAddr", after the member's network
+// address changed. Microcluster doesn't support changing address of an existing member, so its management
+// address is left as is and "newAddr" is stored as the member's OVN service address (ConfigKeyServiceAddress)
+// instead. Applying it moves local OVN NB and SB raft servers to "newAddr", if the member runs "central"
+// service, reissues local service certificates, so that their SAN carries the new address, and regenerates
+// ovn.env and reconfigures local OVN services.
+//
+// Other cluster members need to regenerate their ovn.env afterwards, as their connection strings
+// reference the old address. This function must be executed on the member whose address changed.
+func UpdateMemberAddress(s *state.State, member string, newAddr string) error {
+	if s.Name() != member {
+		return api.StatusErrorf(http.StatusBadRequest, "address of member '%s' must be updated on that member (current member: '%s')", member, s.Name())
+	}
+
+	addr, err := netip.ParseAddr(newAddr)
+	if err != nil {
+		return api.StatusErrorf(http.StatusBadRequest, "invalid address '%s': %s", newAddr, err)
+	}
+
+	current, err := serviceAddress(s)
+	if err != nil {
+		return err
+	}
+
+	currentAddr, err := netip.ParseAddr(current)
+	if err == nil && currentAddr.Unmap() == addr.Unmap() {
+		return nil
+	}
+
+	logger.Infof("Moving OVN services of member '%s' from %s to %s", member, current, addr)
+	return SetConfig(s, ConfigKeyServiceAddress, addr.Unmap().String())
+}
+
+// centralMemberCount returns number of MicroOVN cluster members that run the "central" service.
+func centralMemberCount(s *state.State) (int, error) {
+	centrals, err := centralMembers(s)
+	return len(centrals), err
+}
+
+// moveCentralAddress moves local OVN NB and SB raft servers to the address "addr". Raft servers can't
+// change their address in place. If there are other central members, local servers leave their clusters
+// and join them again, from scratch, using the new address. If this is the only central member, the
+// single-server clusters are recreated from their current data with the new address. The latter results
+// in new cluster IDs, so the recorded IDs are replaced.
+func moveCentralAddress(s *state.State, addr netip.Addr) error {
+	centralCount, err := centralMemberCount(s)
+	if err != nil {
+		return fmt.Errorf("failed to count central members: %w", err)
+	}
+
+	alone := centralCount <= 1
+	if !alone {
+		for _, dbType := range []OvsdbType{OvsdbTypeNBLocal, OvsdbTypeSBLocal} {
+			err = leaveRaftCluster(s, dbType)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = snapStop("central", false)
+	if err != nil {
+		return fmt.Errorf("failed to stop OVN central: %w", err)
+	}
+
+	for _, dbType := range []OvsdbType{OvsdbTypeNBLocal, OvsdbTypeSBLocal} {
+		if alone {
+			err = recreateRaftCluster(s, dbType, addr)
+		} else {
+			err = moveDatabaseFileAside(dbType)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	// Environment has to reflect the new address before central starts again.
+	err = generateEnvironment(s, nil)
+	if err != nil {
+		return fmt.Errorf("failed to generate the daemon configuration: %w", err)
+	}
+
+	err = snapStart("central", false)
+	if err != nil {
+		return fmt.Errorf("failed to start OVN central: %w", err)
+	}
+
+	for _, dbType := range []OvsdbType{OvsdbTypeNBLocal, OvsdbTypeSBLocal} {
+		dbSpec, err := newOvsdbSpec(dbType)
+		if err != nil {
+			return err
+		}
+
+		err = waitForDBState(s, dbSpec, OvsdbConnected, defaultDBConnectWait)
+		if err != nil {
+			return err
+		}
+
+		if alone {
+			err = forgetClusterID(s, dbType)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if alone {
+		return recordClusterIDs(s)
+	}
+
+	return nil
+}
+
+// leaveRaftCluster gracefully removes local server from the raft cluster of database "dbType".
+func leaveRaftCluster(s *state.State, dbType OvsdbType) error {
+	dbSpec, err := newOvsdbSpec(dbType)
+	if err != nil {
+		return err
+	}
+
+	ctlSock, err := ovsdbControlSock(dbType)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Leaving %s cluster", dbSpec.Name)
+	_, err = AppCtl(s, ctlSock, "cluster/leave", dbSpec.Name)
+	if err != nil {
+		return fmt.Errorf("failed to leave %s cluster: %w", dbSpec.Name, err)
+	}
+
+	err = waitForDBState(s, dbSpec, OvsdbRemoved, defaultDBConnectWait)
+	if err != nil {
+		return fmt.Errorf("failed to wait for %s cluster departure: %w", dbSpec.Name, err)
+	}
+
+	return nil
+}
+
+// moveDatabaseFileAside renames local database file of "dbType", so that central service joins the raft
+// cluster as a new server on its next start. Original file is kept with ".old" suffix.
+func moveDatabaseFileAside(dbType OvsdbType) error {
+	dbFile, err := databaseFile(dbType)
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(dbFile, dbFile+".old")
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to move database file '%s': %w", dbFile, err)
+	}
+
+	return nil
+}
+
+// recreateRaftCluster converts local single-server raft database of "dbType" into a new single-server
+// cluster with the same data, whose raft server listens on address "addr". Original file is kept with
+// ".old" suffix.
+func recreateRaftCluster(s *state.State, dbType OvsdbType, addr netip.Addr) error {
+	dbFile, err := databaseFile(dbType)
+	if err != nil {
+		return err
+	}
+
+	standalone := dbFile + ".standalone"
+	_, err = shared.RunCommandContext(s.Context, "ovsdb-tool", "cluster-to-standalone", standalone, dbFile)
+	if err != nil {
+		return fmt.Errorf("failed to convert '%s' to standalone database: %w", dbFile, err)
+	}
+
+	defer func() { _ = os.Remove(standalone) }()
+
+	err = os.Rename(dbFile, dbFile+".old")
+	if err != nil {
+		return fmt.Errorf("failed to move database file '%s': %w", dbFile, err)
+	}
+
+	_, err = shared.RunCommandContext(s.Context, "ovsdb-tool", "create-cluster", dbFile, standalone, raftAddress(addr, dbType))
+	if err != nil {
+		return fmt.Errorf("failed to create cluster database '%s': %w", dbFile, err)
+	}
+
+	return nil
+}
+
+// forgetClusterID removes recorded ID of the raft cluster of database "dbType" from the shared database.
+func forgetClusterID(s *state.State, dbType OvsdbType) error {
+	return s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		err := database.DeleteConfigItem(ctx, tx, clusterIDRecordName(dbType))
+		if err != nil && !api.StatusErrorCheck(err, http.StatusNotFound) {
+			return err
+		}
+
+		return nil
+	})
+}
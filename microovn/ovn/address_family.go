@@ -0,0 +1,63 @@
+package ovn
+
+import (
+	"net/netip"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/logger"
+)
+
+// checkAddressFamilies warns about members whose management address family differs from the rest of the
+// cluster, e.g. a member that advertises IPv4 address in an otherwise IPv6-only cluster. OVN services
+// listen on both families, but members can only reach each other over a family they share, so such
+// member usually indicates misconfiguration. Tunnel (encap) address of this member is checked as well.
+func checkAddressFamilies(s *state.State) {
+	remotes := s.Remotes().RemotesByName()
+
+	ipv4 := []string{}
+	ipv6 := []string{}
+	for name, remote := range remotes {
+		if remote.Address.Addr().Unmap().Is4() {
+			ipv4 = append(ipv4, name)
+		} else {
+			ipv6 = append(ipv6, name)
+		}
+	}
+
+	if len(ipv4) > 0 && len(ipv6) > 0 {
+		minority, family := ipv4, "IPv4"
+		if len(ipv6) < len(ipv4) {
+			minority, family = ipv6, "IPv6"
+		}
+
+		for _, name := range minority {
+			logger.Warnf("Member '%s' advertises %s address in an otherwise %s cluster", name, family, otherFamily(family))
+		}
+	}
+
+	encapAddr, err := encapAddress(s)
+	if err != nil {
+		logger.Warnf("Failed to get OVN tunnel address: %s", err)
+		return
+	}
+
+	encap, err := netip.ParseAddr(encapAddr)
+	if err != nil {
+		return
+	}
+
+	if len(ipv4) == 0 && encap.Unmap().Is4() {
+		logger.Warnf("OVN tunnel address %s of this member is IPv4 in an IPv6-only cluster", encapAddr)
+	} else if len(ipv6) == 0 && !encap.Unmap().Is4() {
+		logger.Warnf("OVN tunnel address %s of this member is IPv6 in an IPv4-only cluster", encapAddr)
+	}
+}
+
+// otherFamily returns name of the address family other than "family".
+func otherFamily(family string) string {
+	if family == "IPv4" {
+		return "IPv6"
+	}
+
+	return "IPv4"
+}
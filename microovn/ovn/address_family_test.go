@@ -0,0 +1,130 @@
+package ovn
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"net/netip"
+	"testing"
+
+	"github.com/canonical/microovn/microovn/database"
+)
+
+// ipv6Snapshot returns services snapshot of an IPv6-only cluster with three central members, as seen by
+// member "m1".
+func ipv6Snapshot() *servicesSnapshot {
+	return &servicesSnapshot{
+		member: "m1",
+		services: []database.Service{
+			{Member: "m1", Service: "central"},
+			{Member: "m2", Service: "central"},
+			{Member: "m3", Service: "central"},
+			{Member: "m1", Service: "switch"},
+		},
+		addresses: map[string]netip.Addr{
+			"m1": netip.MustParseAddr("fd00::1"),
+			"m2": netip.MustParseAddr("fd00::2"),
+			"m3": netip.MustParseAddr("fd00::3"),
+		},
+	}
+}
+
+func TestBracketAddress(t *testing.T) {
+	tests := map[string]string{
+		"10.0.0.1":        "10.0.0.1",
+		"fd00::1":         "[fd00::1]",
+		"::ffff:10.0.0.1": "[::ffff:10.0.0.1]",
+		"host.example":    "host.example",
+	}
+
+	for addr, expected := range tests {
+		got := bracketAddress(addr)
+		if got != expected {
+			t.Errorf("bracketAddress(%q) = %q, expected %q", addr, got, expected)
+		}
+	}
+}
+
+func TestConnectStringIPv6(t *testing.T) {
+	snapshot := ipv6Snapshot()
+
+	expected := "ssl:[fd00::1]:6641,ssl:[fd00::2]:6641,ssl:[fd00::3]:6641"
+	got := snapshot.ConnectString("ssl", 6641)
+	if got != expected {
+		t.Errorf("ConnectString() = %q, expected %q", got, expected)
+	}
+
+	unbracketed := "ssl:fd00::1:6641,ssl:fd00::2:6641,ssl:fd00::3:6641"
+	if ipv6Unbracketer.Replace(got) != unbracketed {
+		t.Errorf("unbracketed connect string = %q, expected %q", ipv6Unbracketer.Replace(got), unbracketed)
+	}
+}
+
+func TestInitialCentralIPv6(t *testing.T) {
+	snapshot := ipv6Snapshot()
+
+	got, err := snapshot.InitialCentral("[fd00::1]")
+	if err != nil {
+		t.Fatalf("InitialCentral() failed: %s", err)
+	}
+
+	if got != "[fd00::2]" {
+		t.Errorf("InitialCentral() = %q, expected %q", got, "[fd00::2]")
+	}
+
+	// Member that bootstraps the cluster points at its own address.
+	snapshot.services = nil
+	got, err = snapshot.InitialCentral("[fd00::1]")
+	if err != nil {
+		t.Fatalf("InitialCentral() failed: %s", err)
+	}
+
+	if got != "[fd00::1]" {
+		t.Errorf("InitialCentral() = %q, expected %q", got, "[fd00::1]")
+	}
+}
+
+func TestRaftAddressIPv6(t *testing.T) {
+	addr := netip.MustParseAddr("fd00::1")
+
+	tests := map[OvsdbType]string{
+		OvsdbTypeNBLocal: "ssl:[fd00::1]:6643",
+		OvsdbTypeSBLocal: "ssl:[fd00::1]:6644",
+	}
+
+	for dbType, expected := range tests {
+		got := raftAddress(addr, dbType)
+		if got != expected {
+			t.Errorf("raftAddress(%s) = %q, expected %q", addr, got, expected)
+		}
+	}
+}
+
+func TestCertificateIPv6SAN(t *testing.T) {
+	certPEM, _, err := issueCertificate("m1", "ovnnb", CertificateTypeServer, nil, nil, []string{"fd00::1", "m1.example"})
+	if err != nil {
+		t.Fatalf("issueCertificate() failed: %s", err)
+	}
+
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		t.Fatal("issued certificate is not PEM encoded")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("failed to parse issued certificate: %s", err)
+	}
+
+	if len(cert.IPAddresses) != 1 || !cert.IPAddresses[0].Equal(netip.MustParseAddr("fd00::1").AsSlice()) {
+		t.Errorf("certificate IP SANs = %v, expected [fd00::1]", cert.IPAddresses)
+	}
+
+	if len(cert.DNSNames) != 1 || cert.DNSNames[0] != "m1.example" {
+		t.Errorf("certificate DNS SANs = %v, expected [m1.example]", cert.DNSNames)
+	}
+
+	err = cert.VerifyHostname("fd00::1")
+	if err != nil {
+		t.Errorf("certificate is not valid for IPv6 address: %s", err)
+	}
+}
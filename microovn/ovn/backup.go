@@ -0,0 +1,344 @@
+package ovn
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared"
+	"github.com/lxc/lxd/shared/api"
+	"github.com/lxc/lxd/shared/logger"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/ovn/paths"
+)
+
+const backupDirPrefix = "backup_" // Prefix of backup directory names, followed by unix timestamp
+
+// renameDir renames directories moved by moveDir. It is replaced in tests to simulate cross-device errors.
+var renameDir = os.Rename
+
+// backupDirName returns name of the backup directory created at time "t".
+func backupDirName(t time.Time) string {
+	return fmt.Sprintf("%s%d", backupDirPrefix, t.Unix())
+}
+
+// backupRoot returns directory where backups of MicroOVN data are created, as configured by
+// ConfigKeyBackupPath. If the option is not set, or it can't be read, paths.Root() is used.
+func backupRoot(s *state.State) string {
+	root, err := GetConfig(s, ConfigKeyBackupPath)
+	if err != nil {
+		logger.Warnf("Failed to get backup path, using '%s': %s", paths.Root(), err)
+		return paths.Root()
+	}
+
+	if root == "" {
+		return paths.Root()
+	}
+
+	return root
+}
+
+// sameFilesystem returns true if paths "a" and "b" reside on the same filesystem, which is required for
+// them to be renamed into each other.
+func sameFilesystem(a string, b string) (bool, error) {
+	var statA, statB syscall.Stat_t
+	err := syscall.Stat(a, &statA)
+	if err != nil {
+		return false, fmt.Errorf("failed to stat '%s': %w", a, err)
+	}
+
+	err = syscall.Stat(b, &statB)
+	if err != nil {
+		return false, fmt.Errorf("failed to stat '%s': %w", b, err)
+	}
+
+	return statA.Dev == statB.Dev, nil
+}
+
+// moveDir moves directory "src" to "dst". Directories on different filesystems can't be renamed, so the
+// content of "src" is copied to "dst" and "src" is removed afterwards. Rename also fails with EXDEV across
+// mount points of the same filesystem, e.g. bind mounts, in which case the copy is used as well.
+func moveDir(src string, dst string) error {
+	same, err := sameFilesystem(src, filepath.Dir(dst))
+	if err != nil {
+		return err
+	}
+
+	if same {
+		err = renameDir(src, dst)
+		if !errors.Is(err, syscall.EXDEV) {
+			return err
+		}
+
+		logger.Infof("Directory '%s' can't be renamed across mount points, copying it to '%s'", src, dst)
+	}
+
+	err = copyDir(src, dst)
+	if err != nil {
+		return fmt.Errorf("failed to copy '%s' to '%s': %w", src, dst, err)
+	}
+
+	err = os.RemoveAll(src)
+	if err != nil {
+		return fmt.Errorf("failed to remove '%s' after copying it to '%s': %w", src, dst, err)
+	}
+
+	return nil
+}
+
+// ListBackups returns information about all backups of MicroOVN data, created by cleanupPaths, that are
+// present in the directory configured by ConfigKeyBackupPath. Backups are sorted from the oldest to the
+// newest. Each backup is validated whether it can be used by Recover, see validateBackup.
+func ListBackups(s *state.State) ([]types.BackupInfo, error) {
+	root := backupRoot(s)
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory '%s': %w", root, err)
+	}
+
+	backups := []types.BackupInfo{}
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), backupDirPrefix) {
+			continue
+		}
+
+		timestamp, err := strconv.ParseInt(strings.TrimPrefix(entry.Name(), backupDirPrefix), 10, 64)
+		if err != nil {
+			// Not a backup created by MicroOVN
+			continue
+		}
+
+		backup, err := inspectBackup(filepath.Join(root, entry.Name()), timestamp)
+		if err != nil {
+			return nil, err
+		}
+
+		validateBackup(s, backup)
+
+		backups = append(backups, *backup)
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].Timestamp < backups[j].Timestamp
+	})
+
+	return backups, nil
+}
+
+// inspectBackup gathers information about backup directory located at "path".
+func inspectBackup(path string, timestamp int64) (*types.BackupInfo, error) {
+	backup := types.BackupInfo{
+		Name:      filepath.Base(path),
+		Path:      path,
+		Timestamp: timestamp,
+		Created:   time.Unix(timestamp, 0).UTC(),
+		Contents:  []string{},
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup directory '%s': %w", path, err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			backup.Contents = append(backup.Contents, entry.Name())
+		}
+	}
+
+	err = filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.Type().IsRegular() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+
+			backup.Size += info.Size()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate size of backup '%s': %w", path, err)
+	}
+
+	return &backup, nil
+}
+
+// FindBackup returns information about the backup created at unix "timestamp". Error with status 404 is
+// returned if there's no such backup.
+func FindBackup(s *state.State, timestamp int64) (*types.BackupInfo, error) {
+	backups, err := ListBackups(s)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, backup := range backups {
+		if backup.Timestamp == timestamp {
+			return &backup, nil
+		}
+	}
+
+	return nil, api.StatusErrorf(http.StatusNotFound, "no backup created at %d", timestamp)
+}
+
+// validateBackup checks whether "backup" can be used to recover OVN databases. Backup is complete if it
+// contains NB database, SB database is optional. Backup is compatible if schema of every database it contains
+// can be used by the installed OVN. Problems found by the checks are listed in backup.Problems.
+func validateBackup(s *state.State, backup *types.BackupInfo) {
+	backup.Problems = []string{}
+	backup.Complete = true
+	backup.Compatible = true
+
+	for _, dbType := range []OvsdbType{OvsdbTypeNBLocal, OvsdbTypeSBLocal} {
+		source, err := backupDatabaseFile(backup.Path, dbType)
+		if err != nil {
+			backup.Complete = false
+			backup.Problems = append(backup.Problems, err.Error())
+			continue
+		}
+
+		_, err = os.Stat(source)
+		if err != nil {
+			if dbType == OvsdbTypeNBLocal {
+				backup.Complete = false
+				backup.Problems = append(backup.Problems, fmt.Sprintf("NB database is missing: %s", err))
+			}
+
+			continue
+		}
+
+		err = checkBackupSchema(s, dbType, source)
+		if err != nil {
+			backup.Compatible = false
+			backup.Problems = append(backup.Problems, err.Error())
+		}
+	}
+}
+
+// backupDatabaseFile returns path to the database file of "dbType" in the backup directory "backupPath".
+// Backups keep the layout of paths.Root().
+func backupDatabaseFile(backupPath string, dbType OvsdbType) (string, error) {
+	dbFile, err := databaseFile(dbType)
+	if err != nil {
+		return "", err
+	}
+
+	relPath, err := filepath.Rel(paths.Root(), dbFile)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(backupPath, relPath), nil
+}
+
+// checkBackupSchema returns error if schema of the database "source" of "dbType" can't be used by the
+// installed OVN. ovsdb-server upgrades databases with older schema of the same major version, but databases
+// from a newer OVN, or from before a schema-incompatible upgrade (different major version), can't be used.
+func checkBackupSchema(s *state.State, dbType OvsdbType, source string) error {
+	schemaFile := paths.OvnNBSchemaFile()
+	if dbType == OvsdbTypeSBLocal {
+		schemaFile = paths.OvnSBSchemaFile()
+	}
+
+	output, err := shared.RunCommandContext(s.Context, "ovsdb-tool", "schema-version", schemaFile)
+	if err != nil {
+		return fmt.Errorf("failed to get version of schema '%s': %w", schemaFile, err)
+	}
+
+	current := strings.TrimSpace(output)
+	backup, err := databaseSchemaVersion(s, source)
+	if err != nil {
+		return err
+	}
+
+	currentVersion, err := parseSchemaVersion(current)
+	if err != nil {
+		return err
+	}
+
+	backupVersion, err := parseSchemaVersion(backup)
+	if err != nil {
+		return err
+	}
+
+	if backupVersion[0] != currentVersion[0] {
+		return fmt.Errorf("database '%s' has schema version %s, which is incompatible with installed schema %s", source, backup, current)
+	}
+
+	for i := range backupVersion {
+		if backupVersion[i] != currentVersion[i] {
+			if backupVersion[i] > currentVersion[i] {
+				return fmt.Errorf("database '%s' has schema version %s, which is newer than installed schema %s", source, backup, current)
+			}
+
+			break
+		}
+	}
+
+	return nil
+}
+
+// databaseSchemaVersion returns version of the schema of database file "dbFile". Clustered databases are
+// converted to standalone in a temporary directory first, as their schema can't be read directly.
+func databaseSchemaVersion(s *state.State, dbFile string) (string, error) {
+	// "db-is-clustered" exits with non-zero status for standalone databases.
+	_, err := shared.RunCommandContext(s.Context, "ovsdb-tool", "db-is-clustered", dbFile)
+	if err == nil {
+		tmpDir, err := os.MkdirTemp("", "microovn-backup-")
+		if err != nil {
+			return "", fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+
+		defer func() { _ = os.RemoveAll(tmpDir) }()
+
+		standalone := filepath.Join(tmpDir, filepath.Base(dbFile))
+		_, err = shared.RunCommandContext(s.Context, "ovsdb-tool", "cluster-to-standalone", standalone, dbFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to convert '%s' to standalone database: %w", dbFile, err)
+		}
+
+		dbFile = standalone
+	}
+
+	output, err := shared.RunCommandContext(s.Context, "ovsdb-tool", "db-version", dbFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to get schema version of '%s': %w", dbFile, err)
+	}
+
+	return strings.TrimSpace(output), nil
+}
+
+// parseSchemaVersion parses OVSDB schema "version" in the "major.minor.patch" format.
+func parseSchemaVersion(version string) ([3]int, error) {
+	parsed := [3]int{}
+	parts := strings.Split(version, ".")
+	if len(parts) != len(parsed) {
+		return parsed, fmt.Errorf("invalid schema version '%s'", version)
+	}
+
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil {
+			return parsed, fmt.Errorf("invalid schema version '%s': %w", version, err)
+		}
+
+		parsed[i] = number
+	}
+
+	return parsed, nil
+}
@@ -0,0 +1,98 @@
+package ovn
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+func TestMoveDirCrossDevice(t *testing.T) {
+	root := t.TempDir()
+	src := filepath.Join(root, "central", "data")
+	dst := filepath.Join(root, "backup_1", "data")
+
+	err := os.MkdirAll(filepath.Join(src, "nested"), 0750)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(dst), 0750)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"ovnnb_db.db":        "nb database",
+		"nested/ovnsb_db.db": "sb database",
+	}
+
+	for name, content := range files {
+		err = os.WriteFile(filepath.Join(src, name), []byte(content), 0640)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Simulate data directory on a different mount point than the backup directory.
+	renameCalled := false
+	renameDir = func(oldpath string, newpath string) error {
+		renameCalled = true
+		return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: syscall.EXDEV}
+	}
+
+	defer func() { renameDir = os.Rename }()
+
+	err = moveDir(src, dst)
+	if err != nil {
+		t.Fatalf("moveDir() failed: %s", err)
+	}
+
+	if !renameCalled {
+		t.Error("moveDir() did not try to rename the directory")
+	}
+
+	_, err = os.Stat(src)
+	if !os.IsNotExist(err) {
+		t.Errorf("source directory '%s' was not removed: %v", src, err)
+	}
+
+	for name, content := range files {
+		got, err := os.ReadFile(filepath.Join(dst, name))
+		if err != nil {
+			t.Errorf("file '%s' was not copied: %s", name, err)
+			continue
+		}
+
+		if string(got) != content {
+			t.Errorf("file '%s' has content %q, expected %q", name, got, content)
+		}
+	}
+}
+
+func TestMoveDirRenameError(t *testing.T) {
+	root := t.TempDir()
+	src := filepath.Join(root, "data")
+
+	err := os.Mkdir(src, 0750)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Errors other than EXDEV are not handled by copying.
+	renameDir = func(oldpath string, newpath string) error {
+		return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: syscall.EACCES}
+	}
+
+	defer func() { renameDir = os.Rename }()
+
+	err = moveDir(src, filepath.Join(root, "backup"))
+	if err == nil {
+		t.Fatal("moveDir() succeeded, expected rename error")
+	}
+
+	_, err = os.Stat(src)
+	if err != nil {
+		t.Errorf("source directory was removed after failed rename: %s", err)
+	}
+}
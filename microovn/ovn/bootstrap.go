@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/logger"
 
 	"github.com/canonical/microovn/microovn/database"
 )
@@ -57,8 +58,13 @@ func Bootstrap(s *state.State) error {
 		return err
 	}
 
+	snapshot, err := loadServicesSnapshot(s)
+	if err != nil {
+		return err
+	}
+
 	// Generate the configuration.
-	err = generateEnvironment(s)
+	err = generateEnvironment(s, snapshot)
 	if err != nil {
 		return fmt.Errorf("Failed to generate the daemon configuration: %w", err)
 	}
@@ -98,6 +104,8 @@ func Bootstrap(s *state.State) error {
 		return fmt.Errorf("Failed to start OVN central: %w", err)
 	}
 
+	recordClusterIDsOnStart(s)
+
 	// Generate certificate for OVN chassis (controller)
 	err = GenerateNewServiceCertificate(s, "ovn-controller", CertificateTypeServer)
 	if err != nil {
@@ -111,10 +119,7 @@ func Bootstrap(s *state.State) error {
 	}
 
 	// Configure OVS to use OVN.
-	sbConnect, err := connectString(s, 6642)
-	if err != nil {
-		return fmt.Errorf("Failed to get OVN SB connect string: %w", err)
-	}
+	sbConnect := snapshot.ConnectString(networkProtocol(s), 6642)
 
 	err = updateOvnListenConfig(s)
 	if err != nil {
@@ -124,15 +129,26 @@ func Bootstrap(s *state.State) error {
 	_, err = VSCtl(
 		s,
 		"set", "open_vswitch", ".",
-		fmt.Sprintf("external_ids:system-id=%s", s.Name()),
 		fmt.Sprintf("external_ids:ovn-remote=%s", sbConnect),
 		"external_ids:ovn-encap-type=geneve",
-		fmt.Sprintf("external_ids:ovn-encap-ip=%s", s.Address().Hostname()),
 	)
 
 	if err != nil {
 		return fmt.Errorf("Error configuring OVS parameters: %s", err)
 	}
 
+	err = updateChassisConfig(s)
+	if err != nil {
+		return fmt.Errorf("Failed to apply OVN chassis configuration: %w", err)
+	}
+
+	// Gateway records may already exist for this chassis, e.g. when it rejoins the cluster.
+	err = applyGatewayPriority(s)
+	if err != nil {
+		logger.Warnf("Failed to apply gateway priority: %s", err)
+	}
+
+	startMonitors(s)
+
 	return nil
 }
@@ -0,0 +1,211 @@
+package ovn
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/canonical/microcluster/client"
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/api"
+	"github.com/lxc/lxd/shared/logger"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	microovnClient "github.com/canonical/microovn/microovn/client"
+	"github.com/canonical/microovn/microovn/database"
+)
+
+// CAPreviousCertRecordName is a key used to store certificate of the CA that is being retired by RotateCA.
+// It's present only while the rotation is in progress.
+const CAPreviousCertRecordName = "ca_cert_previous"
+
+// Steps of CA rotation that are executed on every cluster member, see ApplyCARotationStep.
+const (
+	CARotationStepTrust   = "trust"   // Write current CA certificates, including the retired one, to disk
+	CARotationStepReissue = "reissue" // Reissue certificates of local services with the current CA
+)
+
+// RotateCA replaces cluster CA with a newly generated one, without interrupting mutual trust between members.
+// Rotation proceeds in following stages, each one completed on every member before the next one begins:
+//
+//  1. New CA is stored in the shared database and the current one is kept as the previous CA
+//  2. Both CA certificates are trusted on every member
+//  3. Service certificates are reissued with the new CA on every member
+//  4. Previous CA is retired and only the new one stays trusted on every member
+//
+// If any member fails the second or the third stage, rotation stops and the previous CA stays trusted, so
+// the cluster keeps working with a mix of certificates. Calling RotateCA again resumes the interrupted
+// rotation instead of generating another CA.
+//
+// Client certificates issued by IssueClientCert are not reissued, as their private keys are held by the
+// external clients. Those issued by the previous CA are no longer trusted once it's retired. They are logged
+// before the last stage and returned, so that new certificates can be issued for their clients.
+func RotateCA(s *state.State) ([]types.ClientCertificate, error) {
+	err := stageCARotation(s)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, step := range []string{CARotationStepTrust, CARotationStepReissue} {
+		err = applyCARotationStepAll(s, step)
+		if err != nil {
+			return nil, fmt.Errorf("CA rotation interrupted, previous CA stays trusted: %w", err)
+		}
+	}
+
+	caCert, _, err := getCA(s)
+	if err != nil {
+		return nil, err
+	}
+
+	untrusted, err := untrustedClientCerts(s, caCert)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cert := range untrusted {
+		logger.Warnf("Client certificate '%s' (serial %s) was issued by the previous CA and won't be trusted after rotation", cert.CN, cert.Serial)
+	}
+
+	err = s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		return database.DeleteConfigItem(ctx, tx, CAPreviousCertRecordName)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retire previous CA: %w", err)
+	}
+
+	logger.Info("Previous CA retired")
+	return untrusted, applyCARotationStepAll(s, CARotationStepTrust)
+}
+
+// ApplyCARotationStep executes single "step" of CA rotation on this cluster member.
+func ApplyCARotationStep(s *state.State, step string) error {
+	switch step {
+	case CARotationStepTrust:
+		return DumpCA(s)
+	case CARotationStepReissue:
+		centralActive, err := localServiceActive(s, "central")
+		if err != nil {
+			return fmt.Errorf("failed to query local services: %w", err)
+		}
+
+		switchActive, err := localServiceActive(s, "switch")
+		if err != nil {
+			return fmt.Errorf("failed to query local services: %w", err)
+		}
+
+		for _, service := range localCertificateServices(centralActive, switchActive) {
+			err = GenerateNewServiceCertificate(s, service, CertificateTypeServer)
+			if err != nil {
+				return fmt.Errorf("failed to reissue %s certificate: %w", service, err)
+			}
+		}
+
+		return nil
+	default:
+		return api.StatusErrorf(http.StatusBadRequest, "unknown CA rotation step '%s'", step)
+	}
+}
+
+// stageCARotation generates new CA and stores it in the shared database, keeping certificate of the current
+// CA as the previous one. Nothing is done if rotation is already in progress.
+func stageCARotation(s *state.State) error {
+	cert, key, err := issueCertificate("MicroOVN CA", "MicroOVN CA", CertificateTypeCA, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	staged := false
+	err = s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		previous, err := getPreviousCACert(ctx, tx)
+		if err != nil || previous != "" {
+			return err
+		}
+
+		current, err := database.GetConfigItem(ctx, tx, CACertRecordName)
+		if err != nil {
+			return fmt.Errorf("failed to get CA certificate from the database: %w", err)
+		}
+
+		_, err = database.CreateConfigItem(ctx, tx, database.ConfigItem{Key: CAPreviousCertRecordName, Value: current.Value})
+		if err != nil {
+			return fmt.Errorf("failed to store previous CA certificate: %w", err)
+		}
+
+		err = database.UpdateConfigItem(ctx, tx, CACertRecordName, database.ConfigItem{Key: CACertRecordName, Value: string(cert)})
+		if err != nil {
+			return fmt.Errorf("failed to store CA certificate: %w", err)
+		}
+
+		err = database.UpdateConfigItem(ctx, tx, CAKeyRecordName, database.ConfigItem{Key: CAKeyRecordName, Value: string(key)})
+		if err != nil {
+			return fmt.Errorf("failed to store CA private key: %w", err)
+		}
+
+		staged = true
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if staged {
+		logger.Info("New CA generated, previous CA kept until rotation completes")
+	} else {
+		logger.Info("Resuming CA rotation that is already in progress")
+	}
+
+	return nil
+}
+
+// applyCARotationStepAll executes CA rotation "step" on every cluster member, including this one. Failure on
+// any member does not stop the step on the others, but all failures are reported in the returned error.
+func applyCARotationStepAll(s *state.State, step string) error {
+	var mu sync.Mutex
+	failed := []string{}
+
+	logger.Infof("Executing CA rotation step '%s' on every cluster member", step)
+	err := ApplyCARotationStep(s, step)
+	if err != nil {
+		failed = append(failed, fmt.Sprintf("%s: %s", s.Name(), err))
+	}
+
+	err = QueryCluster(s, nil, func(ctx context.Context, c *client.Client) error {
+		err := microovnClient.ApplyCARotationStep(ctx, c, step)
+		if err != nil {
+			clientURL := c.URL()
+			mu.Lock()
+			failed = append(failed, fmt.Sprintf("%s: %s", clientURL.String(), err))
+			mu.Unlock()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("step '%s' failed on some members: %s", step, strings.Join(failed, "; "))
+	}
+
+	return nil
+}
+
+// getPreviousCACert returns PEM encoded certificate of the CA that is being retired by RotateCA. Empty string
+// is returned if no rotation is in progress.
+func getPreviousCACert(ctx context.Context, tx *sql.Tx) (string, error) {
+	record, err := database.GetConfigItem(ctx, tx, CAPreviousCertRecordName)
+	if err != nil {
+		if api.StatusErrorCheck(err, http.StatusNotFound) {
+			return "", nil
+		}
+
+		return "", fmt.Errorf("failed to get previous CA certificate from the database: %w", err)
+	}
+
+	return record.Value, nil
+}
@@ -12,12 +12,15 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"net/netip"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/canonical/microcluster/state"
 	"github.com/canonical/microovn/microovn/database"
 	"github.com/canonical/microovn/microovn/ovn/paths"
+	"github.com/lxc/lxd/shared/logger"
 )
 
 const CACertRecordName = "ca_cert"                   // Key used to store CA certificate in config DB table
@@ -45,8 +48,11 @@ const (
 // "signer" argument must point to CA's private key. On the other hand if you want to generate self-signed certificate,
 // both "parent" and "signer" arguments must be empty (nil).
 //
+// Values in "altNames" are added to the certificate's SAN, IP addresses as IP SANs and everything else as DNS
+// names.
+//
 // This function returns PEM encoded certificate, private key and error (if any occurred).
-func issueCertificate(cn string, serviceName string, certType CertificateType, parent *x509.Certificate, signer *ecdsa.PrivateKey) ([]byte, []byte, error) {
+func issueCertificate(cn string, serviceName string, certType CertificateType, parent *x509.Certificate, signer *ecdsa.PrivateKey, altNames []string) ([]byte, []byte, error) {
 	var (
 		isCa     bool
 		keyUsage x509.KeyUsage
@@ -99,6 +105,15 @@ func issueCertificate(cn string, serviceName string, certType CertificateType, p
 		IsCA:                  isCa,
 	}
 
+	for _, name := range altNames {
+		addr, err := netip.ParseAddr(name)
+		if err == nil {
+			template.IPAddresses = append(template.IPAddresses, addr.AsSlice())
+		} else {
+			template.DNSNames = append(template.DNSNames, name)
+		}
+	}
+
 	// If there's no parent, use certificate's own key to self-sign it.
 	if parent == nil {
 		parent = &template
@@ -126,11 +141,18 @@ func issueCertificate(cn string, serviceName string, certType CertificateType, p
 // GenerateNewCACertificate generates new CA certificate and private key and stores them in the shared MicroOVN
 // database.
 func GenerateNewCACertificate(s *state.State) error {
-	cert, key, err := issueCertificate("MicroOVN CA", "MicroOVN CA", CertificateTypeCA, nil, nil)
+	cert, key, err := issueCertificate("MicroOVN CA", "MicroOVN CA", CertificateTypeCA, nil, nil, nil)
 	if err != nil {
 		return err
 	}
 
+	return storeCA(s, cert, key)
+}
+
+// storeCA stores PEM encoded CA certificate and private key in the shared MicroOVN database, replacing
+// the current CA.
+func storeCA(s *state.State, cert []byte, key []byte) error {
+	var err error
 	caCert := database.ConfigItem{
 		Key:   CACertRecordName,
 		Value: string(cert),
@@ -168,26 +190,34 @@ func GenerateNewCACertificate(s *state.State) error {
 		return err
 	})
 
+	invalidateNetworkProtocol()
 	return err
 }
 
 // DumpCA copies CA certificate from shared database and stores it in pre-defined file on disk. File path
-// to store CA certificate is defined in paths.PkiCaCertFile.
+// to store CA certificate is defined in paths.PkiCaCertFile. While CA rotation is in progress, the previous
+// CA certificate is appended to the file, so that certificates signed by either CA are trusted. List of
+// revoked client certificates is rewritten as well, see WriteCRL, failure to do so is only logged.
 func DumpCA(s *state.State) error {
 	var err error
 	var CACertRecord *database.ConfigItem
+	var previousCACert string
 
 	err = s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
 		CACertRecord, err = database.GetConfigItem(ctx, tx, CACertRecordName)
 		if err != nil {
 			return fmt.Errorf("failed to get CA certificate from the database: %s", err)
 		}
+
+		previousCACert, err = getPreviousCACert(ctx, tx)
 		return err
 	})
 	if err != nil {
 		return err
 	}
 
+	invalidateNetworkProtocol()
+
 	certPath := paths.PkiCaCertFile()
 	certFile, err := os.Create(certPath)
 	if err != nil {
@@ -200,13 +230,68 @@ func DumpCA(s *state.State) error {
 		return fmt.Errorf("unable to set permissions for CA certificate: %w", err)
 	}
 
-	_, err = certFile.WriteString(CACertRecord.Value)
+	_, err = certFile.WriteString(CACertRecord.Value + previousCACert)
 	if err != nil {
 		return fmt.Errorf("failed to write CA certificate into file %s: %w", certPath, err)
 	}
+
+	// CRL is signed by the CA, so it has to follow CA changes. It's not used by OVN databases, failure to
+	// write it does not prevent use of the CA.
+	err = WriteCRL(s)
+	if err != nil {
+		logger.Warnf("Failed to write list of revoked client certificates: %s", err)
+	}
+
 	return nil
 }
 
+// GetCACertPEM returns PEM encoded CA certificate stored in the shared MicroOVN database.
+func GetCACertPEM(s *state.State) (string, error) {
+	var err error
+	var CACertRecord *database.ConfigItem
+
+	err = s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		CACertRecord, err = database.GetConfigItem(ctx, tx, CACertRecordName)
+		if err != nil {
+			return fmt.Errorf("failed to get CA certificate from the database: %s", err)
+		}
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return CACertRecord.Value, nil
+}
+
+// IssueClientCert issues new client certificate, signed by the CA stored in the shared MicroOVN database,
+// that can be used by external clients to connect to OVN databases. Argument "cn" is used as a certificate's
+// CN. Unlike service certificates, client certificate is not stored on disk, only its CN, serial number and
+// expiry are recorded in the shared database, see ListClientCerts. This function returns PEM encoded
+// certificate and private key.
+//
+// When the cluster uses SSL, OVN NB and SB databases are started with the cluster CA certificate and accept
+// only clients that present a certificate signed by it, so external clients need a certificate issued by this
+// function.
+func IssueClientCert(s *state.State, cn string) ([]byte, []byte, error) {
+	caCert, caKey, err := getCA(s)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cert, key, err := issueCertificate(cn, "client", CertificateTypeClient, caCert, caKey, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = recordClientCert(s, cert)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cert, key, nil
+}
+
 // getCA pulls PEM encoded CA certificate and private key from shared database and returns
 // them as parsed objects x509.Certificate and ecdsa.PrivateKey (+ error if any occurred).
 func getCA(s *state.State) (*x509.Certificate, *ecdsa.PrivateKey, error) {
@@ -291,7 +376,15 @@ func GenerateNewServiceCertificate(s *state.State, serviceName string, certType
 		return err
 	}
 
-	cert, key, err := issueCertificate(s.Name(), serviceName, certType, caCert, caKey)
+	altNames, err := serviceCertificateSANs(s)
+	if err != nil {
+		return err
+	}
+
+	cert, key, err := issueCertificate(s.Name(), serviceName, certType, caCert, caKey, altNames)
+	if err != nil {
+		return err
+	}
 
 	_, err = certFile.Write(cert)
 	if err != nil {
@@ -306,6 +399,80 @@ func GenerateNewServiceCertificate(s *state.State, serviceName string, certType
 	return nil
 }
 
+// serviceCertificateSANs returns names and addresses under which OVN services of this member can be reached.
+// They are included in the SAN of every service certificate issued by GenerateNewServiceCertificate:
+//   - member name and hostname
+//   - management address of the member and its service address, if it's different
+//   - OVN tunnel (encap) address, if it differs from the management address
+//   - additional names and addresses configured in ConfigKeyTLSExtraSANs
+func serviceCertificateSANs(s *state.State) ([]string, error) {
+	address, err := serviceAddress(s)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{s.Name(), s.Address().Hostname(), address}
+
+	hostname, err := os.Hostname()
+	if err == nil {
+		names = append(names, hostname)
+	}
+
+	encapAddr, err := encapAddress(s)
+	if err != nil {
+		return nil, err
+	}
+
+	names = append(names, encapAddr)
+
+	extra, err := GetConfig(s, ConfigKeyTLSExtraSANs)
+	if err != nil {
+		return nil, err
+	}
+
+	names = append(names, strings.Split(extra, ",")...)
+
+	seen := map[string]bool{}
+	sans := []string{}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+
+		seen[name] = true
+		sans = append(sans, name)
+	}
+
+	return sans, nil
+}
+
+// readServiceCertificate loads and parses current certificate of the local "service". If the certificate file
+// does not exist, returned error wraps os.ErrNotExist.
+func readServiceCertificate(service string) (*x509.Certificate, error) {
+	certPath, _, err := getServiceCertificatePaths(service)
+	if err != nil {
+		return nil, err
+	}
+
+	certPEM, err := os.ReadFile(certPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s certificate: %w", service, err)
+	}
+
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode %s certificate", service)
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s certificate: %w", service, err)
+	}
+
+	return cert, nil
+}
+
 // getServiceCertificatePaths returns paths to certificate and private key based on service name
 func getServiceCertificatePaths(service string) (string, string, error) {
 	var (
@@ -0,0 +1,366 @@
+package ovn
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/logger"
+
+	"github.com/canonical/microovn/microovn/database"
+)
+
+// Defaults for the delays between checks made by MicroOVN, when chassis starts, while waiting for OVN
+// Southbound database to become reachable. Both values are in milliseconds and can be overridden by
+// ConfigKeySBWaitMinInterval and ConfigKeySBWaitMaxInterval. They don't affect reconnection backoff of
+// ovn-controller itself, which is not configurable.
+const defaultSBWaitMinInterval = 1000
+const defaultSBWaitMaxInterval = 8000
+const sbRetryTimeout = 60 * time.Second // Maximum time to wait for OVN Southbound database to become reachable
+
+// sbChassis is a minimal representation of a record in the OVN Southbound "Chassis" table.
+type sbChassis struct {
+	Name     string
+	Hostname string
+}
+
+// listSBChassis returns all chassis currently registered in the OVN Southbound database. The query
+// is executed against the local SB database socket, so this function must be called on a member
+// that runs the "central" service.
+func listSBChassis(s *state.State) ([]sbChassis, error) {
+	records, err := listSBTable(s, "Chassis", "name", "hostname")
+	if err != nil {
+		return nil, err
+	}
+
+	chassis := make([]sbChassis, 0, len(records))
+	for _, record := range records {
+		chassis = append(chassis, sbChassis{Name: record[0], Hostname: record[1]})
+	}
+
+	return chassis, nil
+}
+
+// FindOrphanedChassis returns names of chassis registered in the OVN Southbound database that do not
+// belong to any current MicroOVN cluster member. Chassis is considered to be owned by a member if
+// either its name (system-id) or its hostname matches name of a member that runs the "chassis" service.
+// Such orphaned entries are usually left behind by members that crashed and were rebuilt without
+// gracefully leaving the cluster.
+//
+// This function must be executed on a member that runs the "central" service.
+func FindOrphanedChassis(s *state.State) ([]string, error) {
+	centralActive, err := localServiceActive(s, "central")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query local services: %w", err)
+	}
+
+	if !centralActive {
+		return nil, fmt.Errorf("orphaned chassis lookup requires local 'central' service")
+	}
+
+	// Collect names of members that are expected to run OVN chassis.
+	owners := make(map[string]bool)
+	remotes := s.Remotes().RemotesByName()
+	err = s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		serviceName := "chassis"
+		services, err := database.GetServices(ctx, tx, database.ServiceFilter{Service: &serviceName})
+		if err != nil {
+			return err
+		}
+
+		for _, srv := range services {
+			_, ok := remotes[srv.Member]
+			if ok {
+				owners[srv.Member] = true
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	chassis, err := listSBChassis(s)
+	if err != nil {
+		return nil, err
+	}
+
+	orphans := []string{}
+	for _, ch := range chassis {
+		if owners[ch.Name] || owners[ch.Hostname] {
+			continue
+		}
+
+		orphans = append(orphans, ch.Name)
+	}
+
+	return orphans, nil
+}
+
+// RemoveOrphanedChassis removes every chassis reported by FindOrphanedChassis from the OVN Southbound
+// database. It returns list of chassis that were successfully removed. Failure to remove any of the
+// chassis does not stop removal of the others, but it is reported in the returned error.
+//
+// This function must be executed on a member that runs the "central" service.
+func RemoveOrphanedChassis(s *state.State) ([]string, error) {
+	orphans, err := FindOrphanedChassis(s)
+	if err != nil {
+		return nil, err
+	}
+
+	removed := []string{}
+	failed := []string{}
+	for _, chassis := range orphans {
+		logger.Infof("Removing orphaned chassis '%s' from OVN SB database.", chassis)
+		_, err = localSBCtl(s, "--if-exists", "chassis-del", chassis)
+		if err != nil {
+			logger.Warnf("Failed to remove orphaned chassis '%s': %s", chassis, err)
+			failed = append(failed, chassis)
+			continue
+		}
+
+		removed = append(removed, chassis)
+	}
+
+	if len(failed) > 0 {
+		return removed, fmt.Errorf("failed to remove orphaned chassis: %s", strings.Join(failed, ", "))
+	}
+
+	return removed, nil
+}
+
+// updateChassisConfig applies user-configurable OVN chassis options, stored in the shared MicroOVN
+// database, to the local OVS database. Options that are not set are removed from the local configuration,
+// reverting them to OVN defaults.
+func updateChassisConfig(s *state.State) error {
+	err := ensureSystemID(s)
+	if err != nil {
+		return fmt.Errorf("failed to configure chassis system-id: %w", err)
+	}
+
+	bridge, err := integrationBridge(s)
+	if err != nil {
+		return err
+	}
+
+	datapathType, err := configuredDatapathType(s)
+	if err != nil {
+		return err
+	}
+
+	currentType, err := bridgeDatapathType(s, bridge)
+	if err != nil {
+		return fmt.Errorf("failed to get datapath type of integration bridge '%s': %w", bridge, err)
+	}
+
+	if currentType == "" {
+		_, err = VSCtl(s, "--may-exist", "add-br", bridge, "--", "set", "bridge", bridge, fmt.Sprintf("datapath_type=%s", datapathType))
+		if err != nil {
+			return fmt.Errorf("failed to create integration bridge '%s': %w", bridge, err)
+		}
+	} else if currentType != datapathType {
+		logger.Warnf("Integration bridge '%s' uses '%s' datapath instead of configured '%s'. Existing bridge is not recreated automatically, delete it and restart the chassis service to apply the change.", bridge, currentType, datapathType)
+	}
+
+	return EnsureChassisExternalIDs(s)
+}
+
+// chassisExternalIDs returns desired values of OVN chassis settings stored in external_ids of local
+// Open_vSwitch table, derived from the shared MicroOVN database. Settings with empty value are expected to
+// be unset.
+//
+// By default, ovn-controller monitors only the parts of OVN SB that are relevant to datapaths with ports
+// bound to the chassis, which keeps memory usage and SB load low on edge chassis. Setting ConfigKeyMonitorAll
+// makes it monitor the whole database instead. That avoids recomputation of monitor conditions when ports
+// are bound or unbound, at the cost of memory and bandwidth proportional to the size of the whole SB.
+//
+// Chassis with ConfigKeyGatewayPriority set is advertised as a gateway candidate via "ovn-cms-options".
+func chassisExternalIDs(s *state.State) (map[string]string, error) {
+	bridge, err := integrationBridge(s)
+	if err != nil {
+		return nil, err
+	}
+
+	encapAddr, err := encapAddress(s)
+	if err != nil {
+		return nil, err
+	}
+
+	sbConnect, err := connectString(s, 6642)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get OVN SB connect string: %w", err)
+	}
+
+	externalIDs := map[string]string{
+		"ovn-remote":     sbConnect,
+		"ovn-encap-type": "geneve",
+		"ovn-encap-ip":   encapAddr,
+		"ovn-bridge":     bridge,
+	}
+
+	for key, cfgKey := range map[string]string{
+		"ovn-encap-tos":             ConfigKeyEncapTos,
+		"ovn-remote-probe-interval": ConfigKeyRemoteProbeInterval,
+		"ovn-monitor-all":           ConfigKeyMonitorAll,
+	} {
+		externalIDs[key], err = GetConfig(s, cfgKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	externalIDs["ovn-cms-options"], err = gatewayCMSOptions(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return externalIDs, nil
+}
+
+// EnsureChassisExternalIDs reconciles all OVN chassis settings in external_ids of local Open_vSwitch table
+// with the configuration stored in the shared MicroOVN database. Only settings whose value differs are
+// changed, all of them in a single transaction, so calling this function repeatedly has no effect.
+func EnsureChassisExternalIDs(s *state.State) error {
+	desired, err := chassisExternalIDs(s)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(desired))
+	for key := range desired {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	args := []string{}
+	changed := []string{}
+	for _, key := range keys {
+		current, err := getChassisExternalID(s, key)
+		if err != nil {
+			return fmt.Errorf("failed to get chassis setting '%s': %w", key, err)
+		}
+
+		value := desired[key]
+		if current == value {
+			continue
+		}
+
+		if len(args) > 0 {
+			args = append(args, "--")
+		}
+
+		if value == "" {
+			args = append(args, "remove", "open_vswitch", ".", "external_ids", key)
+		} else {
+			args = append(args, "set", "open_vswitch", ".", fmt.Sprintf("external_ids:%s=%s", key, strconv.Quote(value)))
+		}
+
+		changed = append(changed, key)
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	_, err = VSCtl(s, args...)
+	if err != nil {
+		return fmt.Errorf("failed to apply chassis settings %s: %w", strings.Join(changed, ", "), err)
+	}
+
+	logger.Debugf("Updated chassis settings: %s", strings.Join(changed, ", "))
+	return nil
+}
+
+// setChassisExternalID sets "key" in external_ids of local Open_vSwitch table to the "value". If the
+// "value" is empty, the key is removed.
+func setChassisExternalID(s *state.State, key string, value string) error {
+	var err error
+	if value == "" {
+		_, err = VSCtl(s, "remove", "open_vswitch", ".", "external_ids", key)
+	} else {
+		_, err = VSCtl(s, "set", "open_vswitch", ".", fmt.Sprintf("external_ids:%s=%s", key, strconv.Quote(value)))
+	}
+
+	return err
+}
+
+// getChassisExternalID returns value of "key" from external_ids of local Open_vSwitch table. Empty
+// string is returned if the key is not set.
+func getChassisExternalID(s *state.State, key string) (string, error) {
+	value, err := VSCtl(s, "--if-exists", "get", "open_vswitch", ".", fmt.Sprintf("external_ids:%s", key))
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Trim(strings.TrimSpace(value), "\""), nil
+}
+
+// waitForSouthbound waits until at least one of the OVN Southbound endpoints in "sbConnect" accepts
+// connections. Delay between the attempts starts at ConfigKeySBWaitMinInterval and doubles after each
+// failed attempt, up to ConfigKeySBWaitMaxInterval. If none of the endpoints becomes reachable within
+// sbRetryTimeout, an error is returned.
+func waitForSouthbound(s *state.State, sbConnect string) error {
+	minBackoff, err := getConfigInt(s, ConfigKeySBWaitMinInterval, defaultSBWaitMinInterval)
+	if err != nil {
+		return err
+	}
+
+	maxBackoff, err := getConfigInt(s, ConfigKeySBWaitMaxInterval, defaultSBWaitMaxInterval)
+	if err != nil {
+		return err
+	}
+
+	if maxBackoff < minBackoff {
+		maxBackoff = minBackoff
+	}
+
+	deadline := time.Now().Add(sbRetryTimeout)
+	backoff := time.Duration(minBackoff) * time.Millisecond
+	for {
+		if southboundReachable(sbConnect) {
+			return nil
+		}
+
+		if time.Now().Add(backoff).After(deadline) {
+			return fmt.Errorf("OVN Southbound database (%s) is not reachable", sbConnect)
+		}
+
+		logger.Infof("OVN Southbound database is not reachable yet, retrying in %s", backoff)
+		select {
+		case <-s.Context.Done():
+			return s.Context.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > time.Duration(maxBackoff)*time.Millisecond {
+			backoff = time.Duration(maxBackoff) * time.Millisecond
+		}
+	}
+}
+
+// southboundReachable returns true if any of the endpoints in OVN connection string "sbConnect"
+// (e.g. "ssl:10.0.0.1:6642,ssl:10.0.0.2:6642") accepts TCP connections.
+func southboundReachable(sbConnect string) bool {
+	for _, endpoint := range strings.Split(sbConnect, ",") {
+		_, address, found := strings.Cut(endpoint, ":")
+		if !found {
+			continue
+		}
+
+		conn, err := net.DialTimeout("tcp", address, 2*time.Second)
+		if err == nil {
+			_ = conn.Close()
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,164 @@
+package ovn
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/canonical/microcluster/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/database"
+)
+
+// listSBTable returns values of "columns" from every record of the OVN Southbound "table". Set and map
+// columns are returned in the "bare" format, with elements separated by spaces.
+func listSBTable(s *state.State, table string, columns ...string) ([][]string, error) {
+	output, err := localSBCtl(
+		s,
+		"--format=csv",
+		"--data=bare",
+		"--no-headings",
+		fmt.Sprintf("--columns=%s", strings.Join(columns, ",")),
+		"list",
+		table,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list OVN SB table '%s': %w", table, err)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(output)).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse OVN SB table '%s': %w", table, err)
+	}
+
+	rows := make([][]string, 0, len(records))
+	for _, record := range records {
+		if len(record) == len(columns) {
+			rows = append(rows, record)
+		}
+	}
+
+	return rows, nil
+}
+
+// ListChassis returns every chassis registered in the OVN Southbound database, along with its tunnel
+// encapsulations and logical ports currently bound to it. Chassis are mapped to MicroOVN members that run
+// the "chassis" service by their name (system-id) or hostname, in the same way as FindOrphanedChassis
+// does. Chassis that don't belong to any member have empty "Member" field. Labels of the owning member are
+// included, so that chassis can be correlated with member metadata. Gateway chassis report their priority in
+// every HA chassis group, so that active and backup gateways can be told apart.
+//
+// This function must be executed on a member that runs the "central" service.
+func ListChassis(s *state.State) ([]types.ChassisInfo, error) {
+	centralActive, err := localServiceActive(s, "central")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query local services: %w", err)
+	}
+
+	if !centralActive {
+		return nil, fmt.Errorf("chassis listing requires local 'central' service")
+	}
+
+	members := make(map[string]bool)
+	err = s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		serviceName := "chassis"
+		services, err := database.GetServices(ctx, tx, database.ServiceFilter{Service: &serviceName})
+		if err != nil {
+			return err
+		}
+
+		for _, srv := range services {
+			members[srv.Member] = true
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	labels, err := getAllMemberLabels(s)
+	if err != nil {
+		return nil, err
+	}
+
+	encapRows, err := listSBTable(s, "Encap", "_uuid", "type", "ip")
+	if err != nil {
+		return nil, err
+	}
+
+	encaps := make(map[string]types.ChassisEncap, len(encapRows))
+	for _, row := range encapRows {
+		encaps[row[0]] = types.ChassisEncap{Type: row[1], IP: row[2]}
+	}
+
+	bindingRows, err := listSBTable(s, "Port_Binding", "logical_port", "type", "chassis")
+	if err != nil {
+		return nil, err
+	}
+
+	ports := make(map[string][]types.ChassisPort)
+	for _, row := range bindingRows {
+		if row[2] == "" {
+			continue
+		}
+
+		ports[row[2]] = append(ports[row[2]], types.ChassisPort{Name: row[0], Type: row[1]})
+	}
+
+	priorities, err := gatewayPriorities(s)
+	if err != nil {
+		return nil, err
+	}
+
+	chassisRows, err := listSBTable(s, "Chassis", "_uuid", "name", "hostname", "encaps", "other_config")
+	if err != nil {
+		return nil, err
+	}
+
+	chassis := make([]types.ChassisInfo, 0, len(chassisRows))
+	for _, row := range chassisRows {
+		info := types.ChassisInfo{
+			Name:     row[1],
+			Hostname: row[2],
+			Encaps:   []types.ChassisEncap{},
+			Ports:    ports[row[0]],
+
+			Gateway:           isGatewayChassis(row[4]),
+			GatewayPriorities: priorities[row[0]],
+		}
+
+		if members[info.Name] {
+			info.Member = info.Name
+		} else if members[info.Hostname] {
+			info.Member = info.Hostname
+		}
+
+		for _, encapID := range strings.Fields(row[3]) {
+			encap, ok := encaps[encapID]
+			if ok {
+				info.Encaps = append(info.Encaps, encap)
+			}
+		}
+
+		info.Labels = labels[info.Member]
+		if info.Labels == nil {
+			info.Labels = map[string]string{}
+		}
+
+		if info.Ports == nil {
+			info.Ports = []types.ChassisPort{}
+		}
+
+		if info.GatewayPriorities == nil {
+			info.GatewayPriorities = map[string]int{}
+		}
+
+		chassis = append(chassis, info)
+	}
+
+	return chassis, nil
+}
@@ -0,0 +1,63 @@
+package ovn
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/api"
+	"github.com/lxc/lxd/shared/logger"
+)
+
+// PauseChassis freezes local ovn-controller, so that it stops processing changes and installing new flows,
+// while the chassis stays registered in OVN SB database and already installed flows keep forwarding traffic.
+// This is meant for troubleshooting. Paused state does not survive restart of the "chassis" service.
+func PauseChassis(s *state.State) error {
+	return setChassisPaused(s, true)
+}
+
+// ResumeChassis resumes local ovn-controller paused by PauseChassis.
+func ResumeChassis(s *state.State) error {
+	return setChassisPaused(s, false)
+}
+
+// setChassisPaused pauses or resumes local ovn-controller, based on the value of "paused".
+func setChassisPaused(s *state.State, paused bool) error {
+	switchActive, err := localServiceActive(s, "switch")
+	if err != nil {
+		return fmt.Errorf("failed to query local services: %w", err)
+	}
+
+	if !switchActive {
+		return api.StatusErrorf(http.StatusBadRequest, "member '%s' does not run OVN chassis", s.Name())
+	}
+
+	command := "debug/resume"
+	if paused {
+		command = "debug/pause"
+	}
+
+	_, err = ControllerCtl(s, command)
+	if err != nil {
+		return fmt.Errorf("failed to execute '%s' on OVN Controller: %w", command, err)
+	}
+
+	if paused {
+		logger.Warn("OVN Controller paused, chassis does not install new flows until it's resumed")
+	} else {
+		logger.Info("OVN Controller resumed")
+	}
+
+	return nil
+}
+
+// chassisPaused returns true if local ovn-controller was paused by PauseChassis.
+func chassisPaused(s *state.State) (bool, error) {
+	output, err := ControllerCtl(s, "debug/status")
+	if err != nil {
+		return false, fmt.Errorf("failed to get OVN Controller status: %w", err)
+	}
+
+	return strings.TrimSpace(output) == "paused", nil
+}
@@ -0,0 +1,112 @@
+package ovn
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/api"
+	"github.com/lxc/lxd/shared/logger"
+
+	microovnClient "github.com/canonical/microovn/microovn/client"
+)
+
+const chassisReconnectWait = 60 * time.Second // Maximum time to wait for ovn-controller to reconnect to OVN SB
+
+// ReconfigureChassisRemote points local OVN chassis at the OVN SB servers currently run by "central"
+// members. SB connection string is recomputed from the cluster state and written to "ovn-remote" of the
+// local OVS database, which makes ovn-controller reconnect without restart. This allows moving chassis to
+// new or relocated central members without removing them from the cluster. An error is returned if
+// ovn-controller doesn't reconnect within chassisReconnectWait.
+func ReconfigureChassisRemote(s *state.State) error {
+	snapshot, err := loadServicesSnapshot(s)
+	if err != nil {
+		return err
+	}
+
+	if !snapshot.LocalServiceActive("switch") {
+		return api.StatusErrorf(http.StatusBadRequest, "member '%s' does not run OVN chassis", s.Name())
+	}
+
+	sbConnect := snapshot.ConnectString(networkProtocol(s), 6642)
+
+	if sbConnect == "" {
+		return api.StatusErrorf(http.StatusBadRequest, "no cluster member runs the 'central' service")
+	}
+
+	muHook.Lock()
+	defer muHook.Unlock()
+
+	current, err := getChassisExternalID(s, "ovn-remote")
+	if err != nil {
+		return fmt.Errorf("failed to read current 'ovn-remote': %w", err)
+	}
+
+	if current != sbConnect {
+		logger.Infof("Changing OVN chassis remote from '%s' to '%s'", current, sbConnect)
+		err = setChassisExternalID(s, "ovn-remote", sbConnect)
+		if err != nil {
+			return fmt.Errorf("failed to update OVS's 'ovn-remote' configuration: %w", err)
+		}
+	}
+
+	return waitForControllerConnection(s)
+}
+
+// ReconfigureChassisRemoteAll runs ReconfigureChassisRemote on every cluster member that runs OVN chassis,
+// including this one. It is used after the set of "central" members changes. Failure on one member does not
+// prevent reconfiguration of the others, all failures are reported in the returned error.
+func ReconfigureChassisRemoteAll(s *state.State) error {
+	snapshot, err := loadServicesSnapshot(s)
+	if err != nil {
+		return err
+	}
+
+	leader, err := s.Leader()
+	if err != nil {
+		return fmt.Errorf("failed to get client for cluster leader: %w", err)
+	}
+
+	failed := []string{}
+	for _, member := range snapshot.ServiceMembers("switch") {
+		if member == s.Name() {
+			err = ReconfigureChassisRemote(s)
+		} else {
+			err = microovnClient.ReconfigureChassisRemote(s.Context, leader.UseTarget(member))
+		}
+
+		if err != nil {
+			logger.Errorf("Failed to reconfigure OVN chassis remote on cluster member %q: %s", member, err)
+			failed = append(failed, fmt.Sprintf("%s: %s", member, err))
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to reconfigure OVN chassis remote on some members: %s", strings.Join(failed, "; "))
+	}
+
+	return nil
+}
+
+// waitForControllerConnection waits until local ovn-controller reports that it is connected to OVN SB.
+func waitForControllerConnection(s *state.State) error {
+	deadline := time.Now().Add(chassisReconnectWait)
+	for {
+		output, err := ControllerCtl(s, "connection-status")
+		if err == nil && strings.TrimSpace(output) == "connected" {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("ovn-controller did not connect to OVN SB database within %s", chassisReconnectWait)
+		}
+
+		select {
+		case <-s.Context.Done():
+			return s.Context.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
@@ -0,0 +1,290 @@
+package ovn
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/api"
+	"github.com/lxc/lxd/shared/logger"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/database"
+	"github.com/canonical/microovn/microovn/ovn/paths"
+)
+
+// clientCertRecordPrefix is a prefix of keys under which client certificates issued by IssueClientCert are
+// recorded in the shared database. Key is completed by the serial number of the certificate.
+const clientCertRecordPrefix = "client-cert."
+
+// recordClientCert records CN, serial number and expiry of PEM encoded client certificate "certPEM" in the
+// shared database.
+func recordClientCert(s *state.State, certPEM []byte) error {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return errors.New("failed to decode client certificate's PEM data")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse client certificate: %w", err)
+	}
+
+	record := types.ClientCertificate{
+		CN:             cert.Subject.CommonName,
+		Serial:         cert.SerialNumber.Text(16),
+		NotAfter:       cert.NotAfter,
+		AuthorityKeyID: hex.EncodeToString(cert.AuthorityKeyId),
+	}
+
+	value, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	return s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		item := database.ConfigItem{Key: clientCertRecordPrefix + record.Serial, Value: string(value)}
+		_, err := database.CreateConfigItem(ctx, tx, item)
+		if err != nil {
+			return fmt.Errorf("failed to record client certificate: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// ListClientCerts returns client certificates issued by IssueClientCert, including the revoked and expired
+// ones, sorted by CN and serial number.
+func ListClientCerts(s *state.State) ([]types.ClientCertificate, error) {
+	certs := []types.ClientCertificate{}
+	err := s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		items, err := database.GetConfigItems(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			if !strings.HasPrefix(item.Key, clientCertRecordPrefix) {
+				continue
+			}
+
+			cert := types.ClientCertificate{}
+			err = json.Unmarshal([]byte(item.Value), &cert)
+			if err != nil {
+				return fmt.Errorf("failed to parse record '%s': %w", item.Key, err)
+			}
+
+			certs = append(certs, cert)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list client certificates: %w", err)
+	}
+
+	sort.Slice(certs, func(i, j int) bool {
+		if certs[i].CN != certs[j].CN {
+			return certs[i].CN < certs[j].CN
+		}
+
+		return certs[i].Serial < certs[j].Serial
+	})
+
+	return certs, nil
+}
+
+// untrustedClientCerts returns client certificates issued by IssueClientCert that are neither revoked nor
+// expired, but were not issued by "ca". They are rejected by OVN databases once "ca" is the only trusted CA.
+// Certificates recorded without the key ID of their issuer are considered to be issued by a different CA.
+func untrustedClientCerts(s *state.State, ca *x509.Certificate) ([]types.ClientCertificate, error) {
+	certs, err := ListClientCerts(s)
+	if err != nil {
+		return nil, err
+	}
+
+	caKeyID := hex.EncodeToString(ca.SubjectKeyId)
+	now := time.Now().UTC()
+	untrusted := []types.ClientCertificate{}
+	for _, cert := range certs {
+		if cert.Revoked || cert.NotAfter.Before(now) {
+			continue
+		}
+
+		if cert.AuthorityKeyID == "" || cert.AuthorityKeyID != caKeyID {
+			untrusted = append(untrusted, cert)
+		}
+	}
+
+	return untrusted, nil
+}
+
+// RevokeClientCert marks client certificate with hexadecimal serial number "serial" as revoked and makes OVN
+// databases reject it. The databases don't consult certificate revocation lists, so the cluster CA is
+// rotated with RotateCA, which stops trust in every certificate issued by the previous CA. Remaining client
+// certificates of the previous CA are then reissued with the new CA and returned, so that they can be handed
+// over to their clients. Records of the replaced certificates are removed. Revoked certificates are still
+// published in the CRL, see WriteCRL, for TLS terminating proxies and clients that check it.
+func RevokeClientCert(s *state.State, serial string) ([]types.ReissuedClientCertificate, error) {
+	serialNumber, ok := new(big.Int).SetString(strings.TrimPrefix(strings.ToLower(strings.ReplaceAll(serial, ":", "")), "0x"), 16)
+	if !ok {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "invalid serial number '%s'", serial)
+	}
+
+	// Records are keyed by serial numbers without leading zeros, while tools like openssl zero-pad them.
+	serial = serialNumber.Text(16)
+	recordKey := clientCertRecordPrefix + serial
+	err := s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		item, err := database.GetConfigItem(ctx, tx, recordKey)
+		if err != nil {
+			if api.StatusErrorCheck(err, http.StatusNotFound) {
+				return api.StatusErrorf(http.StatusNotFound, "client certificate with serial number '%s' was not issued", serial)
+			}
+
+			return err
+		}
+
+		cert := types.ClientCertificate{}
+		err = json.Unmarshal([]byte(item.Value), &cert)
+		if err != nil {
+			return fmt.Errorf("failed to parse record '%s': %w", recordKey, err)
+		}
+
+		// Revocation is repeated to resume interrupted CA rotation.
+		if cert.Revoked {
+			return nil
+		}
+
+		now := time.Now().UTC()
+		cert.Revoked = true
+		cert.RevokedAt = &now
+
+		value, err := json.Marshal(cert)
+		if err != nil {
+			return err
+		}
+
+		return database.UpdateConfigItem(ctx, tx, recordKey, database.ConfigItem{Key: recordKey, Value: string(value)})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("Client certificate '%s' revoked, rotating CA to stop its trust", serial)
+
+	untrusted, err := RotateCA(s)
+	if err != nil {
+		return nil, fmt.Errorf("certificate was marked as revoked, but it's still trusted: %w", err)
+	}
+
+	reissued := []types.ReissuedClientCertificate{}
+	var errs []error
+	for _, previous := range untrusted {
+		certPEM, keyPEM, err := IssueClientCert(s, previous.CN)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to reissue client certificate '%s' (serial %s): %w", previous.CN, previous.Serial, err))
+			continue
+		}
+
+		reissued = append(reissued, types.ReissuedClientCertificate{
+			CN:             previous.CN,
+			PreviousSerial: previous.Serial,
+			Cert:           string(certPEM),
+			Key:            string(keyPEM),
+		})
+
+		err = deleteClientCertRecord(s, previous.Serial)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return reissued, errors.Join(errs...)
+}
+
+// deleteClientCertRecord removes record of client certificate with hexadecimal serial number "serial".
+func deleteClientCertRecord(s *state.State, serial string) error {
+	err := s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		return database.DeleteConfigItem(ctx, tx, clientCertRecordPrefix+serial)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove record of client certificate '%s': %w", serial, err)
+	}
+
+	return nil
+}
+
+// WriteCRL writes certificate revocation list, signed by the cluster CA, with revoked client certificates that
+// did not expire yet, to paths.PkiCrlFile. The file is removed if no such certificate exists.
+func WriteCRL(s *state.State) error {
+	certs, err := ListClientCerts(s)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	revoked := []pkix.RevokedCertificate{}
+	for _, cert := range certs {
+		if !cert.Revoked || cert.NotAfter.Before(now) {
+			continue
+		}
+
+		serial, ok := new(big.Int).SetString(cert.Serial, 16)
+		if !ok {
+			return fmt.Errorf("invalid serial number '%s' of client certificate", cert.Serial)
+		}
+
+		revocationTime := now
+		if cert.RevokedAt != nil {
+			revocationTime = *cert.RevokedAt
+		}
+
+		revoked = append(revoked, pkix.RevokedCertificate{SerialNumber: serial, RevocationTime: revocationTime})
+	}
+
+	crlPath := paths.PkiCrlFile()
+	if len(revoked) == 0 {
+		err = os.Remove(crlPath)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove CRL file %s: %w", crlPath, err)
+		}
+
+		return nil
+	}
+
+	caCert, caKey, err := getCA(s)
+	if err != nil {
+		return err
+	}
+
+	crl, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		RevokedCertificates: revoked,
+		Number:              big.NewInt(now.Unix()),
+		ThisUpdate:          now,
+		NextUpdate:          now.Add(ServiceCertValidity),
+	}, caCert, caKey)
+	if err != nil {
+		return fmt.Errorf("failed to create CRL: %w", err)
+	}
+
+	err = os.WriteFile(crlPath, pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: crl}), certFileMode)
+	if err != nil {
+		return fmt.Errorf("failed to write CRL into file %s: %w", crlPath, err)
+	}
+
+	return nil
+}
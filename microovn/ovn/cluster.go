@@ -0,0 +1,164 @@
+package ovn
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/canonical/microcluster/state"
+
+	"github.com/canonical/microovn/microovn/ovn/paths"
+)
+
+// ovsdbClusterServer is a representation of a single server listed in the "Servers" section of the
+// "cluster/status" output.
+type ovsdbClusterServer struct {
+	ID          string        // Short server ID
+	Address     string        // Raft address of the server
+	Self        bool          // True if this is the local server
+	LastContact time.Duration // Time since the last message from the server, -1 if not reported
+}
+
+// ovsdbClusterStatus is a parsed representation of the "cluster/status" command output for clustered
+// ovsdb databases.
+type ovsdbClusterStatus struct {
+	Name      string // Database name
+	ClusterID string // Short cluster ID
+	ServerID  string // Short ID of the local server
+	Address   string // Raft address of the local server
+	Status    string // Membership status of the local server (e.g. "cluster member")
+	Role      string // Raft role of the local server (leader, follower or candidate)
+	Term      string // Current raft term
+	Leader    string // ID of the current leader, "self" if local server is the leader or "unknown"
+	Servers   []ovsdbClusterServer
+}
+
+// HasLeader returns true if the local server knows about current cluster leader.
+func (c *ovsdbClusterStatus) HasLeader() bool {
+	return c.Leader != "" && c.Leader != "unknown"
+}
+
+// IsLeader returns true if the local server is the cluster leader.
+func (c *ovsdbClusterStatus) IsLeader() bool {
+	return c.Role == "leader"
+}
+
+// ovsdbControlSock returns path to the control socket of the clustered OVN database identified by "dbType".
+func ovsdbControlSock(dbType OvsdbType) (string, error) {
+	switch dbType {
+	case OvsdbTypeNBLocal:
+		return paths.OvnNBControlSock(), nil
+	case OvsdbTypeSBLocal:
+		return paths.OvnSBControlSock(), nil
+	default:
+		return "", errors.New("unknown DB type. Cluster status is available only for NB or SB database")
+	}
+}
+
+// getClusterStatus executes "cluster/status" command against local clustered OVN database identified by
+// "dbType" and returns its parsed output.
+func getClusterStatus(s *state.State, dbType OvsdbType) (*ovsdbClusterStatus, error) {
+	dbSpec, err := newOvsdbSpec(dbType)
+	if err != nil {
+		return nil, err
+	}
+
+	ctlSock, err := ovsdbControlSock(dbType)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := AppCtl(s, ctlSock, "cluster/status", dbSpec.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster status of %s: %w", dbSpec.Name, err)
+	}
+
+	return parseClusterStatus(output), nil
+}
+
+// parseClusterStatus parses output of the "cluster/status" command.
+func parseClusterStatus(output string) *ovsdbClusterStatus {
+	status := ovsdbClusterStatus{}
+	inServers := false
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if inServers {
+			if !strings.HasPrefix(line, " ") {
+				inServers = false
+				continue
+			}
+
+			server, ok := parseClusterServer(line)
+			if ok {
+				status.Servers = append(status.Servers, server)
+			}
+
+			continue
+		}
+
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+
+		value = strings.TrimSpace(value)
+		switch key {
+		case "Name":
+			status.Name = value
+		case "Cluster ID":
+			status.ClusterID, _, _ = strings.Cut(value, " ")
+		case "Server ID":
+			status.ServerID, _, _ = strings.Cut(value, " ")
+		case "Address":
+			status.Address = value
+		case "Status":
+			status.Status = value
+		case "Role":
+			status.Role = value
+		case "Term":
+			status.Term = value
+		case "Leader":
+			status.Leader = value
+		case "Servers":
+			inServers = true
+		}
+	}
+
+	return &status
+}
+
+// parseClusterServer parses single line from the "Servers" section of the "cluster/status" output. Expected
+// format of the line is "    <id> (<id> at <address>) [(self)] ... [last msg <n> ms ago]".
+func parseClusterServer(line string) (ovsdbClusterServer, bool) {
+	server := ovsdbClusterServer{}
+
+	fields := strings.Fields(line)
+	if len(fields) < 4 || fields[2] != "at" {
+		return server, false
+	}
+
+	server.ID = fields[0]
+	server.Address = strings.TrimSuffix(fields[3], ")")
+	server.Self = strings.Contains(line, "(self)")
+	server.LastContact = -1
+
+	// Leader reports time since the last message from each follower as "last msg <n> ms ago".
+	for i := 0; i+3 < len(fields); i++ {
+		if fields[i] != "last" || fields[i+1] != "msg" || fields[i+3] != "ms" {
+			continue
+		}
+
+		ms, err := strconv.Atoi(fields[i+2])
+		if err == nil {
+			server.LastContact = time.Duration(ms) * time.Millisecond
+		}
+	}
+
+	return server, true
+}
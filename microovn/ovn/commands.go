@@ -1,21 +1,40 @@
 package ovn
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"math"
+	"math/rand"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/canonical/microcluster/state"
 	"github.com/lxc/lxd/shared"
+	"github.com/lxc/lxd/shared/logger"
 
 	"github.com/canonical/microovn/microovn/ovn/paths"
 )
 
 const defaultDBConnectWait = 30 //Default time to wait for connection to ovsdb
+const dbStatePollTimeout = 1    // Time (in seconds) that single database state check is allowed to take
+const dbStatePollInterval = 500 * time.Millisecond
+const dbStatePollMaxInterval = 5 * time.Second
+const defaultAppCtlTimeout = 10         // Default time (s) that a target has to respond to ovn-appctl command
+const appCtlKillGrace = 5 * time.Second // Time after the ovn-appctl timeout when the process is killed
 const OvsdbConnected = "connected"
 const OvsdbRemoved = "removed"
 
+// States of the local clustered database reported by DBState.
+const (
+	DBStateJoined       = "joined"
+	DBStateJoining      = "joining"
+	DBStateRemoved      = "removed"
+	DBStateDisconnected = "disconnected"
+)
+
 // ovsdbSpec is a helper structure for precise identification of ovsdb databases. A lot of
 // ovn/ovs commands take path to either database file, database socket or process control socket
 // along with the database name. This structure can be used for such cases.
@@ -66,21 +85,119 @@ func newOvsdbSpec(dbType OvsdbType) (*ovsdbSpec, error) {
 // specified state. If database does not reach this state within timeout, this function returns error.
 // Target specified in "db" parameter does not need to necessarily exist before this function is executed,
 // creation of the database socket (db.Target) will be awaited as well.
+//
+// Database state is checked repeatedly with short timeouts. Interval between the checks grows
+// exponentially (up to dbStatePollMaxInterval) and is randomized, so that multiple members waiting
+// at the same time, e.g. when leaving the cluster simultaneously, do not query databases in lockstep.
 func waitForDBState(s *state.State, db *ovsdbSpec, dbState string, timeout int) error {
+	var err error
+	deadline := time.Now().Add(time.Duration(timeout) * time.Second)
+	interval := dbStatePollInterval
+
+	for {
+		err = checkDBState(s, db, dbState)
+		if err == nil {
+			return nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			break
+		}
+
+		sleep := jitter(interval)
+		if sleep > remaining {
+			sleep = remaining
+		}
+
+		select {
+		case <-s.Context.Done():
+			err = s.Context.Err()
+			return fmt.Errorf("database in '%s' (%s) failed to reach state '%s': %w", db.Name, db.Target, dbState, err)
+		case <-time.After(sleep):
+		}
+
+		interval *= 2
+		if interval > dbStatePollMaxInterval {
+			interval = dbStatePollMaxInterval
+		}
+	}
+
+	return fmt.Errorf("database in '%s' (%s) failed to reach state '%s': %w", db.Name, db.Target, dbState, err)
+}
+
+// checkDBState performs single, short, check whether ovsdb database specified by "db" is in state "dbState".
+// Nil is returned if it is.
+func checkDBState(s *state.State, db *ovsdbSpec, dbState string) error {
 	_, err := shared.RunCommandContext(
 		s.Context,
 		"ovsdb-client",
 		"--timeout",
-		strconv.Itoa(timeout),
+		strconv.Itoa(dbStatePollTimeout),
 		"wait",
 		fmt.Sprintf("unix:%s", db.Target),
 		db.Name,
 		dbState,
 	)
+
+	return err
+}
+
+// DBState returns current state of the local clustered OVN database "dbType" in its raft cluster lifecycle.
+// Returned value is one of:
+//   - DBStateJoined: database is a connected member of its cluster
+//   - DBStateJoining: server is in the process of joining the cluster
+//   - DBStateRemoved: server left the cluster, or the database is not running at all
+//   - DBStateDisconnected: server is a member of the cluster, but it's not connected to it
+//
+// State is detected with the same checks that are used by waitForDBState, without waiting for any
+// particular state.
+func DBState(s *state.State, dbType OvsdbType) (string, error) {
+	if dbType != OvsdbTypeNBLocal && dbType != OvsdbTypeSBLocal {
+		return "", errors.New("unknown DB type. Raft state is available only for NB or SB database")
+	}
+
+	db, err := newOvsdbSpec(dbType)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = os.Stat(db.Target)
 	if err != nil {
-		return fmt.Errorf("database in '%s' (%s) failed to reach state '%s': %w", db.Name, db.Target, dbState, err)
+		return DBStateRemoved, nil
+	}
+
+	if checkDBState(s, db, OvsdbConnected) == nil {
+		return DBStateJoined, nil
+	}
+
+	if checkDBState(s, db, OvsdbRemoved) == nil {
+		return DBStateRemoved, nil
+	}
+
+	status, err := getClusterStatus(s, dbType)
+	if err != nil {
+		return DBStateDisconnected, nil
+	}
+
+	if strings.Contains(status.Status, "joining") {
+		return DBStateJoining, nil
 	}
-	return nil
+
+	if strings.Contains(status.Status, "left") {
+		return DBStateRemoved, nil
+	}
+
+	return DBStateDisconnected, nil
+}
+
+// jitter returns random duration from interval <d/2, 3d/2).
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+
+	return d/2 + time.Duration(rand.Int63n(int64(d)))
 }
 
 // ovnDBCtl is a helper function to execute "ovn-nbctl" and "ovn-sbctl" commands. It takes "dbType" parameter
@@ -128,6 +245,22 @@ func SBCtl(s *state.State, args ...string) (string, error) {
 	return ovnDBCtl(s, OvsdbTypeSBLocal, defaultDBConnectWait, args...)
 }
 
+// localNBCtl is a convenience function for execution of ovn-nbctl command against the NB database socket
+// of the local central service. Read requests are served by the local database server even if it is not
+// the cluster leader, write requests are forwarded by the server to the leader.
+func localNBCtl(s *state.State, args ...string) (string, error) {
+	arguments := []string{"--no-leader-only", fmt.Sprintf("--db=unix:%s", paths.OvnNBDatabaseSock())}
+	return NBCtl(s, append(arguments, args...)...)
+}
+
+// localSBCtl is a convenience function for execution of ovn-sbctl command against the SB database socket
+// of the local central service. Read requests are served by the local database server even if it is not
+// the cluster leader, write requests are forwarded by the server to the leader.
+func localSBCtl(s *state.State, args ...string) (string, error) {
+	arguments := []string{"--no-leader-only", fmt.Sprintf("--db=unix:%s", paths.OvnSBDatabaseSock())}
+	return SBCtl(s, append(arguments, args...)...)
+}
+
 // VSCtl is a convenience function for execution of ovs-vsctl command. Parameter "args" is list of arguments
 // that are passed directly to the shell command. Before the command is executed, this
 // function ensures that underlying database is in connected state. If the database does not reach "connected"
@@ -149,15 +282,42 @@ func VSCtl(s *state.State, args ...string) (string, error) {
 
 // AppCtl is a convenience function that wraps execution of 'ovn-appctl' command. It requires argument
 // 'target' which will be substituted to the '-t' argument of 'ovn-appctl'. Rest of the 'args' will be passed
-// to the ovn-appctl unchanged.
+// to the ovn-appctl unchanged. Command fails if the target does not respond within the time configured in
+// ConfigKeyAppCtlTimeout (defaultAppCtlTimeout by default), see AppCtlWithTimeout.
 func AppCtl(s *state.State, target string, args ...string) (string, error) {
-	arguments := []string{"-t", target}
+	timeout, err := getConfigInt(s, ConfigKeyAppCtlTimeout, defaultAppCtlTimeout)
+	if err != nil {
+		logger.Debugf("Using default ovn-appctl timeout: %s", err)
+		timeout = defaultAppCtlTimeout
+	}
+
+	return AppCtlWithTimeout(s, target, time.Duration(timeout)*time.Second, args...)
+}
+
+// AppCtlWithTimeout executes 'ovn-appctl' command in the same way as AppCtl, but the target has to respond
+// within "timeout". Timeout is enforced by ovn-appctl itself, so that it reports which target didn't
+// respond, and the process is killed if it keeps running for appCtlKillGrace longer.
+func AppCtlWithTimeout(s *state.State, target string, timeout time.Duration, args ...string) (string, error) {
+	seconds := int(math.Ceil(timeout.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	ctx, cancel := context.WithTimeout(s.Context, time.Duration(seconds)*time.Second+appCtlKillGrace)
+	defer cancel()
+
+	arguments := []string{"-t", target, fmt.Sprintf("--timeout=%d", seconds)}
 	arguments = append(arguments, args...)
-	return shared.RunCommandContext(
-		s.Context,
+	output, err := shared.RunCommandContext(
+		ctx,
 		"ovn-appctl",
 		arguments...,
 	)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return output, fmt.Errorf("%s did not respond within %d s: %w", target, seconds, err)
+	}
+
+	return output, err
 }
 
 // ControllerCtl is a wrapper function that executes 'ovs-appctl' command specifically
@@ -179,6 +339,24 @@ func ControllerCtl(s *state.State, args ...string) (string, error) {
 	return stdout, err
 }
 
+// SwitchCtl is a wrapper function that executes 'ovs-appctl' command targeted at OVS daemon "target"
+// (e.g. "ovs-vswitchd" or "ovsdb-server") running as part of the "switch" service. Any arguments supplied
+// in 'args' will be passed to the 'ovs-appctl' unchanged.
+func SwitchCtl(s *state.State, target string, args ...string) (string, error) {
+	arguments := []string{"-t", target}
+	arguments = append(arguments, args...)
+
+	stdout, _, err := shared.RunCommandSplit(
+		s.Context,
+		append(os.Environ(), fmt.Sprintf("OVS_RUNDIR=%s", paths.SwitchRuntimeDir())),
+		nil,
+		"ovs-appctl",
+		arguments...,
+	)
+
+	return stdout, err
+}
+
 // GetOvsdbLocalPath returns path to the database file or local unix socket based on the supplied "dbType"
 func GetOvsdbLocalPath(dbType OvsdbType) (string, error) {
 	spec, err := newOvsdbSpec(dbType)
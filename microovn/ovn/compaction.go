@@ -0,0 +1,143 @@
+package ovn
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/logger"
+
+	"github.com/canonical/microovn/microovn/ovn/paths"
+)
+
+const compactionCheckInterval = time.Minute // How often is the size of OVN SB database checked
+const defaultSBCompactMinInterval = 10 * 60 // Default value (s) for ConfigKeySBCompactMinInterval
+const bytesInMiB = 1024 * 1024
+
+// compactionMonitorOnce ensures that only one compaction monitor is running.
+var compactionMonitorOnce sync.Once
+
+// lastSBCompaction holds time of the last size-triggered compaction of OVN SB database.
+var lastSBCompaction struct {
+	sync.Mutex
+	time time.Time
+}
+
+// databaseFile returns path to the file where local clustered OVN database identified by "dbType" stores
+// its data.
+func databaseFile(dbType OvsdbType) (string, error) {
+	switch dbType {
+	case OvsdbTypeNBLocal:
+		return paths.OvnNBDatabaseFile(), nil
+	case OvsdbTypeSBLocal:
+		return paths.OvnSBDatabaseFile(), nil
+	default:
+		return "", fmt.Errorf("unknown DB type. Database size is available only for NB or SB database")
+	}
+}
+
+// databaseSize returns on-disk size, in bytes, of local clustered OVN database identified by "dbType".
+func databaseSize(dbType OvsdbType) (int64, error) {
+	dbFile, err := databaseFile(dbType)
+	if err != nil {
+		return 0, err
+	}
+
+	info, err := os.Stat(dbFile)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get size of database file '%s': %w", dbFile, err)
+	}
+
+	return info.Size(), nil
+}
+
+// startCompactionMonitor starts background routine that triggers compaction of OVN SB database when its
+// on-disk size exceeds ConfigKeySBCompactSize, and compaction of OVN NB or SB database whose server uses more
+// memory than ConfigKeyDBMemoryLimit. The routine runs for the lifetime of the daemon, only one
+// instance is started regardless of how many times this function is called.
+func startCompactionMonitor(s *state.State) {
+	compactionMonitorOnce.Do(func() {
+		go func() {
+			ticker := time.NewTicker(compactionCheckInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-s.Context.Done():
+					return
+				case <-ticker.C:
+				}
+
+				err := compactSBIfOversized(s)
+				if err != nil {
+					logger.Warnf("Size-triggered compaction of OVN SB database failed: %s", err)
+				}
+
+				err = compactIfOverMemoryLimit(s)
+				if err != nil {
+					logger.Warnf("Memory-triggered compaction of OVN database failed: %s", err)
+				}
+			}
+		}()
+	})
+}
+
+// compactSBIfOversized compacts local OVN SB database if size-triggered compaction is configured, local
+// server is the cluster leader, database file exceeds configured size and the last size-triggered
+// compaction happened more than ConfigKeySBCompactMinInterval ago.
+func compactSBIfOversized(s *state.State) error {
+	limit, err := getConfigInt(s, ConfigKeySBCompactSize, 0)
+	if err != nil || limit == 0 {
+		return err
+	}
+
+	if skipInMaintenance(s, "size-triggered compaction") {
+		return nil
+	}
+
+	centralActive, err := localServiceActive(s, "central")
+	if err != nil || !centralActive {
+		return err
+	}
+
+	minInterval, err := getConfigInt(s, ConfigKeySBCompactMinInterval, defaultSBCompactMinInterval)
+	if err != nil {
+		return err
+	}
+
+	lastSBCompaction.Lock()
+	defer lastSBCompaction.Unlock()
+
+	if time.Since(lastSBCompaction.time) < time.Duration(minInterval)*time.Second {
+		return nil
+	}
+
+	size, err := databaseSize(OvsdbTypeSBLocal)
+	if err != nil {
+		return err
+	}
+
+	if size < int64(limit)*bytesInMiB {
+		return nil
+	}
+
+	status, err := getClusterStatus(s, OvsdbTypeSBLocal)
+	if err != nil {
+		return err
+	}
+
+	if !status.IsLeader() {
+		return nil
+	}
+
+	logger.Infof("OVN SB database size (%d bytes) exceeds %d MiB, triggering compaction.", size, limit)
+	err = compactDatabase(s, OvsdbTypeSBLocal)
+	if err != nil {
+		return err
+	}
+
+	lastSBCompaction.time = time.Now()
+	return nil
+}
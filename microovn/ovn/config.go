@@ -0,0 +1,750 @@
+package ovn
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/api"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/database"
+)
+
+// Keys of user-configurable options stored in the shared MicroOVN database.
+const (
+	ConfigKeyMaintenanceMode = "maintenance-mode" // Suppress automatic reconfiguration of OVN services
+
+	ConfigKeyEncapTos = "ovn.encap-tos" // ToS/DSCP value applied to OVN tunnel traffic
+	ConfigKeyEncapIP  = "ovn.encap-ip"  // IP address used by the member for OVN tunnel traffic
+
+	ConfigKeyServiceAddress = "ovn.service-address" // IP address under which OVN services of the member are reachable
+
+	ConfigKeyRemoteProbeInterval = "ovn.remote-probe-interval" // Interval (ms) of chassis probes towards OVN SB
+	ConfigKeyBridge              = "ovn.bridge"                // Name of the OVS integration bridge used by OVN chassis
+	ConfigKeyDatapathType        = "ovs.datapath-type"         // Datapath type of new integration bridge ("system" or "netdev")
+	ConfigKeyMonitorAll          = "ovn.monitor-all"           // Chassis monitors whole OVN SB instead of datapaths relevant to it
+	ConfigKeyGatewayPriority     = "ovn.gateway-priority"      // Priority of the chassis as a gateway, chassis is not a gateway candidate if not set
+
+	ConfigKeySBWaitMinInterval = "ovn.sb-wait-min-interval" // Initial delay (ms) between checks of OVN SB reachability while chassis starts
+	ConfigKeySBWaitMaxInterval = "ovn.sb-wait-max-interval" // Maximum delay (ms) between checks of OVN SB reachability while chassis starts
+
+	ConfigKeyNorthdThreads = "ovn.northd-n-threads" // Number of threads used by ovn-northd for parallel build
+	ConfigKeyNorthdLocalDB = "ovn.northd-local-db"  // Co-located ovn-northd tries local unix sockets of NB and SB before the cluster remotes
+	ConfigKeyNorthdStandby = "ovn.northd-standby"   // Local ovn-northd stays in standby, unless no other instance is active
+
+	ConfigKeyNorthdExternal = "ovn.northd-external" // ovn-northd is run and coordinated by an external system, not by MicroOVN
+
+	ConfigKeyPreferredLeaders = "ovn.preferred-leaders" // Comma-separated names of members preferred as NB/SB raft leaders
+	ConfigKeyExpectedCentrals = "ovn.expected-centrals" // Number of members expected to run the "central" service
+	ConfigKeyLeavePolicy      = "ovn.leave-policy"      // Behavior of leaving member that can't reach NB/SB cluster ("proceed" or "abort")
+	ConfigKeyLeaveOrder       = "ovn.leave-order"       // Comma-separated order in which leaving member shuts down its services
+	ConfigKeyLeaveGrace       = "ovn.leave-grace"       // Time (s) that leaving member waits for its chassis to disappear from OVN SB
+
+	ConfigKeyFanOutConcurrency = "cluster.fan-out-concurrency" // Maximum number of members contacted at once by fleet-wide operations
+
+	ConfigKeySBCompactSize        = "ovn.sb-compact-size"         // Size (MiB) of OVN SB database file that triggers compaction
+	ConfigKeySBCompactMinInterval = "ovn.sb-compact-min-interval" // Minimum time (s) between size-triggered compactions
+	ConfigKeyDBMemoryLimit        = "ovn.db-memory-limit"         // Memory usage (MiB) of OVN database servers that triggers compaction
+	ConfigKeyDBWatchdogFailures   = "ovn.db-watchdog-failures"    // Consecutive failed pings of OVN database server that trigger its restart
+	ConfigKeyAppCtlTimeout        = "ovn.appctl-timeout"          // Time (s) that OVN daemon has to respond to a unixctl command
+
+	ConfigKeyFDBRemovalLimit        = "ovn.fdb-removal-limit"         // Maximum number of aged out FDB entries removed in one transaction
+	ConfigKeyMACBindingRemovalLimit = "ovn.mac-binding-removal-limit" // Maximum number of aged out MAC bindings removed in one transaction
+
+	ConfigKeyBackupPath = "backup.path" // Directory where backups of MicroOVN data are created
+
+	ConfigKeyControllerArgs = "ovn.controller-extra-args" // Additional command line arguments for ovn-controller
+	ConfigKeyEnvBracketIPv6 = "ovn.env-bracket-ipv6"      // IPv6 addresses in ovn.env are wrapped in square brackets (default "true")
+
+	ConfigKeyLogTarget   = "ovn.log-target"   // Where OVN daemons log ("file" or "syslog")
+	ConfigKeyLogFacility = "ovn.log-facility" // Syslog facility of OVN daemon logs, used with "syslog" log target
+
+	ConfigKeyNBInactivityProbe = "ovn.nb.inactivity-probe" // Inactivity probe interval (ms) of OVN NB client connections
+	ConfigKeySBInactivityProbe = "ovn.sb.inactivity-probe" // Inactivity probe interval (ms) of OVN SB client connections, i.e. server-side probe towards chassis
+	ConfigKeyNBMaxBackoff      = "ovn.nb.max-backoff"      // Maximum backoff (ms) between attempts to reopen OVN NB listening connections
+	ConfigKeySBMaxBackoff      = "ovn.sb.max-backoff"      // Maximum backoff (ms) between attempts to reopen OVN SB listening connections
+
+	ConfigKeySamplingProtocol = "ovs.sampling-protocol" // Traffic sampling export protocol on integration bridge ("sflow" or "ipfix")
+	ConfigKeySamplingTarget   = "ovs.sampling-target"   // Address (host:port) of the collector receiving sampled traffic
+	ConfigKeySamplingRate     = "ovs.sampling-rate"     // Sample one of this many packets
+
+	ConfigKeyDHCPv4Options = "ovn.dhcpv4-options" // Default DHCPv4 options applied to new logical switches
+	ConfigKeyDHCPv6Options = "ovn.dhcpv6-options" // Default DHCPv6 options applied to new logical switches
+
+	ConfigKeyTLSExtraSANs = "tls.extra-sans" // Comma-separated names and addresses added to SAN of service certificates
+
+	ConfigKeyStatsdAddress  = "metrics.statsd-address"  // Address (host:port) of statsd server that receives metrics
+	ConfigKeyStatsdInterval = "metrics.statsd-interval" // Interval (s) between metrics pushed to statsd server
+
+	ConfigKeyStatsInterval = "ovn.stats-interval" // Interval (s) between collections of OVN daemon coverage and memory statistics
+
+	ConfigKeyHealthAddress = "health.address" // Address (host:port) on which HTTP health checks of OVN databases are served
+)
+
+// configApply is a set of actions that need to be performed on a cluster member for a change of
+// configuration option to take effect.
+type configApply int
+
+const configApplyNone configApply = 0 // Option is read only when needed, no action is required
+
+const (
+	configApplyEnvironment    configApply = 1 << iota // Regenerate ovn.env
+	configApplyChassis                                // Reapply OVN chassis settings in local OVS database
+	configApplyNorthd                                 // Reconfigure running ovn-northd process
+	configApplyRestartChassis                         // Reload OVN chassis service, restarting it if needed
+	configApplySwitch                                 // Reapply OVS database settings in local OVS database
+	configApplyReconcile                              // Reconcile all local services, unless in maintenance mode
+	configApplyRestartCentral                         // Reload OVN central service, restarting it if needed
+	configApplyCertificates                           // Reissue local service certificates
+	configApplyListen                                 // Reapply listening connections of OVN NB and SB databases
+	configApplyGlobalOption                           // Set corresponding option in NB_Global table
+	configApplyServiceAddress                         // Move OVN NB and SB raft servers to the service address
+	configApplyGateway                                // Update priority of the local chassis in OVN NB gateway records
+)
+
+// configKey describes single user-configurable option that is stored in the shared MicroOVN database.
+type configKey struct {
+	validate  func(value string) error // Function that verifies that the value is acceptable for the key
+	perMember bool                     // Option is configured separately for each cluster member
+	apply     configApply              // Actions that make changed value take effect
+}
+
+// configKeys is a list of all user-configurable options recognized by MicroOVN. Keys that are not
+// listed here can't be read or written via GetConfig/SetConfig.
+var configKeys = map[string]configKey{
+	ConfigKeyMaintenanceMode: {validate: validateBool, apply: configApplyReconcile},
+
+	ConfigKeyEncapTos: {validate: validateEncapTos, apply: configApplyChassis},
+	ConfigKeyEncapIP:  {validate: validateIPAddress, perMember: true, apply: configApplyEnvironment | configApplyChassis | configApplyCertificates},
+
+	ConfigKeyServiceAddress: {validate: validateLocalIPAddress, perMember: true, apply: configApplyEnvironment | configApplyServiceAddress | configApplyListen | configApplyChassis | configApplyCertificates},
+
+	ConfigKeyRemoteProbeInterval: {validate: validateProbeInterval, apply: configApplyChassis},
+	ConfigKeyBridge:              {validate: validateBridgeName, apply: configApplyChassis},
+	ConfigKeyDatapathType:        {validate: validateDatapathType, perMember: true, apply: configApplyChassis},
+	ConfigKeyMonitorAll:          {validate: validateBool, perMember: true, apply: configApplyChassis},
+	ConfigKeyGatewayPriority:     {validate: validateNonNegativeInt, perMember: true, apply: configApplyChassis | configApplyGateway},
+
+	ConfigKeySBWaitMinInterval: {validate: validatePositiveInt, apply: configApplyNone},
+	ConfigKeySBWaitMaxInterval: {validate: validatePositiveInt, apply: configApplyNone},
+
+	ConfigKeyNorthdThreads: {validate: validateThreadCount, apply: configApplyEnvironment | configApplyNorthd},
+	ConfigKeyNorthdLocalDB: {validate: validateBool, apply: configApplyEnvironment | configApplyRestartCentral},
+	ConfigKeyNorthdStandby: {validate: validateBool, perMember: true, apply: configApplyNorthd},
+
+	ConfigKeyNorthdExternal: {validate: validateBool, apply: configApplyEnvironment | configApplyRestartCentral},
+
+	ConfigKeyPreferredLeaders: {validate: validateMemberList, apply: configApplyNone},
+	ConfigKeyExpectedCentrals: {validate: validatePositiveInt, apply: configApplyNone},
+	ConfigKeyLeavePolicy:      {validate: validateLeavePolicy, apply: configApplyNone},
+	ConfigKeyLeaveOrder:       {validate: validateLeaveOrder, apply: configApplyNone},
+	ConfigKeyLeaveGrace:       {validate: validateNonNegativeInt, apply: configApplyNone},
+
+	ConfigKeyFanOutConcurrency: {validate: validatePositiveInt, apply: configApplyNone},
+
+	ConfigKeySBCompactSize:        {validate: validatePositiveInt, apply: configApplyNone},
+	ConfigKeySBCompactMinInterval: {validate: validatePositiveInt, apply: configApplyNone},
+	ConfigKeyDBMemoryLimit:        {validate: validatePositiveInt, apply: configApplyNone},
+	ConfigKeyDBWatchdogFailures:   {validate: validatePositiveInt, apply: configApplyNone},
+	ConfigKeyAppCtlTimeout:        {validate: validatePositiveInt, apply: configApplyNone},
+
+	ConfigKeyFDBRemovalLimit:        {validate: validateNonNegativeInt, apply: configApplyGlobalOption},
+	ConfigKeyMACBindingRemovalLimit: {validate: validateNonNegativeInt, apply: configApplyGlobalOption},
+
+	ConfigKeyBackupPath: {validate: validateAbsolutePath, perMember: true, apply: configApplyNone},
+
+	ConfigKeyControllerArgs: {validate: validateControllerArgs, apply: configApplyEnvironment | configApplyRestartChassis},
+	ConfigKeyEnvBracketIPv6: {validate: validateBool, apply: configApplyEnvironment | configApplyRestartCentral | configApplyRestartChassis},
+
+	ConfigKeyLogTarget:   {validate: validateLogTarget, apply: configApplyEnvironment | configApplyRestartCentral | configApplyRestartChassis},
+	ConfigKeyLogFacility: {validate: validateSyslogFacility, apply: configApplyEnvironment | configApplyRestartCentral | configApplyRestartChassis},
+
+	ConfigKeyNBInactivityProbe: {validate: validateProbeInterval, apply: configApplyListen},
+	ConfigKeySBInactivityProbe: {validate: validateProbeInterval, apply: configApplyListen},
+	ConfigKeyNBMaxBackoff:      {validate: validateBackoff, apply: configApplyListen},
+	ConfigKeySBMaxBackoff:      {validate: validateBackoff, apply: configApplyListen},
+
+	ConfigKeySamplingProtocol: {validate: validateSamplingProtocol, perMember: true, apply: configApplySwitch},
+	ConfigKeySamplingTarget:   {validate: validateHostPort, perMember: true, apply: configApplySwitch},
+	ConfigKeySamplingRate:     {validate: validatePositiveInt, perMember: true, apply: configApplySwitch},
+
+	ConfigKeyDHCPv4Options: {validate: validateDHCPOptions, apply: configApplyNone},
+	ConfigKeyDHCPv6Options: {validate: validateDHCPOptions, apply: configApplyNone},
+
+	ConfigKeyTLSExtraSANs: {validate: validateSANList, perMember: true, apply: configApplyCertificates},
+
+	ConfigKeyStatsdAddress:  {validate: validateHostPort, apply: configApplyNone},
+	ConfigKeyStatsdInterval: {validate: validatePositiveInt, apply: configApplyNone},
+
+	ConfigKeyStatsInterval: {validate: validatePositiveInt, apply: configApplyNone},
+
+	ConfigKeyHealthAddress: {validate: validateHostPort, perMember: true, apply: configApplyNone},
+}
+
+// bridgeName is a pattern that OVS bridge names must match. Length is limited by the maximum length of
+// network interface names in Linux.
+var bridgeName = regexp.MustCompile(`^[a-zA-Z0-9_.-]{1,15}$`)
+
+// dnsName is a pattern that DNS names included in certificate SAN must match.
+var dnsName = regexp.MustCompile(`^[a-zA-Z0-9*]([a-zA-Z0-9-]*[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?)*$`)
+
+// dhcpOptionName is a pattern that names of DHCP options must match.
+var dhcpOptionName = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// controllerArgsAllowed lists ovn-controller arguments that can be set via ConfigKeyControllerArgs. Value
+// indicates whether the argument accepts value in format "--arg=value". Arguments that control
+// database connection, TLS, control socket or daemonization are managed by MicroOVN and are intentionally
+// not listed.
+var controllerArgsAllowed = map[string]bool{
+	"--enable-dummy-vif-plug": false,
+	"--verbose":               true,
+	"-v":                      true,
+	"--syslog-method":         true,
+}
+
+// configItemKey returns key under which the configuration option is stored in the database. Options
+// that are configured separately for each cluster member are stored with the member name as a suffix
+// in format "<key>@<member>". In such case, option for the local member is addressed.
+func configItemKey(s *state.State, key string) string {
+	if configKeys[key].perMember {
+		return fmt.Sprintf("%s@%s", key, s.Name())
+	}
+
+	return key
+}
+
+// GetConfig returns value of configuration option "key" from the shared MicroOVN database. Empty string is
+// returned if the option is not set. For options that are configured separately for each cluster member,
+// value for the local member is returned.
+func GetConfig(s *state.State, key string) (string, error) {
+	_, ok := configKeys[key]
+	if !ok {
+		return "", api.StatusErrorf(http.StatusNotFound, "unknown configuration key '%s'", key)
+	}
+
+	var value string
+	itemKey := configItemKey(s, key)
+	err := s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		item, err := database.GetConfigItem(ctx, tx, itemKey)
+		if err != nil {
+			if api.StatusErrorCheck(err, http.StatusNotFound) {
+				return nil
+			}
+
+			return err
+		}
+
+		value = item.Value
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get configuration option '%s': %w", key, err)
+	}
+
+	return value, nil
+}
+
+// SetConfig validates and stores value of configuration option "key" in the shared MicroOVN database. Setting
+// empty value removes the option, reverting it to its default. For options that are configured separately
+// for each cluster member, value for the local member is stored.
+func SetConfig(s *state.State, key string, value string) error {
+	cfgKey, ok := configKeys[key]
+	if !ok {
+		return api.StatusErrorf(http.StatusNotFound, "unknown configuration key '%s'", key)
+	}
+
+	if value != "" && cfgKey.validate != nil {
+		err := cfgKey.validate(value)
+		if err != nil {
+			return api.StatusErrorf(http.StatusBadRequest, "invalid value for '%s': %s", key, err)
+		}
+	}
+
+	if key == ConfigKeyDBMemoryLimit && value != "" {
+		err := checkMemoryLimit(s, value)
+		if err != nil {
+			return api.StatusErrorf(http.StatusBadRequest, "invalid value for '%s': %s", key, err)
+		}
+	}
+
+	itemKey := configItemKey(s, key)
+	err := s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		exists, err := database.ConfigItemExists(ctx, tx, itemKey)
+		if err != nil {
+			return err
+		}
+
+		if value == "" {
+			if exists {
+				return database.DeleteConfigItem(ctx, tx, itemKey)
+			}
+
+			return nil
+		}
+
+		item := database.ConfigItem{Key: itemKey, Value: value}
+		if exists {
+			return database.UpdateConfigItem(ctx, tx, itemKey, item)
+		}
+
+		_, err = database.CreateConfigItem(ctx, tx, item)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store configuration option '%s': %w", key, err)
+	}
+
+	return nil
+}
+
+// ListConfig returns values of all configuration options recognized by MicroOVN, sorted by key. Options that
+// are not set have empty value. For options that are configured separately for each cluster member, values
+// for the local member are returned.
+func ListConfig(s *state.State) ([]types.ConfigValue, error) {
+	keys := make([]string, 0, len(configKeys))
+	for key := range configKeys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	values := make([]types.ConfigValue, 0, len(keys))
+	for _, key := range keys {
+		value, err := GetConfig(s, key)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, types.ConfigValue{Key: key, Value: value})
+	}
+
+	return values, nil
+}
+
+// IsMemberConfig returns true if configuration option "key" is configured separately for each cluster member.
+func IsMemberConfig(key string) bool {
+	return configKeys[key].perMember
+}
+
+// ApplyConfig performs actions required for the current value of configuration option "key" to take effect on
+// this cluster member. Actions related to services that are not running locally are skipped.
+func ApplyConfig(s *state.State, key string) error {
+	cfgKey, ok := configKeys[key]
+	if !ok {
+		return api.StatusErrorf(http.StatusNotFound, "unknown configuration key '%s'", key)
+	}
+
+	if cfgKey.apply == configApplyNone {
+		return nil
+	}
+
+	if cfgKey.apply&configApplyReconcile != 0 {
+		return reconcile(s)
+	}
+
+	muHook.Lock()
+	defer muHook.Unlock()
+
+	snapshot, err := loadServicesSnapshot(s)
+	if err != nil {
+		return err
+	}
+
+	centralActive := snapshot.LocalServiceActive("central")
+	switchActive := snapshot.LocalServiceActive("switch")
+
+	if cfgKey.apply&configApplyEnvironment != 0 {
+		err = generateEnvironment(s, snapshot)
+		if err != nil {
+			return fmt.Errorf("failed to generate the daemon configuration: %w", err)
+		}
+	}
+
+	if cfgKey.apply&configApplyNorthd != 0 && centralActive {
+		err = applyNorthdThreads(s)
+		if err != nil {
+			return err
+		}
+
+		err = applyNorthdStandby(s)
+		if err != nil {
+			return err
+		}
+	}
+
+	if cfgKey.apply&configApplyRestartCentral != 0 && centralActive {
+		_, err = ReloadService(s, "central")
+		if err != nil {
+			return err
+		}
+	}
+
+	if cfgKey.apply&configApplyServiceAddress != 0 && centralActive {
+		address, err := serviceAddress(s)
+		if err != nil {
+			return err
+		}
+
+		addr, err := netip.ParseAddr(address)
+		if err != nil {
+			return fmt.Errorf("failed to parse service address: %w", err)
+		}
+
+		err = applyServiceAddress(s, addr)
+		if err != nil {
+			return fmt.Errorf("failed to move OVN databases to the service address: %w", err)
+		}
+	}
+
+	if cfgKey.apply&configApplyListen != 0 && centralActive {
+		err = updateOvnListenConfig(s)
+		if err != nil {
+			return fmt.Errorf("failed to apply OVN database listening configuration: %w", err)
+		}
+	}
+
+	if cfgKey.apply&configApplyGlobalOption != 0 && centralActive {
+		err = ensureNBLeaderReachable(s)
+		if err != nil {
+			return err
+		}
+
+		value, err := GetConfig(s, key)
+		if err != nil {
+			return err
+		}
+
+		err = applyGlobalOption(s, globalOptionConfigKeys[key], value)
+		if err != nil {
+			return err
+		}
+	}
+
+	if cfgKey.apply&configApplyCertificates != 0 && networkProtocol(s) == "ssl" {
+		for _, service := range localCertificateServices(centralActive, switchActive) {
+			err = GenerateNewServiceCertificate(s, service, CertificateTypeServer)
+			if err != nil {
+				return fmt.Errorf("failed to reissue %s certificate: %w", service, err)
+			}
+		}
+	}
+
+	if cfgKey.apply&configApplyChassis != 0 && switchActive {
+		err = updateChassisConfig(s)
+		if err != nil {
+			return fmt.Errorf("failed to apply OVN chassis configuration: %w", err)
+		}
+	}
+
+	if cfgKey.apply&configApplyGateway != 0 && switchActive {
+		err = applyGatewayPriority(s)
+		if err != nil {
+			return fmt.Errorf("failed to apply gateway priority: %w", err)
+		}
+	}
+
+	if cfgKey.apply&configApplySwitch != 0 && switchActive {
+		err = updateSwitchConfig(s)
+		if err != nil {
+			return fmt.Errorf("failed to apply OVS database configuration: %w", err)
+		}
+	}
+
+	if cfgKey.apply&configApplyRestartChassis != 0 && switchActive {
+		_, err = ReloadService(s, "chassis")
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateBool verifies that the value is either "true" or "false".
+func validateBool(value string) error {
+	if value != "true" && value != "false" {
+		return fmt.Errorf("expected 'true' or 'false', got '%s'", value)
+	}
+
+	return nil
+}
+
+// validateEncapTos verifies that the value is acceptable for OVS tunnel "tos" option. Valid values
+// are integers in range 0-255 or string "inherit".
+func validateEncapTos(value string) error {
+	if value == "inherit" {
+		return nil
+	}
+
+	tos, err := strconv.Atoi(value)
+	if err != nil || tos < 0 || tos > 255 {
+		return fmt.Errorf("expected integer in range 0-255 or 'inherit', got '%s'", value)
+	}
+
+	return nil
+}
+
+// validateIPAddress verifies that the value is a valid IPv4 or IPv6 address.
+func validateIPAddress(value string) error {
+	_, err := netip.ParseAddr(value)
+	if err != nil {
+		return fmt.Errorf("expected IP address, got '%s'", value)
+	}
+
+	return nil
+}
+
+// validateHostPort verifies that the value is a network address in the "host:port" form.
+func validateHostPort(value string) error {
+	host, port, err := net.SplitHostPort(value)
+	if err != nil || host == "" {
+		return fmt.Errorf("expected address in the form 'host:port', got '%s'", value)
+	}
+
+	number, err := strconv.Atoi(port)
+	if err != nil || number <= 0 || number > 65535 {
+		return fmt.Errorf("expected port in range 1-65535, got '%s'", port)
+	}
+
+	return nil
+}
+
+// validateSANList verifies that the value is a comma-separated list of IP addresses or DNS names.
+func validateSANList(value string) error {
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		_, err := netip.ParseAddr(name)
+		if err != nil && !dnsName.MatchString(name) {
+			return fmt.Errorf("expected comma-separated list of IP addresses or DNS names, got '%s'", value)
+		}
+	}
+
+	return nil
+}
+
+// validateLeavePolicy verifies that the value is one of the supported leave policies.
+func validateLeavePolicy(value string) error {
+	if value != LeavePolicyProceed && value != LeavePolicyAbort {
+		return fmt.Errorf("expected '%s' or '%s', got '%s'", LeavePolicyProceed, LeavePolicyAbort, value)
+	}
+
+	return nil
+}
+
+// validateLeaveOrder verifies that the value is a comma-separated list that contains each of the services
+// shut down by a leaving member exactly once.
+func validateLeaveOrder(value string) error {
+	required := strings.Split(defaultLeaveOrder, ",")
+	known := map[string]bool{}
+	for _, service := range required {
+		known[service] = true
+	}
+
+	seen := map[string]bool{}
+	for _, service := range strings.Split(value, ",") {
+		service = strings.TrimSpace(service)
+		if !known[service] {
+			return fmt.Errorf("unknown service '%s', expected order of services %s", service, strings.Join(required, ", "))
+		}
+
+		if seen[service] {
+			return fmt.Errorf("service '%s' is listed more than once", service)
+		}
+
+		seen[service] = true
+	}
+
+	if len(seen) != len(required) {
+		return fmt.Errorf("expected order of all services %s, got '%s'", strings.Join(required, ", "), value)
+	}
+
+	return nil
+}
+
+// validateMemberList verifies that the value is a comma-separated list of non-empty member names.
+func validateMemberList(value string) error {
+	for _, member := range strings.Split(value, ",") {
+		if strings.TrimSpace(member) == "" {
+			return fmt.Errorf("expected comma-separated list of member names, got '%s'", value)
+		}
+	}
+
+	return nil
+}
+
+// validatePositiveInt verifies that the value is an integer greater than zero.
+func validatePositiveInt(value string) error {
+	number, err := strconv.Atoi(value)
+	if err != nil || number <= 0 {
+		return fmt.Errorf("expected positive integer, got '%s'", value)
+	}
+
+	return nil
+}
+
+// validateNonNegativeInt verifies that the value is an integer greater than or equal to zero.
+func validateNonNegativeInt(value string) error {
+	number, err := strconv.Atoi(value)
+	if err != nil || number < 0 {
+		return fmt.Errorf("expected non-negative integer, got '%s'", value)
+	}
+
+	return nil
+}
+
+// validateDatapathType verifies that the value is one of the supported datapath types of the integration bridge.
+func validateDatapathType(value string) error {
+	if value != DatapathTypeSystem && value != DatapathTypeNetdev {
+		return fmt.Errorf("expected '%s' or '%s', got '%s'", DatapathTypeSystem, DatapathTypeNetdev, value)
+	}
+
+	return nil
+}
+
+// validateLogTarget verifies that the value is one of the supported logging targets of OVN daemons.
+func validateLogTarget(value string) error {
+	if value != LogTargetFile && value != LogTargetSyslog {
+		return fmt.Errorf("expected '%s' or '%s', got '%s'", LogTargetFile, LogTargetSyslog, value)
+	}
+
+	return nil
+}
+
+// validateSyslogFacility verifies that the value is a name of syslog facility accepted by OVN daemons.
+func validateSyslogFacility(value string) error {
+	if !syslogFacilities[value] {
+		return fmt.Errorf("unknown syslog facility '%s'", value)
+	}
+
+	return nil
+}
+
+// validateSamplingProtocol verifies that the value is one of the traffic sampling protocols supported by OVS.
+func validateSamplingProtocol(value string) error {
+	if value != SamplingProtocolSFlow && value != SamplingProtocolIPFIX {
+		return fmt.Errorf("expected '%s' or '%s', got '%s'", SamplingProtocolSFlow, SamplingProtocolIPFIX, value)
+	}
+
+	return nil
+}
+
+// validateAbsolutePath verifies that the value is an absolute filesystem path.
+func validateAbsolutePath(value string) error {
+	if !filepath.IsAbs(value) {
+		return fmt.Errorf("expected absolute path, got '%s'", value)
+	}
+
+	return nil
+}
+
+// validateProbeInterval verifies that the value is acceptable as OVSDB probe interval in milliseconds. Valid
+// values are 0, which disables the probes, or integers greater than or equal to 1000.
+func validateProbeInterval(value string) error {
+	interval, err := strconv.Atoi(value)
+	if err != nil || (interval != 0 && interval < 1000) {
+		return fmt.Errorf("expected 0 or integer greater than or equal to 1000, got '%s'", value)
+	}
+
+	return nil
+}
+
+// validateBridgeName verifies that the value can be used as a name of OVS bridge.
+func validateBridgeName(value string) error {
+	if !bridgeName.MatchString(value) {
+		return fmt.Errorf("expected up to 15 alphanumeric characters, '_', '.' or '-', got '%s'", value)
+	}
+
+	return nil
+}
+
+// validateThreadCount verifies that the value is a positive integer that does not exceed number of
+// CPUs available on this member.
+func validateThreadCount(value string) error {
+	err := validatePositiveInt(value)
+	if err != nil {
+		return err
+	}
+
+	threads, _ := strconv.Atoi(value)
+	if threads > runtime.NumCPU() {
+		return fmt.Errorf("thread count %d exceeds number of available CPUs (%d)", threads, runtime.NumCPU())
+	}
+
+	return nil
+}
+
+// validateBackoff verifies that the value is acceptable as OVSDB connection backoff in milliseconds, i.e.
+// an integer greater than or equal to 1000.
+func validateBackoff(value string) error {
+	backoff, err := strconv.Atoi(value)
+	if err != nil || backoff < 1000 {
+		return fmt.Errorf("expected integer greater than or equal to 1000, got '%s'", value)
+	}
+
+	return nil
+}
+
+// validateControllerArgs verifies that the value is a space separated list of ovn-controller arguments
+// that are listed in controllerArgsAllowed.
+func validateControllerArgs(value string) error {
+	for _, arg := range strings.Fields(value) {
+		name, _, hasValue := strings.Cut(arg, "=")
+		acceptsValue, ok := controllerArgsAllowed[name]
+		if !ok {
+			return fmt.Errorf("argument '%s' is not allowed", name)
+		}
+
+		if hasValue && !acceptsValue {
+			return fmt.Errorf("argument '%s' does not accept value", name)
+		}
+
+		if strings.ContainsAny(arg, `"'\$`+"`") {
+			return fmt.Errorf("argument '%s' contains unsupported characters", arg)
+		}
+	}
+
+	return nil
+}
+
+// validateDHCPOptions verifies that the value is a space separated list of DHCP options in format
+// "<name>=<value>", as accepted by "ovn-nbctl dhcp-options-set-options".
+func validateDHCPOptions(value string) error {
+	for _, option := range strings.Fields(value) {
+		name, optionValue, found := strings.Cut(option, "=")
+		if !found || optionValue == "" || !dhcpOptionName.MatchString(name) {
+			return fmt.Errorf("expected option in format '<name>=<value>', got '%s'", option)
+		}
+	}
+
+	return nil
+}
+
+// getConfigInt returns value of configuration option "key" converted to integer. If the option
+// is not set, "defaultValue" is returned.
+func getConfigInt(s *state.State, key string, defaultValue int) (int, error) {
+	value, err := GetConfig(s, key)
+	if err != nil {
+		return 0, err
+	}
+
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("configuration option '%s' is not an integer: %w", key, err)
+	}
+
+	return number, nil
+}
@@ -0,0 +1,107 @@
+package ovn
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/lxc/lxd/shared/logger"
+)
+
+// ConnectStrings holds OVN connection strings that clients use to reach NB and SB databases.
+type ConnectStrings struct {
+	NB string // Connection string of OVN NB database
+	SB string // Connection string of OVN SB database
+}
+
+// connectEndpointCount returns number of endpoints listed in OVN connection string "connect".
+func connectEndpointCount(connect string) int {
+	if connect == "" {
+		return 0
+	}
+
+	return len(strings.Split(connect, ","))
+}
+
+// lastConnectStrings returns connection strings rendered by the last generateEnvironment call. Returned
+// strings are empty if ovn.env was not generated yet.
+func lastConnectStrings() ConnectStrings {
+	connectSubscribers.Lock()
+	defer connectSubscribers.Unlock()
+
+	return connectSubscribers.last
+}
+
+// connectSubscribers holds callbacks registered by SubscribeConnectStrings along with the connection
+// strings rendered by the last generateEnvironment call.
+var connectSubscribers struct {
+	sync.Mutex
+	next      int
+	callbacks map[int]func(ConnectStrings)
+	last      ConnectStrings
+}
+
+// SubscribeConnectStrings registers "callback" that is executed whenever ovn.env is generated with OVN NB
+// or SB connection string different from the previous one. Callbacks are executed synchronously while
+// the configuration is being regenerated, so they must not block. Connection strings known at the time of
+// subscription are returned, along with function that cancels the subscription. Returned strings are empty
+// if ovn.env was not generated yet.
+func SubscribeConnectStrings(callback func(ConnectStrings)) (ConnectStrings, func()) {
+	connectSubscribers.Lock()
+	defer connectSubscribers.Unlock()
+
+	if connectSubscribers.callbacks == nil {
+		connectSubscribers.callbacks = map[int]func(ConnectStrings){}
+	}
+
+	id := connectSubscribers.next
+	connectSubscribers.next++
+	connectSubscribers.callbacks[id] = callback
+
+	unsubscribe := func() {
+		connectSubscribers.Lock()
+		defer connectSubscribers.Unlock()
+
+		delete(connectSubscribers.callbacks, id)
+	}
+
+	return connectSubscribers.last, unsubscribe
+}
+
+// notifyConnectStrings records connection strings "current" and, if they differ from the previously
+// recorded ones, delivers them to all subscribers. Strings recorded for the first time are not delivered,
+// subscribers receive them from SubscribeConnectStrings. Decrease of the number of NB or SB endpoints is
+// logged, as it means that a central member is gone, which may happen unnoticed otherwise.
+func notifyConnectStrings(current ConnectStrings) {
+	connectSubscribers.Lock()
+	previous := connectSubscribers.last
+	connectSubscribers.last = current
+
+	callbacks := make([]func(ConnectStrings), 0, len(connectSubscribers.callbacks))
+	for _, callback := range connectSubscribers.callbacks {
+		callbacks = append(callbacks, callback)
+	}
+	connectSubscribers.Unlock()
+
+	if previous == (ConnectStrings{}) || previous == current {
+		return
+	}
+
+	for _, db := range []struct {
+		name     string
+		previous string
+		current  string
+	}{
+		{name: "NB", previous: previous.NB, current: current.NB},
+		{name: "SB", previous: previous.SB, current: current.SB},
+	} {
+		before := connectEndpointCount(db.previous)
+		after := connectEndpointCount(db.current)
+		if after < before {
+			logger.Warnf("Number of OVN %s endpoints decreased from %d to %d (%s)", db.name, before, after, db.current)
+		}
+	}
+
+	for _, callback := range callbacks {
+		callback(current)
+	}
+}
@@ -0,0 +1,97 @@
+package ovn
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/canonical/microcluster/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+)
+
+const controlSocketPingTimeout = 2 * time.Second // Time that daemon has to respond to a ping on its control socket
+
+// ControlSockets returns status of control sockets of all daemons run by the services that are active on
+// this member, keyed by daemon name (e.g. "ovnnb_db" or "ovn-northd"). Every existing socket is pinged with
+// a "version" command, so that a daemon that is stuck can be told apart from a daemon that is not running.
+func ControlSockets(s *state.State) (map[string]types.SocketStatus, error) {
+	snapshot, err := loadServicesSnapshot(s)
+	if err != nil {
+		return nil, err
+	}
+
+	sockets := map[string]types.SocketStatus{}
+	for _, service := range snapshot.LocalServices() {
+		pidFiles, ok := serviceDaemonPidFiles[service]
+		if !ok {
+			continue
+		}
+
+		for _, pidFile := range pidFiles() {
+			daemon := strings.TrimSuffix(filepath.Base(pidFile), ".pid")
+			sockets[daemon] = controlSocketStatus(s, pidFile)
+		}
+	}
+
+	return sockets, nil
+}
+
+// controlSocketStatus returns status of the control socket of the daemon that writes "pidFile".
+func controlSocketStatus(s *state.State, pidFile string) types.SocketStatus {
+	status := types.SocketStatus{}
+	sock, err := controlSocketPath(pidFile)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+
+	status.Path = sock
+	_, err = os.Stat(sock)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+
+	status.Exists = true
+	_, err = AppCtlWithTimeout(s, sock, controlSocketPingTimeout, "version")
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+
+	status.Responding = true
+	return status
+}
+
+// controlSocketPath returns path to the control socket of the daemon that writes "pidFile". OVN database
+// servers are started with fixed socket names ("<daemon>.ctl"), other daemons create socket named after
+// their process ID ("<daemon>.<pid>.ctl").
+func controlSocketPath(pidFile string) (string, error) {
+	base := strings.TrimSuffix(pidFile, ".pid")
+	fixed := base + ".ctl"
+	_, err := os.Stat(fixed)
+	if err == nil {
+		return fixed, nil
+	}
+
+	content, err := os.ReadFile(pidFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("daemon is not running, pid file %s does not exist", pidFile)
+		}
+
+		return "", err
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(content)))
+	if err != nil {
+		return "", fmt.Errorf("invalid pid file %s: %w", pidFile, err)
+	}
+
+	return fmt.Sprintf("%s.%d.ctl", base, pid), nil
+}
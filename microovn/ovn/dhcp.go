@@ -0,0 +1,103 @@
+package ovn
+
+import (
+	"fmt"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/api"
+)
+
+// dhcpSwitchExternalID is a key in external_ids of DHCP_Options records created by ApplyDHCPDefaults. Its
+// value is name of the logical switch that the options were created for.
+const dhcpSwitchExternalID = "microovn-switch"
+
+// ApplyDHCPDefaults creates DHCP_Options record for subnet "cidr" of logical switch "switchName" in the
+// OVN Northbound database, and populates it with cluster-wide default options stored in ConfigKeyDHCPv4Options
+// or ConfigKeyDHCPv6Options, depending on the address family of the subnet. If such record already exists,
+// its options are replaced by the defaults. UUID of the DHCP_Options record is returned, so it can be
+// referenced by logical switch ports.
+//
+// This function must be executed on a member that runs the "central" service.
+func ApplyDHCPDefaults(s *state.State, switchName string, cidr string) (string, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return "", api.StatusErrorf(http.StatusBadRequest, "invalid subnet '%s': %s", cidr, err)
+	}
+
+	configKey := ConfigKeyDHCPv4Options
+	if prefix.Addr().Is6() {
+		configKey = ConfigKeyDHCPv6Options
+	}
+
+	defaults, err := GetConfig(s, configKey)
+	if err != nil {
+		return "", err
+	}
+
+	if defaults == "" {
+		return "", api.StatusErrorf(http.StatusNotFound, "default DHCP options are not configured ('%s')", configKey)
+	}
+
+	_, err = localNBCtl(s, "get", "Logical_Switch", switchName, "name")
+	if err != nil {
+		return "", fmt.Errorf("failed to find logical switch '%s': %w", switchName, err)
+	}
+
+	uuid, err := findDHCPOptions(s, switchName, prefix.String())
+	if err != nil {
+		return "", err
+	}
+
+	if uuid == "" {
+		output, err := localNBCtl(
+			s,
+			"dhcp-options-create",
+			prefix.String(),
+			fmt.Sprintf("%s=%s", dhcpSwitchExternalID, switchName),
+		)
+		if err != nil {
+			return "", fmt.Errorf("failed to create DHCP options for '%s': %w", prefix.String(), err)
+		}
+
+		uuid = strings.TrimSpace(output)
+		if uuid == "" {
+			uuid, err = findDHCPOptions(s, switchName, prefix.String())
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+
+	args := append([]string{"dhcp-options-set-options", uuid}, strings.Fields(defaults)...)
+	_, err = localNBCtl(s, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to set DHCP options for '%s': %w", prefix.String(), err)
+	}
+
+	return uuid, nil
+}
+
+// findDHCPOptions returns UUID of the DHCP_Options record created by ApplyDHCPDefaults for subnet "cidr" of
+// logical switch "switchName". Empty string is returned if such record does not exist.
+func findDHCPOptions(s *state.State, switchName string, cidr string) (string, error) {
+	output, err := localNBCtl(
+		s,
+		"--data=bare",
+		"--no-headings",
+		"--columns=_uuid",
+		"find",
+		"DHCP_Options",
+		fmt.Sprintf("cidr=%s", strconv.Quote(cidr)),
+		fmt.Sprintf("external_ids:%s=%s", dhcpSwitchExternalID, strconv.Quote(switchName)),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up DHCP options for '%s': %w", cidr, err)
+	}
+
+	uuid, _, _ := strings.Cut(strings.TrimSpace(output), "\n")
+	return strings.TrimSpace(uuid), nil
+}
@@ -0,0 +1,146 @@
+package ovn
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/canonical/microcluster/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+)
+
+// effectiveSetting describes OVN/OVS tunable whose value derived from the stored MicroOVN configuration can
+// be compared with the value currently applied to the local services.
+type effectiveSetting struct {
+	key      string                               // Name of the setting, configuration key if it has one
+	service  string                               // Local service that has to run for the live value to exist
+	expected func(s *state.State) (string, error) // Value derived from the stored configuration
+	live     func(s *state.State) (string, error) // Value currently applied to the local service
+}
+
+// storedConfig returns function that reads stored value of configuration option "key".
+func storedConfig(key string) func(s *state.State) (string, error) {
+	return func(s *state.State) (string, error) {
+		return GetConfig(s, key)
+	}
+}
+
+// fixedValue returns function that always returns "value".
+func fixedValue(value string) func(s *state.State) (string, error) {
+	return func(_ *state.State) (string, error) {
+		return value, nil
+	}
+}
+
+// chassisExternalID returns function that reads "key" from external_ids of local Open_vSwitch table.
+func chassisExternalID(key string) func(s *state.State) (string, error) {
+	return func(s *state.State) (string, error) {
+		return getChassisExternalID(s, key)
+	}
+}
+
+// firstRowColumn returns function that reads "column" of the first row of "table" with "ctl" command.
+func firstRowColumn(ctl func(*state.State, ...string) (string, error), table string, column string) func(s *state.State) (string, error) {
+	return func(s *state.State) (string, error) {
+		output, err := ctl(s, "--data=bare", "--no-headings", fmt.Sprintf("--columns=%s", column), "list", table)
+		if err != nil {
+			return "", err
+		}
+
+		value, _, _ := strings.Cut(strings.TrimSpace(output), "\n")
+		return strings.TrimSpace(value), nil
+	}
+}
+
+// globalOption returns function that reads NB_Global option controlled by configuration key "key".
+func globalOption(key string) func(s *state.State) (string, error) {
+	return func(s *state.State) (string, error) {
+		return GetGlobalOption(s, globalOptionConfigKeys[key])
+	}
+}
+
+// listenTarget returns functions that compute expected and read live connection target of OVN database
+// accessed with "ctl" command, that listens on "port".
+func listenTarget(ctl func(*state.State, ...string) (string, error), port int) (func(s *state.State) (string, error), func(s *state.State) (string, error)) {
+	expected := func(s *state.State) (string, error) {
+		return fmt.Sprintf("p%s:%d:[::]", networkProtocol(s), port), nil
+	}
+
+	live := func(s *state.State) (string, error) {
+		output, err := ctl(s, "get-connection")
+		if err != nil {
+			return "", err
+		}
+
+		value, _, _ := strings.Cut(strings.TrimSpace(output), "\n")
+		return strings.TrimSpace(value), nil
+	}
+
+	return expected, live
+}
+
+// effectiveSettings returns list of settings reported by EffectiveConfig.
+func effectiveSettings() []effectiveSetting {
+	nbListenExpected, nbListenLive := listenTarget(localNBCtl, 6641)
+	sbListenExpected, sbListenLive := listenTarget(localSBCtl, 6642)
+
+	return []effectiveSetting{
+		{key: "tls.protocol", service: "central", expected: func(s *state.State) (string, error) { return networkProtocol(s), nil }, live: listenProtocol},
+		{key: "ovn.nb.listen", service: "central", expected: nbListenExpected, live: nbListenLive},
+		{key: "ovn.sb.listen", service: "central", expected: sbListenExpected, live: sbListenLive},
+		{key: ConfigKeyNBInactivityProbe, service: "central", expected: storedConfig(ConfigKeyNBInactivityProbe), live: firstRowColumn(localNBCtl, "Connection", "inactivity_probe")},
+		{key: ConfigKeySBInactivityProbe, service: "central", expected: storedConfig(ConfigKeySBInactivityProbe), live: firstRowColumn(localSBCtl, "Connection", "inactivity_probe")},
+		{key: ConfigKeyNBMaxBackoff, service: "central", expected: storedConfig(ConfigKeyNBMaxBackoff), live: firstRowColumn(localNBCtl, "Connection", "max_backoff")},
+		{key: ConfigKeySBMaxBackoff, service: "central", expected: storedConfig(ConfigKeySBMaxBackoff), live: firstRowColumn(localSBCtl, "Connection", "max_backoff")},
+		{key: ConfigKeyFDBRemovalLimit, service: "central", expected: storedConfig(ConfigKeyFDBRemovalLimit), live: globalOption(ConfigKeyFDBRemovalLimit)},
+		{key: ConfigKeyMACBindingRemovalLimit, service: "central", expected: storedConfig(ConfigKeyMACBindingRemovalLimit), live: globalOption(ConfigKeyMACBindingRemovalLimit)},
+		{key: ConfigKeyNorthdThreads, service: "central", expected: storedConfig(ConfigKeyNorthdThreads), live: getNorthdThreadCount},
+		{key: "ovn.remote", service: "switch", expected: func(s *state.State) (string, error) { return connectString(s, 6642) }, live: chassisExternalID("ovn-remote")},
+		{key: "ovn.encap-type", service: "switch", expected: fixedValue("geneve"), live: chassisExternalID("ovn-encap-type")},
+		{key: ConfigKeyEncapIP, service: "switch", expected: encapAddress, live: chassisExternalID("ovn-encap-ip")},
+		{key: ConfigKeyEncapTos, service: "switch", expected: storedConfig(ConfigKeyEncapTos), live: chassisExternalID("ovn-encap-tos")},
+		{key: ConfigKeyRemoteProbeInterval, service: "switch", expected: storedConfig(ConfigKeyRemoteProbeInterval), live: chassisExternalID("ovn-remote-probe-interval")},
+		{key: ConfigKeyMonitorAll, service: "switch", expected: storedConfig(ConfigKeyMonitorAll), live: chassisExternalID("ovn-monitor-all")},
+		{key: ConfigKeyBridge, service: "switch", expected: integrationBridge, live: chassisExternalID("ovn-bridge")},
+	}
+}
+
+// EffectiveConfig gathers OVN/OVS tunables of this member, comparing values derived from the stored
+// configuration with values currently applied to the local services. Settings of services that don't run
+// locally are omitted. Setting is reported as diverged if its stored value is set and the live value
+// differs, which indicates that the configuration was not applied successfully. Settings that are not set
+// use defaults of the services and are never reported as diverged.
+func EffectiveConfig(s *state.State) ([]types.EffectiveConfigValue, error) {
+	active := map[string]bool{}
+	for _, service := range []string{"central", "switch"} {
+		isActive, err := localServiceActive(s, service)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query local services: %w", err)
+		}
+
+		active[service] = isActive
+	}
+
+	values := []types.EffectiveConfigValue{}
+	for _, setting := range effectiveSettings() {
+		if !active[setting.service] {
+			continue
+		}
+
+		expected, err := setting.expected(s)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get stored value of '%s': %w", setting.key, err)
+		}
+
+		value := types.EffectiveConfigValue{Key: setting.key, Stored: expected}
+		value.Live, err = setting.live(s)
+		if err != nil {
+			value.Error = err.Error()
+		}
+
+		value.Diverged = value.Error != "" || (expected != "" && expected != value.Live)
+		values = append(values, value)
+	}
+
+	return values, nil
+}
@@ -0,0 +1,173 @@
+package ovn
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/canonical/microcluster/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/ovn/paths"
+)
+
+// shellUnescaper reverts escaping applied by shellEscaper.
+var shellUnescaper = strings.NewReplacer(`\\`, `\`, `\"`, `"`, `\$`, "$", "\\`", "`")
+
+// VerifyEnvApplied compares configuration that running OVN daemons actually use with the ovn.env file on
+// disk and returns list of daemons that run with stale configuration and need their service restarted.
+// Following is checked for daemons of services that are active on this member:
+//   - every daemon was started after the last change of ovn.env
+//   - ovn-northd connects to NB and SB databases listed in ovn.env
+//   - ovn-controller connects to SB databases listed in ovn.env ("ovn-remote" chassis setting)
+//
+// Daemons that are not running are not reported, nor is anything reported if ovn.env does not exist yet.
+func VerifyEnvApplied(s *state.State) ([]types.EnvironmentDrift, error) {
+	env, envModified, err := readEnvironment()
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []types.EnvironmentDrift{}, nil
+		}
+
+		return nil, err
+	}
+
+	snapshot, err := loadServicesSnapshot(s)
+	if err != nil {
+		return nil, err
+	}
+
+	drifts := []types.EnvironmentDrift{}
+	for _, service := range snapshot.LocalServices() {
+		pidFiles, ok := serviceDaemonPidFiles[service]
+		if !ok {
+			continue
+		}
+
+		for _, pidFile := range pidFiles() {
+			daemon := strings.TrimSuffix(filepath.Base(pidFile), ".pid")
+			pidInfo, err := os.Stat(pidFile)
+			if err != nil {
+				continue
+			}
+
+			if envModified.After(pidInfo.ModTime()) {
+				drifts = append(drifts, types.EnvironmentDrift{
+					Service:  service,
+					Daemon:   daemon,
+					Setting:  "ovn.env",
+					Expected: envModified.UTC().Format(time.RFC3339),
+					Live:     pidInfo.ModTime().UTC().Format(time.RFC3339),
+				})
+			}
+
+			if daemon != "ovn-northd" {
+				continue
+			}
+
+			args, err := daemonArgs(pidFile)
+			if err != nil {
+				// Daemon exited after its pid file was checked.
+				continue
+			}
+
+			for flag, expected := range map[string]string{
+				"--ovnnb-db": northdEnvDatabase(env, "OVN_NORTHD_NB_DB", "OVN_NB_CONNECT"),
+				"--ovnsb-db": northdEnvDatabase(env, "OVN_NORTHD_SB_DB", "OVN_SB_CONNECT"),
+			} {
+				live := args[flag]
+				if live != expected {
+					drifts = append(drifts, types.EnvironmentDrift{Service: service, Daemon: daemon, Setting: flag, Expected: expected, Live: live})
+				}
+			}
+		}
+
+		if service == "chassis" {
+			live, err := getChassisExternalID(s, "ovn-remote")
+			if err != nil {
+				return nil, fmt.Errorf("failed to get OVN SB remote of the chassis: %w", err)
+			}
+
+			// Connect string in ovn.env may have IPv6 addresses unbracketed, see ConfigKeyEnvBracketIPv6.
+			if ipv6Unbracketer.Replace(live) != ipv6Unbracketer.Replace(env["OVN_SB_CONNECT"]) {
+				drifts = append(drifts, types.EnvironmentDrift{Service: service, Daemon: "ovn-controller", Setting: "ovn-remote", Expected: env["OVN_SB_CONNECT"], Live: live})
+			}
+		}
+	}
+
+	return drifts, nil
+}
+
+// northdEnvDatabase returns value of the variable "override" from "env", or value of "fallback" if it's not
+// set, in the same way as ovn-northd database arguments are resolved by the central service.
+func northdEnvDatabase(env map[string]string, override string, fallback string) string {
+	value := env[override]
+	if value == "" {
+		value = env[fallback]
+	}
+
+	return value
+}
+
+// readEnvironment parses ovn.env file on disk and returns its variables along with the time of its last
+// modification.
+func readEnvironment() (map[string]string, time.Time, error) {
+	info, err := os.Stat(paths.OvnEnvFile())
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("couldn't read ovn.env: %w", err)
+	}
+
+	content, err := os.ReadFile(paths.OvnEnvFile())
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("couldn't read ovn.env: %w", err)
+	}
+
+	env := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		name, value, found := strings.Cut(scanner.Text(), "=")
+		if !found || !ovnEnvVarName.MatchString(name) {
+			continue
+		}
+
+		value = strings.TrimSuffix(strings.TrimPrefix(value, `"`), `"`)
+		env[name] = shellUnescaper.Replace(value)
+	}
+
+	return env, info.ModTime(), scanner.Err()
+}
+
+// daemonArgs returns command line arguments in the "--name=value" format of the daemon that writes
+// "pidFile", keyed by their names.
+func daemonArgs(pidFile string) (map[string]string, error) {
+	content, err := os.ReadFile(pidFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pid file '%s': %w", pidFile, err)
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(content)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid pid in '%s': %w", pidFile, err)
+	}
+
+	cmdline, err := os.ReadFile(fmt.Sprintf("/proc/%d/cmdline", pid))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read command line of process %d: %w", pid, err)
+	}
+
+	args := map[string]string{}
+	for _, arg := range strings.Split(string(cmdline), "\x00") {
+		name, value, found := strings.Cut(arg, "=")
+		if found && strings.HasPrefix(name, "--") {
+			args[name] = value
+		}
+	}
+
+	return args, nil
+}
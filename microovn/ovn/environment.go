@@ -1,198 +1,451 @@
 package ovn
 
 import (
-	"context"
-	"database/sql"
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"net/netip"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"text/template"
 	"time"
 
 	"github.com/canonical/microcluster/state"
 	"github.com/lxc/lxd/shared/logger"
 
-	"github.com/canonical/microovn/microovn/database"
 	"github.com/canonical/microovn/microovn/ovn/paths"
 )
 
-var ovnEnvTpl = template.Must(template.New("ovnEnvTpl").Parse(`# # Generated by MicroOVN, DO NOT EDIT.
-OVN_INITIAL_NB="{{ .nbInitial }}"
-OVN_INITIAL_SB="{{ .sbInitial }}"
-OVN_NB_CONNECT="{{ .nbConnect }}"
-OVN_SB_CONNECT="{{ .sbConnect }}"
-OVN_LOCAL_IP="{{ .localAddr }}"
+const requiredDirMode = 0700 // Expected permissions of directories created by createPaths
+
+// ovnEnvTpl renders map of variable names and their values into ovn.env file. Variables are rendered
+// in alphabetical order and their values are escaped to be safely used in double-quoted shell strings.
+var ovnEnvTpl = template.Must(template.New("ovnEnvTpl").Funcs(template.FuncMap{
+	"escape": shellEscaper.Replace,
+}).Parse(`# # Generated by MicroOVN, DO NOT EDIT.
+{{- range $name, $value := . }}
+{{ $name }}="{{ escape $value }}"
+{{- end }}
 `))
 
+// shellEscaper escapes characters that have special meaning inside double-quoted shell strings.
+var shellEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`, "`", "\\`")
+
+// ovnEnvVarName is a pattern that all variable names in ovn.env file must match.
+var ovnEnvVarName = regexp.MustCompile(`^[A-Z_][A-Z0-9_]*$`)
+
+// protocolCache holds result of the last successful networkProtocol lookup. Only the "ssl" result is
+// cached, because lookup failure may be transient. Cache is invalidated by invalidateNetworkProtocol
+// whenever CA certificate changes.
+var protocolCache struct {
+	sync.Mutex
+	generation uint64 // Incremented on every invalidation
+	protocol   string // Cached protocol, empty if there's no cached value
+}
+
 // networkProtocol returns appropriate network protocol that should be used
 // by OVN services.
 func networkProtocol(s *state.State) string {
+	protocolCache.Lock()
+	if protocolCache.protocol != "" {
+		defer protocolCache.Unlock()
+		return protocolCache.protocol
+	}
+	generation := protocolCache.generation
+	protocolCache.Unlock()
+
 	_, _, err := getCA(s)
 	if err != nil {
 		return "tcp"
-	} else {
-		return "ssl"
 	}
 
+	protocolCache.Lock()
+	defer protocolCache.Unlock()
+	// Don't store the result if cache was invalidated while we were looking up CA.
+	if protocolCache.generation == generation {
+		protocolCache.protocol = "ssl"
+	}
+
+	return "ssl"
 }
 
-// localServiceActive function accepts service names (like "central" or "switch") and returns true/false based
-// on whether the selected service is running on this node.
-func localServiceActive(s *state.State, serviceName string) (bool, error) {
-	serviceActive := false
-	err := s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
-		// Get list of all active local services.
-		name := s.Name()
-		services, err := database.GetServices(ctx, tx, database.ServiceFilter{Member: &name})
-		if err != nil {
-			return err
-		}
+// invalidateNetworkProtocol discards cached result of networkProtocol, forcing the next call to
+// look up the CA certificate again.
+func invalidateNetworkProtocol() {
+	protocolCache.Lock()
+	defer protocolCache.Unlock()
 
-		// Check if the specified service is among active local services.
-		for _, srv := range services {
-			if srv.Service == serviceName {
-				serviceActive = true
-			}
-		}
+	protocolCache.generation++
+	protocolCache.protocol = ""
+}
 
-		return nil
-	})
+// bracketAddress wraps IPv6 addresses in square brackets, as expected by OVN in connection
+// strings. Other values are returned unchanged. IPv4-mapped IPv6 addresses should be unmapped by
+// the caller, so that the same member is always rendered the same way.
+func bracketAddress(addr string) string {
+	ip, err := netip.ParseAddr(addr)
+	if err == nil && ip.Is6() {
+		return "[" + addr + "]"
+	}
 
-	return serviceActive, err
+	return addr
 }
 
-func connectString(s *state.State, port int) (string, error) {
-	var err error
-	var servers []database.Service
+// ipv6Unbracketer removes square brackets around IPv6 addresses, including those in connection strings.
+var ipv6Unbracketer = strings.NewReplacer("[", "", "]", "")
+
+// bracketedEnvVariables lists ovn.env variables whose values contain addresses bracketed by bracketAddress.
+var bracketedEnvVariables = []string{
+	"OVN_INITIAL_NB",
+	"OVN_INITIAL_SB",
+	"OVN_NB_CONNECT",
+	"OVN_SB_CONNECT",
+	"OVN_LOCAL_IP",
+	"OVN_ENCAP_IP",
+}
 
-	err = s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
-		serviceName := "central"
-		servers, err = database.GetServices(ctx, tx, database.ServiceFilter{Service: &serviceName})
-		if err != nil {
-			return err
-		}
+// unbracketEnvironment removes square brackets around IPv6 addresses in "env", if ConfigKeyEnvBracketIPv6
+// is set to "false". This is meant only for external tools that parse ovn.env and can't handle bracketed
+// addresses. Note that OVN itself requires IPv6 addresses in connection strings to be bracketed, so OVN
+// services of members with IPv6 addresses won't be able to connect to OVN databases with this setting.
+func unbracketEnvironment(s *state.State, env map[string]string) error {
+	bracket, err := GetConfig(s, ConfigKeyEnvBracketIPv6)
+	if err != nil {
+		return err
+	}
 
+	if bracket != "false" {
 		return nil
-	})
+	}
+
+	for _, name := range bracketedEnvVariables {
+		env[name] = ipv6Unbracketer.Replace(env[name])
+	}
+
+	return nil
+}
+
+// encapAddress returns IP address that OVN chassis on this member uses for tunnel encapsulation. Address
+// configured in ConfigKeyEncapIP takes precedence, otherwise service address of the member is used.
+func encapAddress(s *state.State) (string, error) {
+	encapIP, err := GetConfig(s, ConfigKeyEncapIP)
 	if err != nil {
 		return "", err
 	}
 
-	addresses := make([]string, 0, len(servers))
-	remotes := s.Remotes().RemotesByName()
-	protocol := networkProtocol(s)
-	for _, server := range servers {
-		remote, ok := remotes[server.Member]
-		if !ok {
-			continue
-		}
+	if encapIP != "" {
+		return encapIP, nil
+	}
 
-		addresses = append(
-			addresses,
-			fmt.Sprintf("%s:%s",
-				protocol,
-				netip.AddrPortFrom(remote.Address.Addr(), uint16(port)).String(),
-			),
-		)
+	return serviceAddress(s)
+}
+
+// localServiceActive function accepts service names (like "central" or "switch") and returns true/false based
+// on whether the selected service is running on this node.
+func localServiceActive(s *state.State, serviceName string) (bool, error) {
+	snapshot, err := loadServicesSnapshot(s)
+	if err != nil {
+		return false, err
+	}
+
+	return snapshot.LocalServiceActive(serviceName), nil
+}
+
+// connectString returns OVN connection string that lists servers on "port" of all "central" members.
+func connectString(s *state.State, port int) (string, error) {
+	snapshot, err := loadServicesSnapshot(s)
+	if err != nil {
+		return "", err
 	}
 
-	return strings.Join(addresses, ","), nil
+	return snapshot.ConnectString(networkProtocol(s), port), nil
 }
 
-func generateEnvironment(s *state.State) error {
+// environmentVariables computes values of all variables that should be rendered into ovn.env file
+// on this cluster member, from the services "snapshot". If "snapshot" is nil, a fresh one is loaded.
+// Returned map is keyed by variable names.
+func environmentVariables(s *state.State, snapshot *servicesSnapshot) (map[string]string, error) {
 	// Get the servers.
-	nbConnect, err := connectString(s, 6641)
+	snapshot, err := ensureServicesSnapshot(s, snapshot)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	sbConnect, err := connectString(s, 6642)
+	protocol := networkProtocol(s)
+	nbConnect := snapshot.ConnectString(protocol, 6641)
+	sbConnect := snapshot.ConnectString(protocol, 6642)
+	address, err := serviceAddress(s)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	localAddr := bracketAddress(address)
+
+	// During bootstrap, no member runs "central" service yet and this member is about to become the first
+	// one. Render environment that points at the local address, so that the initial central can start.
+	if nbConnect == "" {
+		nbConnect = fmt.Sprintf("%s:%s:6641", protocol, localAddr)
+	}
+
+	if sbConnect == "" {
+		sbConnect = fmt.Sprintf("%s:%s:6642", protocol, localAddr)
 	}
 
 	// Get the initial (first server).
-	var nbInitial string
-	var sbInitial string
-	err = s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
-		serviceName := "central"
-		servers, err := database.GetServices(ctx, tx, database.ServiceFilter{Service: &serviceName})
-		if err != nil {
-			return err
-		}
+	initial, err := snapshot.InitialCentral(localAddr)
+	if err != nil {
+		return nil, err
+	}
 
-		server := servers[0]
+	nbInitial := initial
+	sbInitial := initial
 
-		remotes := s.Remotes().RemotesByName()
-		remote, ok := remotes[server.Member]
-		if !ok {
-			return fmt.Errorf("Remote couldn't be found for %q", server.Member)
-		}
+	encapAddr, err := encapAddress(s)
+	if err != nil {
+		return nil, err
+	}
+
+	systemID, err := getSystemID(s)
+	if err != nil {
+		return nil, err
+	}
+
+	env := map[string]string{
+		"OVN_INITIAL_NB": nbInitial,
+		"OVN_INITIAL_SB": sbInitial,
+		"OVN_NB_CONNECT": nbConnect,
+		"OVN_SB_CONNECT": sbConnect,
+		"OVN_LOCAL_IP":   localAddr,
+		"OVN_ENCAP_IP":   bracketAddress(encapAddr),
+		"OVN_SYSTEM_ID":  systemID,
+	}
+
+	northdThreads, err := northdThreadCount(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if northdThreads > 0 {
+		env["OVN_NORTHD_N_THREADS"] = strconv.Itoa(northdThreads)
+	}
 
-		addrString := remote.Address.Addr().String()
-		if remote.Address.Addr().Is6() {
-			addrString = "[" + addrString + "]"
+	northdDBs, err := northdLocalDatabases(s, nbConnect, sbConnect)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, value := range northdDBs {
+		env[name] = value
+	}
+
+	external, err := northdExternal(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if external {
+		env["OVN_NORTHD_EXTERNAL"] = "true"
+	}
+
+	controllerArgs, err := GetConfig(s, ConfigKeyControllerArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	if controllerArgs != "" {
+		env["OVN_CONTROLLER_EXTRA_ARGS"] = strings.Join(strings.Fields(controllerArgs), " ")
+	}
+
+	logArgs, err := ovnLogArgs(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if logArgs != "" {
+		env["OVN_LOG_ARGS"] = logArgs
+	}
+
+	err = unbracketEnvironment(s, env)
+	if err != nil {
+		return nil, err
+	}
+
+	return env, nil
+}
+
+// renderEnvironment writes variables from "env" into "w" in the format of ovn.env file. Variable
+// names must be valid shell variable names composed of uppercase letters, digits and underscores.
+func renderEnvironment(w io.Writer, env map[string]string) error {
+	for name := range env {
+		if !ovnEnvVarName.MatchString(name) {
+			return fmt.Errorf("invalid ovn.env variable name %q", name)
 		}
+	}
 
-		nbInitial = addrString
-		sbInitial = addrString
+	return ovnEnvTpl.Execute(w, env)
+}
 
-		return nil
-	})
+// generateEnvironment renders ovn.env of this cluster member from the services "snapshot". If "snapshot" is
+// nil, a fresh one is loaded.
+func generateEnvironment(s *state.State, snapshot *servicesSnapshot) error {
+	env, err := environmentVariables(s, snapshot)
 	if err != nil {
 		return err
 	}
 
-	// Generate ovn.env.
-	fd, err := os.OpenFile(paths.OvnEnvFile(), os.O_CREATE|os.O_TRUNC|os.O_RDWR, 0644)
+	// Generate ovn.env. File is rewritten only if its content changes, so that its modification time
+	// tells when services started with an outdated environment need to be restarted.
+	var rendered bytes.Buffer
+	err = renderEnvironment(&rendered, env)
 	if err != nil {
-		return fmt.Errorf("Couldn't open ovn.env: %w", err)
+		return fmt.Errorf("Couldn't render ovn.env: %w", err)
 	}
-	defer fd.Close()
 
-	localAddr := s.Address().Hostname()
-	if ip, err := netip.ParseAddr(localAddr); err == nil && ip.Is6() {
-		localAddr = "[" + localAddr + "]"
+	current, err := os.ReadFile(paths.OvnEnvFile())
+	if err == nil && bytes.Equal(current, rendered.Bytes()) {
+		notifyConnectStrings(ConnectStrings{NB: env["OVN_NB_CONNECT"], SB: env["OVN_SB_CONNECT"]})
+		return nil
 	}
 
-	err = ovnEnvTpl.Execute(fd, map[string]any{
-		"localAddr": localAddr,
-		"nbInitial": nbInitial,
-		"sbInitial": sbInitial,
-		"nbConnect": nbConnect,
-		"sbConnect": sbConnect,
-	})
+	err = os.WriteFile(paths.OvnEnvFile(), rendered.Bytes(), 0644)
 	if err != nil {
-		return fmt.Errorf("Couldn't render ovn.env: %w", err)
+		return fmt.Errorf("Couldn't write ovn.env: %w", err)
 	}
 
+	notifyConnectStrings(ConnectStrings{NB: env["OVN_NB_CONNECT"], SB: env["OVN_SB_CONNECT"]})
 	return nil
 }
 
+// VerifyEnvironment renders ovn.env content that generateEnvironment would produce from the current
+// cluster state and compares it with the ovn.env file on disk, without modifying it. It returns true if
+// they match. Otherwise, human-readable diff is returned as well, with lines missing from the file on disk
+// prefixed by "+" and stale lines prefixed by "-".
+func VerifyEnvironment(s *state.State) (bool, string, error) {
+	env, err := environmentVariables(s, nil)
+	if err != nil {
+		return false, "", err
+	}
+
+	expected := bytes.Buffer{}
+	err = renderEnvironment(&expected, env)
+	if err != nil {
+		return false, "", fmt.Errorf("couldn't render ovn.env: %w", err)
+	}
+
+	current, err := os.ReadFile(paths.OvnEnvFile())
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return false, "", fmt.Errorf("couldn't read ovn.env: %w", err)
+	}
+
+	if bytes.Equal(expected.Bytes(), current) {
+		return true, "", nil
+	}
+
+	return false, diffLines(string(current), expected.String()), nil
+}
+
+// diffLines returns simple line-based diff between "old" and "new". Lines present only in "old" are
+// prefixed by "-" and lines present only in "new" are prefixed by "+". Order of lines is preserved.
+func diffLines(old string, new string) string {
+	oldLines := strings.Split(strings.TrimSuffix(old, "\n"), "\n")
+	newLines := strings.Split(strings.TrimSuffix(new, "\n"), "\n")
+
+	oldSet := make(map[string]bool, len(oldLines))
+	for _, line := range oldLines {
+		oldSet[line] = true
+	}
+
+	newSet := make(map[string]bool, len(newLines))
+	for _, line := range newLines {
+		newSet[line] = true
+	}
+
+	diff := strings.Builder{}
+	for _, line := range oldLines {
+		if !newSet[line] {
+			diff.WriteString("-" + line + "\n")
+		}
+	}
+
+	for _, line := range newLines {
+		if !oldSet[line] {
+			diff.WriteString("+" + line + "\n")
+		}
+	}
+
+	return diff.String()
+}
+
+// createPaths creates directories required by MicroOVN. Directories that already exist are checked
+// for correct permissions and ownership and fixed if they don't match the expected values.
 func createPaths() error {
 	// Create our various paths.
 	for _, path := range paths.RequiredDirs() {
-		err := os.MkdirAll(path, 0700)
+		err := os.MkdirAll(path, requiredDirMode)
 		if err != nil {
 			return fmt.Errorf("Unable to create %q: %w", path, err)
 		}
+
+		err = ensureDirPermissions(path)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ensureDirPermissions verifies that directory "path" has mode set to requiredDirMode and that it is
+// owned by the current user. Any discrepancies are corrected and logged.
+func ensureDirPermissions(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("Unable to inspect %q: %w", path, err)
+	}
+
+	if info.Mode().Perm() != requiredDirMode {
+		logger.Warnf("Fixing permissions of %q (%04o -> %04o)", path, info.Mode().Perm(), requiredDirMode)
+		err = os.Chmod(path, requiredDirMode)
+		if err != nil {
+			return fmt.Errorf("Unable to set permissions of %q: %w", path, err)
+		}
+	}
+
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return nil
+	}
+
+	uid := os.Getuid()
+	gid := os.Getgid()
+	if int(stat.Uid) != uid || int(stat.Gid) != gid {
+		logger.Warnf("Fixing ownership of %q (%d:%d -> %d:%d)", path, stat.Uid, stat.Gid, uid, gid)
+		err = os.Chown(path, uid, gid)
+		if err != nil {
+			return fmt.Errorf("Unable to set ownership of %q: %w", path, err)
+		}
 	}
 
 	return nil
 }
 
-// cleanupPaths backs up directories defined by paths.BackupDirs and then removes directories
-// created by createPaths function. This effectively removes any data created during MicroOVN runtime.
-func cleanupPaths() error {
+// cleanupPaths backs up directories defined by paths.BackupDirs into a new backup directory under "root" and
+// then removes directories created by createPaths function. This effectively removes any data created during
+// MicroOVN runtime. If "root" is on a different filesystem, backed up directories are copied and removed,
+// instead of renamed.
+func cleanupPaths(root string) error {
 	var errs []error
 
 	// Create timestamped backup dir
-	backupDir := fmt.Sprintf("backup_%d", time.Now().Unix())
-	backupPath := filepath.Join(paths.Root(), backupDir)
-	err := os.Mkdir(backupPath, 0750)
+	backupDir := backupDirName(time.Now())
+	backupPath := filepath.Join(root, backupDir)
+	err := os.MkdirAll(backupPath, 0750)
 	if err != nil {
 		errs = append(
 			errs,
@@ -209,7 +462,7 @@ func cleanupPaths() error {
 	for _, dir := range paths.BackupDirs() {
 		_, fileName := filepath.Split(dir)
 		destination := filepath.Join(backupPath, fileName)
-		err = os.Rename(dir, destination)
+		err = moveDir(dir, destination)
 		if err != nil {
 			errs = append(errs, err)
 		}
@@ -0,0 +1,84 @@
+package ovn
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/canonical/microcluster/client"
+	"github.com/canonical/microcluster/state"
+)
+
+// defaultFanOutConcurrency is a number of cluster members that fleet-wide operations contact at the same
+// time, unless ConfigKeyFanOutConcurrency is set.
+const defaultFanOutConcurrency = 8
+
+// fanOutSlots limits number of concurrent requests made by all fleet-wide operations of this member.
+var fanOutSlots = &fanOutLimiter{released: make(chan struct{})}
+
+// fanOutLimiter is a semaphore whose size can change between acquisitions, so that changes of
+// ConfigKeyFanOutConcurrency take effect without restart.
+type fanOutLimiter struct {
+	mu       sync.Mutex
+	active   int           // Number of slots currently held
+	released chan struct{} // Closed and replaced whenever a slot is released
+}
+
+// acquire waits until fewer than "limit" slots are held and takes one of them. Error is returned if "ctx" is
+// cancelled while waiting.
+func (l *fanOutLimiter) acquire(ctx context.Context, limit int) error {
+	for {
+		l.mu.Lock()
+		if l.active < limit {
+			l.active++
+			l.mu.Unlock()
+			return nil
+		}
+
+		released := l.released
+		l.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-released:
+		}
+	}
+}
+
+// release returns slot taken by acquire and wakes up waiting callers.
+func (l *fanOutLimiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.active--
+	close(l.released)
+	l.released = make(chan struct{})
+}
+
+// QueryCluster executes "query" against every cluster member other than this one. Members are queried
+// concurrently, but no more than ConfigKeyFanOutConcurrency requests are in flight at a time, counting
+// requests of all fleet-wide operations running on this member. Argument "r" is the request that triggered
+// the operation, if any, see state.State.Cluster.
+func QueryCluster(s *state.State, r *http.Request, query func(ctx context.Context, c *client.Client) error) error {
+	limit, err := getConfigInt(s, ConfigKeyFanOutConcurrency, defaultFanOutConcurrency)
+	if err != nil {
+		return err
+	}
+
+	cluster, err := s.Cluster(r)
+	if err != nil {
+		return fmt.Errorf("failed to get a client for every cluster member: %w", err)
+	}
+
+	return cluster.Query(s.Context, true, func(ctx context.Context, c *client.Client) error {
+		err := fanOutSlots.acquire(ctx, limit)
+		if err != nil {
+			return err
+		}
+
+		defer fanOutSlots.release()
+		return query(ctx, c)
+	})
+}
@@ -0,0 +1,93 @@
+package ovn
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/api"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	microovnClient "github.com/canonical/microovn/microovn/client"
+)
+
+// ClusterFootprint returns resources used by OVN across the whole cluster. Sizes of NB and SB databases are
+// summed over every central member, as each of them stores a full replica. Local sizes are measured with
+// databaseSize, remote central members report theirs in their status. Chassis, logical flows and ports are
+// counted once in the OVN SB database, which is shared by the whole cluster. Central members that don't
+// respond are listed in the result and their databases are not included in the totals.
+//
+// This function must be executed on a member that runs the "central" service.
+func ClusterFootprint(s *state.State) (*types.Footprint, error) {
+	snapshot, err := loadServicesSnapshot(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query services: %w", err)
+	}
+
+	if !snapshot.LocalServiceActive("central") {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "cluster footprint must be gathered on a member that runs the 'central' service")
+	}
+
+	leader, err := s.Leader()
+	if err != nil {
+		return nil, err
+	}
+
+	footprint := types.Footprint{Unreachable: []string{}}
+	for _, member := range snapshot.ServiceMembers("central") {
+		var nbSize, sbSize int64
+		if member == s.Name() {
+			nbSize, err = databaseSize(OvsdbTypeNBLocal)
+			if err != nil {
+				return nil, err
+			}
+
+			sbSize, err = databaseSize(OvsdbTypeSBLocal)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			status, err := microovnClient.GetStatus(s.Context, leader.UseTarget(member))
+			if err != nil {
+				footprint.Unreachable = append(footprint.Unreachable, member)
+				continue
+			}
+
+			nbSize = status.Central.NBDatabaseSize
+			sbSize = status.Central.SBDatabaseSize
+		}
+
+		footprint.CentralMembers++
+		footprint.NBDatabaseSize += nbSize
+		footprint.SBDatabaseSize += sbSize
+	}
+
+	sort.Strings(footprint.Unreachable)
+
+	flowCounts, err := logicalFlowCounts(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count logical flows: %w", err)
+	}
+
+	for _, count := range flowCounts {
+		footprint.LogicalFlows += count
+	}
+
+	for _, table := range []struct {
+		name  string
+		count *int
+	}{
+		{name: "Chassis", count: &footprint.Chassis},
+		{name: "Port_Binding", count: &footprint.Ports},
+	} {
+		rows, err := listSBTable(s, table.name, "_uuid")
+		if err != nil {
+			return nil, fmt.Errorf("failed to count %s records: %w", table.name, err)
+		}
+
+		*table.count = len(rows)
+	}
+
+	return &footprint, nil
+}
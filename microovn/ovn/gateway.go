@@ -0,0 +1,138 @@
+package ovn
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/canonical/microcluster/state"
+)
+
+// gatewayCMSOption is the value of "ovn-cms-options" that marks chassis as eligible to host gateway ports.
+const gatewayCMSOption = "enable-chassis-as-gw"
+
+// gatewayCMSOptions returns desired value of "ovn-cms-options" chassis setting. Chassis is advertised as a
+// gateway candidate only if ConfigKeyGatewayPriority is set for the local member.
+func gatewayCMSOptions(s *state.State) (string, error) {
+	priority, err := GetConfig(s, ConfigKeyGatewayPriority)
+	if err != nil {
+		return "", err
+	}
+
+	if priority == "" {
+		return "", nil
+	}
+
+	return gatewayCMSOption, nil
+}
+
+// applyGatewayPriority sets priority of the local chassis, as configured in ConfigKeyGatewayPriority, in every
+// Gateway_Chassis and HA_Chassis record of OVN NB database that refers to it. Chassis with the highest
+// priority in a group hosts the active gateway, the others are backups. Records are left intact if the
+// priority is not configured.
+//
+// Only records that exist at the moment are updated. Gateways scheduled later get priority chosen by the CMS
+// that creates them, until the option is applied again.
+func applyGatewayPriority(s *state.State) error {
+	priority, err := GetConfig(s, ConfigKeyGatewayPriority)
+	if err != nil {
+		return err
+	}
+
+	if priority == "" {
+		return nil
+	}
+
+	systemID, err := getSystemID(s)
+	if err != nil {
+		return fmt.Errorf("failed to get chassis system-id: %w", err)
+	}
+
+	args := []string{}
+	for _, table := range []string{"Gateway_Chassis", "HA_Chassis"} {
+		output, err := NBCtl(s, "--bare", "--columns=_uuid", "find", table, fmt.Sprintf("chassis_name=%s", strconv.Quote(systemID)))
+		if err != nil {
+			return fmt.Errorf("failed to find %s records of chassis '%s': %w", table, systemID, err)
+		}
+
+		for _, uuid := range strings.Fields(output) {
+			if len(args) > 0 {
+				args = append(args, "--")
+			}
+
+			args = append(args, "set", table, uuid, fmt.Sprintf("priority=%s", priority))
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	_, err = NBCtl(s, args...)
+	if err != nil {
+		return fmt.Errorf("failed to set priority of chassis '%s': %w", systemID, err)
+	}
+
+	return nil
+}
+
+// isGatewayChassis returns true if "otherConfig" of OVN SB Chassis record, in the "bare" format returned by
+// listSBTable, marks the chassis as a gateway candidate.
+func isGatewayChassis(otherConfig string) bool {
+	for _, item := range strings.Fields(otherConfig) {
+		key, value, found := strings.Cut(item, "=")
+		if !found || key != "ovn-cms-options" {
+			continue
+		}
+
+		for _, option := range strings.Split(strings.Trim(value, "\""), ",") {
+			if option == gatewayCMSOption {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// gatewayPriorities returns priorities of chassis in OVN SB HA chassis groups, mapped by UUID of the chassis
+// and name of the group.
+func gatewayPriorities(s *state.State) (map[string]map[string]int, error) {
+	haChassisRows, err := listSBTable(s, "HA_Chassis", "_uuid", "chassis", "priority")
+	if err != nil {
+		return nil, err
+	}
+
+	groupRows, err := listSBTable(s, "HA_Chassis_Group", "name", "ha_chassis")
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string]string)
+	for _, row := range groupRows {
+		for _, haChassis := range strings.Fields(row[1]) {
+			groups[haChassis] = row[0]
+		}
+	}
+
+	priorities := make(map[string]map[string]int)
+	for _, row := range haChassisRows {
+		group, ok := groups[row[0]]
+		if !ok || row[1] == "" {
+			continue
+		}
+
+		priority, err := strconv.Atoi(row[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid priority '%s' of HA chassis '%s': %w", row[2], row[0], err)
+		}
+
+		if priorities[row[1]] == nil {
+			priorities[row[1]] = make(map[string]int)
+		}
+
+		priorities[row[1]][group] = priority
+	}
+
+	return priorities, nil
+}
@@ -0,0 +1,72 @@
+package ovn
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/ovn/paths"
+)
+
+const handshakeTimeout = 5 // Timeout (s) of OVSDB handshake with a single endpoint
+
+// clusterDatabases maps names of clustered OVN databases to ports on which their servers accept clients.
+var clusterDatabases = []struct {
+	name string
+	port int
+}{
+	{name: "OVN_Northbound", port: 6641},
+	{name: "OVN_Southbound", port: 6642},
+}
+
+// TestDatabaseConnections connects to NB and SB servers of every "central" member, using the configured
+// protocol and, for SSL, the local client certificate, and lists databases they serve. Unlike a TCP check,
+// this confirms that the endpoint speaks OVSDB and serves the expected OVN database. Result of every
+// endpoint is reported, failure to reach an endpoint is not an error.
+func TestDatabaseConnections(s *state.State) ([]types.DatabaseEndpointCheck, error) {
+	snapshot, err := loadServicesSnapshot(s)
+	if err != nil {
+		return nil, err
+	}
+
+	protocol := networkProtocol(s)
+	args := []string{"--timeout", strconv.Itoa(handshakeTimeout)}
+	if protocol == "ssl" {
+		certPath, keyPath := paths.PkiClientCertFiles()
+		args = append(args, "-p", keyPath, "-c", certPath, "-C", paths.PkiCaCertFile())
+	}
+
+	checks := []types.DatabaseEndpointCheck{}
+	for _, addr := range snapshot.CentralAddresses() {
+		for _, db := range clusterDatabases {
+			endpoint := fmt.Sprintf("%s:%s:%d", protocol, bracketAddress(addr.String()), db.port)
+			check := types.DatabaseEndpointCheck{Endpoint: endpoint, Expected: db.name, Databases: []string{}}
+
+			output, err := shared.RunCommandContext(s.Context, "ovsdb-client", append(args, "list-dbs", endpoint)...)
+			if err != nil {
+				check.Error = err.Error()
+				checks = append(checks, check)
+				continue
+			}
+
+			for _, name := range strings.Fields(output) {
+				check.Databases = append(check.Databases, name)
+				if name == db.name {
+					check.OK = true
+				}
+			}
+
+			if !check.OK {
+				check.Error = fmt.Sprintf("endpoint does not serve %s database", db.name)
+			}
+
+			checks = append(checks, check)
+		}
+	}
+
+	return checks, nil
+}
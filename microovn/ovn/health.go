@@ -0,0 +1,223 @@
+package ovn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/logger"
+
+	"github.com/canonical/microovn/microovn/api/types"
+)
+
+const healthServerCheckInterval = 10 * time.Second // Interval between checks of ConfigKeyHealthAddress changes
+const healthRequestTimeout = 5 * time.Second       // Maximum time to handle single health check request
+
+// healthServerOnce ensures that only one health server monitor is running.
+var healthServerOnce sync.Once
+
+// startHealthServer starts background goroutine that serves HTTP health checks of the local OVN databases on
+// the address configured in ConfigKeyHealthAddress. Health checks are meant for external load balancers that
+// front NB and SB databases of multiple central members. Configuration is checked periodically, so changes
+// take effect without restart. Nothing is served while the address is not configured.
+//
+// Following paths are served:
+//   - /nb: OVN NB database of this member is healthy
+//   - /sb: OVN SB database of this member is healthy
+//   - /: both databases are healthy
+//
+// Response status is 200 if the checked databases are healthy and 503 otherwise. See HealthCheck.
+func startHealthServer(s *state.State) {
+	healthServerOnce.Do(func() {
+		go func() {
+			var server *http.Server
+			address := ""
+
+			for {
+				newAddress, err := GetConfig(s, ConfigKeyHealthAddress)
+				if err != nil {
+					logger.Warnf("Failed to get health check address: %s", err)
+					newAddress = address
+				}
+
+				if newAddress != address {
+					stopHealthServer(server)
+					server = nil
+					address = newAddress
+
+					if address != "" {
+						server, err = serveHealth(s, address)
+						if err != nil {
+							logger.Errorf("Failed to start health check server: %s", err)
+							address = ""
+						}
+					}
+				}
+
+				select {
+				case <-s.Context.Done():
+					stopHealthServer(server)
+					return
+				case <-time.After(healthServerCheckInterval):
+				}
+			}
+		}()
+	})
+}
+
+// serveHealth starts HTTP server that handles health checks on "address".
+func serveHealth(s *state.State, address string) (*http.Server, error) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/nb", healthHandler(s, "nb"))
+	mux.HandleFunc("/sb", healthHandler(s, "sb"))
+	mux.HandleFunc("/", healthHandler(s, "nb", "sb"))
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on '%s': %w", address, err)
+	}
+
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: healthRequestTimeout}
+	go func() {
+		err := server.Serve(listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Errorf("Health check server on '%s' failed: %s", address, err)
+		}
+	}()
+
+	logger.Infof("Serving health checks on '%s'", address)
+	return server, nil
+}
+
+// stopHealthServer shuts down health check "server", if it's running.
+func stopHealthServer(server *http.Server) {
+	if server == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), healthRequestTimeout)
+	defer cancel()
+
+	err := server.Shutdown(ctx)
+	if err != nil {
+		logger.Warnf("Failed to stop health check server: %s", err)
+	}
+}
+
+// healthHandler returns HTTP handler that responds with status 200 if all local "databases" are healthy and
+// with status 503 otherwise. Body of the response is a types.ReportKindHealth report of the checked
+// databases.
+func healthHandler(s *state.State, databases ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" && r.URL.Path != "/nb" && r.URL.Path != "/sb" {
+			http.NotFound(w, r)
+			return
+		}
+
+		health, err := HealthCheck(s)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+
+		checked := types.HealthStatus{Healthy: true, Databases: []types.DatabaseHealth{}}
+		for _, database := range health.Databases {
+			for _, name := range databases {
+				if database.Database == name {
+					checked.Databases = append(checked.Databases, database)
+					checked.Healthy = checked.Healthy && database.Healthy
+				}
+			}
+		}
+
+		body, err := types.MarshalReport(types.ReportKindHealth, checked)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if checked.Healthy {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+
+		_, _ = w.Write(body)
+	}
+}
+
+// HealthCheck reports whether local OVN NB and SB databases are able to serve clients. Databases are healthy
+// only if this member runs the "central" service and they are joined to a cluster with quorum, see
+// databaseInQuorum. Cluster with fewer central members than declared by ConfigKeyExpectedCentrals is reported
+// as under-provisioned, which on its own doesn't make the member unhealthy.
+func HealthCheck(s *state.State) (*types.HealthStatus, error) {
+	snapshot, err := loadServicesSnapshot(s)
+	if err != nil {
+		return nil, err
+	}
+
+	centralActive := snapshot.LocalServiceActive("central")
+
+	health := types.HealthStatus{Healthy: true, Databases: []types.DatabaseHealth{}}
+	for _, database := range []struct {
+		name   string
+		dbType OvsdbType
+	}{
+		{name: "nb", dbType: OvsdbTypeNBLocal},
+		{name: "sb", dbType: OvsdbTypeSBLocal},
+	} {
+		dbHealth := types.DatabaseHealth{Database: database.name}
+		if !centralActive {
+			dbHealth.Reason = "member does not run the central service"
+		} else {
+			inQuorum, err := databaseInQuorum(s, database.dbType)
+			if err != nil {
+				dbHealth.Reason = err.Error()
+			} else if !inQuorum {
+				dbHealth.Reason = "database is not connected to a cluster with quorum"
+			}
+
+			dbHealth.Healthy = err == nil && inQuorum
+		}
+
+		health.Healthy = health.Healthy && dbHealth.Healthy
+		health.Databases = append(health.Databases, dbHealth)
+	}
+
+	health.CentralMembers = len(snapshot.ServiceMembers("central"))
+	health.ExpectedCentrals, err = expectedCentralCount(s)
+	if err != nil {
+		return nil, err
+	}
+
+	health.UnderProvisioned = health.CentralMembers < health.ExpectedCentrals
+
+	return &health, nil
+}
+
+// databaseInQuorum returns true if local database server of "dbType" is joined to its raft cluster and the
+// cluster has a leader, i.e. quorum of its servers is reachable. This function must be executed on a member
+// that runs the "central" service.
+func databaseInQuorum(s *state.State, dbType OvsdbType) (bool, error) {
+	dbState, err := DBState(s, dbType)
+	if err != nil {
+		return false, err
+	}
+
+	if dbState != DBStateJoined {
+		return false, nil
+	}
+
+	status, err := getClusterStatus(s, dbType)
+	if err != nil {
+		return false, err
+	}
+
+	return status.HasLeader(), nil
+}
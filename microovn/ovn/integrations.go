@@ -0,0 +1,44 @@
+package ovn
+
+import (
+	"fmt"
+
+	"github.com/canonical/microcluster/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+)
+
+// lxdClientCertCN is a CN used in client certificates issued for LXD.
+const lxdClientCertCN = "lxd"
+
+// LXDConnectionInfo returns information required by LXD to connect to OVN Northbound database
+// managed by MicroOVN. If "withClientCert" is true, new client certificate is issued and included
+// in the response.
+func LXDConnectionInfo(s *state.State, withClientCert bool) (*types.LXDConnectionInfo, error) {
+	nbConnect, err := connectString(s, 6641)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get OVN NB connect string: %w", err)
+	}
+
+	info := types.LXDConnectionInfo{NorthboundConnection: nbConnect}
+	if networkProtocol(s) != "ssl" {
+		return &info, nil
+	}
+
+	info.CACert, err = GetCACertPEM(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if withClientCert {
+		cert, key, err := IssueClientCert(s, lxdClientCertCN)
+		if err != nil {
+			return nil, fmt.Errorf("failed to issue client certificate for LXD: %w", err)
+		}
+
+		info.ClientCert = string(cert)
+		info.ClientKey = string(key)
+	}
+
+	return &info, nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/logger"
 	"github.com/canonical/microovn/microovn/database"
 )
 
@@ -39,6 +40,14 @@ func Join(s *state.State) error {
 		return err
 	}
 
+	// Refuse to join with database files from a different OVN cluster.
+	if srvCentral < 3 {
+		err = verifyClusterMembership(s)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Record the new roles in the database.
 	err = s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
 		// Record the roles.
@@ -65,8 +74,13 @@ func Join(s *state.State) error {
 		return err
 	}
 
+	snapshot, err := loadServicesSnapshot(s)
+	if err != nil {
+		return err
+	}
+
 	// Generate the configuration.
-	err = generateEnvironment(s)
+	err = generateEnvironment(s, snapshot)
 	if err != nil {
 		return fmt.Errorf("Failed to generate the daemon configuration: %w", err)
 	}
@@ -112,6 +126,8 @@ func Join(s *state.State) error {
 		if err != nil {
 			return fmt.Errorf("Failed to start OVN central: %w", err)
 		}
+
+		recordClusterIDsOnStart(s)
 	}
 
 	// Generate certificate for OVN chassis (controller)
@@ -125,23 +141,31 @@ func Join(s *state.State) error {
 	}
 
 	// Enable OVN chassis.
-	sbConnect, err := connectString(s, 6642)
-	if err != nil {
-		return fmt.Errorf("Failed to get OVN SB connect string: %w", err)
-	}
+	sbConnect := snapshot.ConnectString(networkProtocol(s), 6642)
 
 	_, err = VSCtl(
 		s,
 		"set", "open_vswitch", ".",
-		fmt.Sprintf("external_ids:system-id=%s", s.Name()),
 		fmt.Sprintf("external_ids:ovn-remote=%s", sbConnect),
 		"external_ids:ovn-encap-type=geneve",
-		fmt.Sprintf("external_ids:ovn-encap-ip=%s", s.Address().Hostname()),
 	)
 
 	if err != nil {
 		return fmt.Errorf("Error configuring OVS parameters: %s", err)
 	}
 
+	err = updateChassisConfig(s)
+	if err != nil {
+		return fmt.Errorf("Failed to apply OVN chassis configuration: %w", err)
+	}
+
+	// Gateway records may already exist for this chassis, e.g. when it rejoins the cluster.
+	err = applyGatewayPriority(s)
+	if err != nil {
+		logger.Warnf("Failed to apply gateway priority: %s", err)
+	}
+
+	startMonitors(s)
+
 	return nil
 }
@@ -0,0 +1,137 @@
+package ovn
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/api"
+
+	"github.com/canonical/microovn/microovn/database"
+)
+
+// labelRecordPrefix is a prefix of keys under which member labels are stored in the config DB table. Full key
+// has format "<prefix><label_key>@<member_name>".
+const labelRecordPrefix = "label."
+
+// labelKey is a pattern that keys of member labels must match.
+var labelKey = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]{0,62}$`)
+
+// labelRecordName returns key of the config DB record that stores label "key" of the "member".
+func labelRecordName(member string, key string) string {
+	return fmt.Sprintf("%s%s@%s", labelRecordPrefix, key, member)
+}
+
+// GetMemberLabels returns all labels attached to the "member".
+func GetMemberLabels(s *state.State, member string) (map[string]string, error) {
+	labels, err := getAllMemberLabels(s)
+	if err != nil {
+		return nil, err
+	}
+
+	memberLabels, ok := labels[member]
+	if !ok {
+		return map[string]string{}, nil
+	}
+
+	return memberLabels, nil
+}
+
+// getAllMemberLabels returns labels of every member, keyed by member names.
+func getAllMemberLabels(s *state.State) (map[string]map[string]string, error) {
+	labels := map[string]map[string]string{}
+	err := s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		items, err := database.GetConfigItems(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			if !strings.HasPrefix(item.Key, labelRecordPrefix) {
+				continue
+			}
+
+			key, member, found := strings.Cut(strings.TrimPrefix(item.Key, labelRecordPrefix), "@")
+			if !found {
+				continue
+			}
+
+			if labels[member] == nil {
+				labels[member] = map[string]string{}
+			}
+
+			labels[member][key] = item.Value
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get member labels: %w", err)
+	}
+
+	return labels, nil
+}
+
+// SetMemberLabel attaches label "key" with "value" to the "member". Empty value removes the label.
+func SetMemberLabel(s *state.State, member string, key string, value string) error {
+	if !labelKey.MatchString(key) {
+		return api.StatusErrorf(http.StatusBadRequest, "invalid label key '%s': expected up to 63 alphanumeric characters, '_', '.' or '-'", key)
+	}
+
+	_, ok := s.Remotes().RemotesByName()[member]
+	if !ok {
+		return api.StatusErrorf(http.StatusNotFound, "cluster member '%s' not found", member)
+	}
+
+	recordKey := labelRecordName(member, key)
+	return s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		exists, err := database.ConfigItemExists(ctx, tx, recordKey)
+		if err != nil {
+			return err
+		}
+
+		if value == "" {
+			if !exists {
+				return nil
+			}
+
+			return database.DeleteConfigItem(ctx, tx, recordKey)
+		}
+
+		if exists {
+			return database.UpdateConfigItem(ctx, tx, recordKey, database.ConfigItem{Key: recordKey, Value: value})
+		}
+
+		_, err = database.CreateConfigItem(ctx, tx, database.ConfigItem{Key: recordKey, Value: value})
+		return err
+	})
+}
+
+// deleteMemberLabels removes all labels attached to the "member". It's used when the member leaves the
+// cluster, so that a new member with the same name doesn't inherit them.
+func deleteMemberLabels(s *state.State, member string) error {
+	labels, err := GetMemberLabels(s, member)
+	if err != nil {
+		return err
+	}
+
+	err = s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		for key := range labels {
+			err := database.DeleteConfigItem(ctx, tx, labelRecordName(member, key))
+			if err != nil && !api.StatusErrorCheck(err, http.StatusNotFound) {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete labels of member '%s': %w", member, err)
+	}
+
+	return nil
+}
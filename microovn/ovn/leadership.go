@@ -0,0 +1,208 @@
+package ovn
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/logger"
+)
+
+const leadershipCheckInterval = time.Minute        // Interval between checks of OVN database leaders
+const leadershipTransferChecks = 3                 // Consecutive checks that must see a non-preferred leader before transfer
+const leadershipTransferHoldoff = 10 * time.Minute // Minimum time between leadership transfers of the same database
+const healthyServerContact = 5 * time.Second       // Follower that contacted the leader within this time is healthy
+
+// leadershipMonitorOnce ensures that only one leadership monitor is running.
+var leadershipMonitorOnce sync.Once
+
+// leadershipState tracks, per database, state used to avoid flapping of leadership transfers.
+type leadershipState struct {
+	misplaced    int       // Number of consecutive checks that found leader on a non-preferred member
+	lastTransfer time.Time // Time of the last leadership transfer
+}
+
+// startLeadershipMonitor starts background goroutine that periodically moves leadership of local OVN NB and
+// SB databases away from this member, if it is not among the members preferred by ConfigKeyPreferredLeaders.
+func startLeadershipMonitor(s *state.State) {
+	leadershipMonitorOnce.Do(func() {
+		go func() {
+			ticker := time.NewTicker(leadershipCheckInterval)
+			defer ticker.Stop()
+
+			states := map[OvsdbType]*leadershipState{
+				OvsdbTypeNBLocal: {},
+				OvsdbTypeSBLocal: {},
+			}
+
+			for {
+				select {
+				case <-s.Context.Done():
+					return
+				case <-ticker.C:
+				}
+
+				for dbType, dbState := range states {
+					err := reconcileLeadership(s, dbType, dbState)
+					if err != nil {
+						logger.Warnf("Failed to reconcile leadership of OVN database: %s", err)
+					}
+				}
+			}
+		}()
+	})
+}
+
+// preferredLeaders returns names of members configured in ConfigKeyPreferredLeaders.
+func preferredLeaders(s *state.State) ([]string, error) {
+	value, err := GetConfig(s, ConfigKeyPreferredLeaders)
+	if err != nil {
+		return nil, err
+	}
+
+	members := []string{}
+	for _, member := range strings.Split(value, ",") {
+		member = strings.TrimSpace(member)
+		if member != "" {
+			members = append(members, member)
+		}
+	}
+
+	return members, nil
+}
+
+// reconcileLeadership transfers leadership of the local database "dbType" if this member is its leader, it is
+// not a preferred leader and at least one preferred member is a healthy follower. Transfer is requested only
+// after the leader was found on a non-preferred member in leadershipTransferChecks consecutive checks and no
+// sooner than leadershipTransferHoldoff after the previous transfer. Leadership is never transferred if
+// healthy servers don't form a majority of the cluster, as the new leader could not be elected.
+//
+// OVSDB does not allow choosing the new leader, it is handed to the most up-to-date follower. If that is
+// not a preferred member, its own monitor transfers the leadership again after the holdoff.
+func reconcileLeadership(s *state.State, dbType OvsdbType, dbState *leadershipState) error {
+	preferred, err := preferredLeaders(s)
+	if err != nil || len(preferred) == 0 {
+		dbState.misplaced = 0
+		return err
+	}
+
+	if skipInMaintenance(s, "leadership transfer") {
+		return nil
+	}
+
+	centralActive, err := localServiceActive(s, "central")
+	if err != nil || !centralActive {
+		return err
+	}
+
+	status, err := getClusterStatus(s, dbType)
+	if err != nil {
+		return err
+	}
+
+	if !status.IsLeader() || isPreferredLeader(s.Name(), preferred) {
+		dbState.misplaced = 0
+		return nil
+	}
+
+	addresses, err := memberServiceAddresses(s)
+	if err != nil {
+		return err
+	}
+
+	preferredAddresses := map[string]bool{}
+	for _, member := range preferred {
+		addr, ok := addresses[member]
+		if ok {
+			preferredAddresses[raftAddress(addr, dbType)] = true
+		}
+	}
+
+	healthy := 0
+	preferredHealthy := false
+	for _, server := range status.Servers {
+		if !server.Self && (server.LastContact < 0 || server.LastContact > healthyServerContact) {
+			continue
+		}
+
+		healthy++
+		if !server.Self && preferredAddresses[server.Address] {
+			preferredHealthy = true
+		}
+	}
+
+	if !preferredHealthy || healthy <= len(status.Servers)/2 {
+		dbState.misplaced = 0
+		return nil
+	}
+
+	dbState.misplaced++
+	if dbState.misplaced < leadershipTransferChecks || time.Since(dbState.lastTransfer) < leadershipTransferHoldoff {
+		return nil
+	}
+
+	ctlSock, err := ovsdbControlSock(dbType)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Transferring leadership of %s database away from non-preferred member '%s'", status.Name, s.Name())
+	_, err = AppCtl(s, ctlSock, "cluster/failure-test", "transfer-leadership")
+	if err != nil {
+		return fmt.Errorf("failed to transfer leadership of %s database: %w", status.Name, err)
+	}
+
+	dbState.misplaced = 0
+	dbState.lastTransfer = time.Now()
+
+	return nil
+}
+
+// isPreferredLeader returns true if "member" is listed in "preferred".
+func isPreferredLeader(member string, preferred []string) bool {
+	for _, name := range preferred {
+		if name == member {
+			return true
+		}
+	}
+
+	return false
+}
+
+// leaderMember returns name of the member that is the leader of the database "dbType", as reported by the
+// local database server. Empty string is returned if the leader is not known.
+func leaderMember(s *state.State, dbType OvsdbType) (string, error) {
+	status, err := getClusterStatus(s, dbType)
+	if err != nil {
+		return "", err
+	}
+
+	if status.IsLeader() {
+		return s.Name(), nil
+	}
+
+	if !status.HasLeader() {
+		return "", nil
+	}
+
+	addresses, err := memberServiceAddresses(s)
+	if err != nil {
+		return "", err
+	}
+
+	for _, server := range status.Servers {
+		if server.ID != status.Leader {
+			continue
+		}
+
+		for name, addr := range addresses {
+			if raftAddress(addr, dbType) == server.Address {
+				return name, nil
+			}
+		}
+	}
+
+	return "", nil
+}
@@ -1,7 +1,17 @@
 package ovn
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared"
+	"github.com/lxc/lxd/shared/api"
 	"github.com/lxc/lxd/shared/logger"
 
 	"github.com/canonical/microovn/microovn/ovn/paths"
@@ -9,45 +19,226 @@ import (
 
 // Leave function gracefully departs from the OVN cluster before the member is removed from MicroOVN
 // cluster. It ensures that:
-//   - OVN chassis is stopped and removed from SB database
+//   - OVN chassis is stopped and removed from SB database, waiting up to ConfigKeyLeaveGrace for the removal
 //   - OVN NB cluster is cleanly departed
 //   - OVN SB cluster is cleanly departed
+//   - labels attached to the member are deleted
+//
+// Any failures encountered during the departure are logged as warnings, but they do not prevent
+// removal of the member. See LeaveStrict for variant that reports these failures to the caller.
+//
+// If the member can't reach the NB or SB raft cluster, behavior follows ConfigKeyLeavePolicy, see
+// checkLeavePolicy. Refusal to leave under LeavePolicyAbort is the only failure that is returned.
+func Leave(s *state.State) error {
+	err := checkLeavePolicy(s)
+	if err != nil {
+		return err
+	}
+
+	err = leave(s)
+	if err != nil {
+		logger.Warnf("Member left OVN cluster with warnings: %s", err)
+	}
+
+	return nil
+}
+
+// LeaveStrict function performs the same steps as Leave, but instead of only logging failures, it
+// returns all of them joined in a single error. This allows callers to surface that the member
+// left the cluster, but some of the steps did not complete cleanly.
+func LeaveStrict(s *state.State) error {
+	err := checkLeavePolicy(s)
+	if err != nil {
+		return err
+	}
+
+	return leave(s)
+}
+
+// leave implements the departure process shared by Leave and LeaveStrict. Services are shut down in the
+// order configured by ConfigKeyLeaveOrder. Every step is attempted regardless of failures in the previous
+// ones and all encountered errors are returned joined together.
 //
 // Note (mkalcok): At this point, database table `services` no longer contains entries
 // for departing cluster member, so we'll try to exit/leave/stop all possible services
 // ignoring any errors from services that are not actually running.
-func Leave(s *state.State) error {
-	var err error
-	chassisName := s.Name()
+func leave(s *state.State) error {
+	var errs []error
+
+	order, err := leaveOrder(s)
+	if err != nil {
+		logger.Warnf("Failed to get shutdown order, using '%s': %s", defaultLeaveOrder, err)
+		order = strings.Split(defaultLeaveOrder, ",")
+	}
+
+	steps := map[string]func(s *state.State) []error{
+		"chassis": leaveChassis,
+		"switch":  leaveSwitch,
+		"central": leaveCentral,
+	}
+
+	for _, service := range order {
+		errs = append(errs, steps[service](s)...)
+	}
+
+	err = deleteMemberLabels(s, s.Name())
+	if err != nil {
+		logger.Warn(err.Error())
+		errs = append(errs, err)
+	}
+
+	logger.Info("Cleaning up runtime and data directories.")
+	err = cleanupPaths(backupRoot(s))
+	if err != nil {
+		logger.Warn(err.Error())
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// defaultLeaveOrder is the order in which services are shut down by a leaving member, unless configured
+// otherwise by ConfigKeyLeaveOrder.
+const defaultLeaveOrder = "chassis,switch,central"
+
+// leaveOrder returns order in which services are shut down by a leaving member, as configured by
+// ConfigKeyLeaveOrder.
+func leaveOrder(s *state.State) ([]string, error) {
+	value, err := GetConfig(s, ConfigKeyLeaveOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	if value == "" {
+		value = defaultLeaveOrder
+	}
+
+	order := []string{}
+	for _, service := range strings.Split(value, ",") {
+		order = append(order, strings.TrimSpace(service))
+	}
+
+	return order, nil
+}
+
+// leaveChassis gracefully exits OVN controller, which removes the chassis from OVN SB database, waits for
+// the removal to complete and stops the "chassis" service.
+func leaveChassis(s *state.State) []error {
+	var errs []error
+
+	// Chassis record is looked up by system-id, which is removed along with the member's data.
+	systemID, err := getSystemID(s)
+	if err != nil {
+		logger.Warnf("Failed to get chassis system-id: %s", err)
+	}
 
 	// Gracefully exit OVN controller causing chassis to be automatically removed.
-	logger.Infof("Stopping OVN Controller and removing Chassis '%s' from OVN SB database.", chassisName)
+	logger.Infof("Stopping OVN Controller and removing Chassis '%s' from OVN SB database.", s.Name())
 	_, err = ControllerCtl(s, "exit")
 	if err != nil {
 		logger.Warnf("Failed to gracefully stop OVN Controller: %s", err)
+		errs = append(errs, fmt.Errorf("failed to gracefully stop OVN Controller: %w", err))
+	} else if systemID != "" {
+		err = waitForChassisRemoval(s, systemID)
+		if err != nil {
+			logger.Warnf("Chassis '%s' may be left in OVN SB database: %s", systemID, err)
+			errs = append(errs, err)
+		}
+	}
+
+	err = stopLeavingService("chassis")
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+// defaultLeaveGrace is the time (s) that leaving member waits for its chassis to be removed from OVN SB
+// database, unless configured otherwise by ConfigKeyLeaveGrace.
+const defaultLeaveGrace = 10
+
+// waitForChassisRemoval polls OVN SB database, via servers of the remaining central members, until chassis
+// "systemID" disappears from it. Waiting is limited by ConfigKeyLeaveGrace, zero disables it. Nothing is
+// waited for if no reachable member runs the "central" service.
+func waitForChassisRemoval(s *state.State, systemID string) error {
+	grace, err := getConfigInt(s, ConfigKeyLeaveGrace, defaultLeaveGrace)
+	if err != nil {
+		return err
 	}
 
-	err = snapStop("chassis", true)
+	if grace <= 0 {
+		return nil
+	}
+
+	sbConnect, err := connectString(s, 6642)
 	if err != nil {
-		logger.Warnf("Failed to stop Chassis service: %s", err)
+		return fmt.Errorf("failed to get OVN SB connect string: %w", err)
+	}
+
+	if sbConnect == "" {
+		return nil
+	}
+
+	args := []string{"--timeout", strconv.Itoa(handshakeTimeout), fmt.Sprintf("--db=%s", sbConnect)}
+	if networkProtocol(s) == "ssl" {
+		certPath, keyPath := paths.PkiClientCertFiles()
+		args = append(args, "-p", keyPath, "-c", certPath, "-C", paths.PkiCaCertFile())
+	}
+
+	args = append(args, "--bare", "--columns=name", "find", "Chassis", fmt.Sprintf("name=%s", strconv.Quote(systemID)))
+
+	deadline := time.Now().Add(time.Duration(grace) * time.Second)
+	for {
+		output, err := shared.RunCommandContext(s.Context, "ovn-sbctl", args...)
+		if err == nil && strings.TrimSpace(output) == "" {
+			logger.Infof("Chassis '%s' was removed from OVN SB database", systemID)
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			if err != nil {
+				return fmt.Errorf("failed to confirm removal of chassis '%s' within %ds: %w", systemID, grace, err)
+			}
+
+			return fmt.Errorf("chassis '%s' is still registered in OVN SB database after %ds", systemID, grace)
+		}
+
+		select {
+		case <-s.Context.Done():
+			return s.Context.Err()
+		case <-time.After(time.Second):
+		}
 	}
+}
 
-	err = snapStop("switch", true)
+// leaveSwitch stops the "switch" service.
+func leaveSwitch(_ *state.State) []error {
+	err := stopLeavingService("switch")
 	if err != nil {
-		logger.Warnf("Failed to stop Switch service: %s", err)
+		return []error{err}
 	}
 
+	return nil
+}
+
+// leaveCentral departs OVN NB and SB clusters and stops the "central" service.
+func leaveCentral(s *state.State) []error {
+	var errs []error
+
 	// Leave SB and NB clusters
 	logger.Info("Leaving OVN Northbound cluster")
-	_, err = AppCtl(s, paths.OvnNBControlSock(), "cluster/leave", "OVN_Northbound")
+	_, err := AppCtl(s, paths.OvnNBControlSock(), "cluster/leave", "OVN_Northbound")
 	if err != nil {
 		logger.Warnf("Failed to leave OVN Northbound cluster: %s", err)
+		errs = append(errs, fmt.Errorf("failed to leave OVN Northbound cluster: %w", err))
 	}
 
 	logger.Info("Leaving OVN Southbound cluster")
 	_, err = AppCtl(s, paths.OvnSBControlSock(), "cluster/leave", "OVN_Southbound")
 	if err != nil {
 		logger.Warnf("Failed to leave OVN Southbound cluster: %s", err)
+		errs = append(errs, fmt.Errorf("failed to leave OVN Southbound cluster: %w", err))
 	}
 
 	// Wait for NB and SB cluster members to complete departure process
@@ -56,9 +247,11 @@ func Leave(s *state.State) error {
 		err = waitForDBState(s, nbDatabase, OvsdbRemoved, defaultDBConnectWait)
 		if err != nil {
 			logger.Warnf("Failed to wait for NB cluster departure: %s", err)
+			errs = append(errs, fmt.Errorf("failed to wait for NB cluster departure: %w", err))
 		}
 	} else {
 		logger.Warnf("Failed to get NB database specification: %s", err)
+		errs = append(errs, fmt.Errorf("failed to get NB database specification: %w", err))
 	}
 
 	sbDatabase, err := newOvsdbSpec(OvsdbTypeSBLocal)
@@ -66,21 +259,93 @@ func Leave(s *state.State) error {
 		err = waitForDBState(s, sbDatabase, OvsdbRemoved, defaultDBConnectWait)
 		if err != nil {
 			logger.Warnf("Failed to wait for SB cluster departure: %s", err)
+			errs = append(errs, fmt.Errorf("failed to wait for SB cluster departure: %w", err))
 		}
 	} else {
 		logger.Warnf("Failed to get SB database specification: %s", err)
+		errs = append(errs, fmt.Errorf("failed to get SB database specification: %w", err))
 	}
 
-	err = snapStop("central", true)
+	err = stopLeavingService("central")
 	if err != nil {
-		logger.Warnf("Failed to stop Central service: %s", err)
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+// stopLeavingService stops and disables snap "service" of the leaving member. Service that doesn't stop
+// within snapStopTimeout is killed, so that it can't block the departure.
+func stopLeavingService(service string) error {
+	result, err := snapStopWithTimeout(service, true, snapStopTimeout, true)
+	if len(result.Killed) > 0 {
+		logger.Warnf("Service %s did not stop in time, killed processes %v", service, result.Killed)
 	}
 
-	logger.Info("Cleaning up runtime and data directories.")
-	err = cleanupPaths()
 	if err != nil {
-		logger.Warn(err.Error())
+		logger.Warnf("Failed to stop %s service: %s (output: %q)", service, err, result.Output)
+		return fmt.Errorf("failed to stop %s service: %w", service, err)
 	}
 
 	return nil
 }
+
+// Policies, configured by ConfigKeyLeavePolicy, applied when the leaving member can't reach its OVN
+// NB or SB raft cluster.
+const (
+	LeavePolicyProceed = "proceed" // Leave after the departure times out, stale raft server is left behind
+	LeavePolicyAbort   = "abort"   // Refuse to leave until connectivity is restored
+)
+
+// checkLeavePolicy detects whether local OVN NB or SB database servers lost connection to their raft
+// clusters, e.g. due to a network partition. In such case, "cluster/leave" can't complete and waiting for
+// the departure only times out. Depending on ConfigKeyLeavePolicy, the member either proceeds with leaving,
+// leaving its stale server in the raft clusters to be removed later (with "cluster/kick") from one of the
+// remaining members, or an error asking to restore the connectivity first is returned. Chosen path is logged.
+func checkLeavePolicy(s *state.State) error {
+	unreachable := []string{}
+	for _, dbType := range []OvsdbType{OvsdbTypeNBLocal, OvsdbTypeSBLocal} {
+		dbSpec, err := newOvsdbSpec(dbType)
+		if err != nil {
+			return err
+		}
+
+		// Database is not running locally, there's no raft cluster to leave.
+		_, err = os.Stat(dbSpec.Target)
+		if err != nil {
+			continue
+		}
+
+		status, err := getClusterStatus(s, dbType)
+		if err != nil || !status.HasLeader() {
+			unreachable = append(unreachable, dbSpec.Name)
+		}
+	}
+
+	if len(unreachable) == 0 {
+		return nil
+	}
+
+	policy, err := GetConfig(s, ConfigKeyLeavePolicy)
+	if err != nil {
+		logger.Warnf("Failed to get leave policy, using '%s': %s", LeavePolicyProceed, err)
+		policy = LeavePolicyProceed
+	}
+
+	if policy == LeavePolicyAbort {
+		logger.Errorf("Refusing to leave, %s cluster is unreachable (leave policy '%s')", strings.Join(unreachable, ", "), policy)
+		return api.StatusErrorf(
+			http.StatusServiceUnavailable,
+			"local %s database can't reach its cluster. Restore connectivity to the rest of the cluster and try again",
+			strings.Join(unreachable, ", "),
+		)
+	}
+
+	logger.Warnf(
+		"%s cluster is unreachable, leaving anyway (leave policy '%s'). Departure will time out and stale server has to be removed with 'cluster/kick' from one of the remaining central members",
+		strings.Join(unreachable, ", "),
+		LeavePolicyProceed,
+	)
+
+	return nil
+}
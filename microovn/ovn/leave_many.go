@@ -0,0 +1,144 @@
+package ovn
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/api"
+	"github.com/lxc/lxd/shared/logger"
+
+	"github.com/canonical/microovn/microovn/database"
+)
+
+// leavePlan describes order in which members are removed from the cluster by LeaveMany.
+type leavePlan struct {
+	Parallel   []string // Members without "central" service, they can be removed concurrently
+	Sequential []string // Members with "central" service, they are removed one by one
+}
+
+// planLeave computes safe order for removal of "members" from the cluster. Members that run only chassis
+// services don't affect OVN raft clusters and can be removed concurrently. Members that run "central"
+// service are removed one at a time, so that each of them can gracefully leave OVN NB/SB clusters
+// while the rest of the cluster keeps quorum. Removal is refused if it would leave no "central" service
+// in the cluster, or if any of the members is not part of the cluster. Local member, if requested to be
+// removed, is always removed last, because it coordinates the removal of the others.
+func planLeave(s *state.State, members []string) (*leavePlan, error) {
+	remotes := s.Remotes().RemotesByName()
+	centrals := map[string]bool{}
+	err := s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		serviceName := "central"
+		services, err := database.GetServices(ctx, tx, database.ServiceFilter{Service: &serviceName})
+		if err != nil {
+			return err
+		}
+
+		for _, srv := range services {
+			centrals[srv.Member] = true
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query central services: %w", err)
+	}
+
+	plan := leavePlan{}
+	removeSelf := false
+	removedCentrals := 0
+	seen := map[string]bool{}
+	for _, member := range members {
+		if seen[member] {
+			continue
+		}
+		seen[member] = true
+
+		_, ok := remotes[member]
+		if !ok {
+			return nil, api.StatusErrorf(http.StatusNotFound, "member '%s' is not part of the cluster", member)
+		}
+
+		if centrals[member] {
+			removedCentrals++
+		}
+
+		if member == s.Name() {
+			removeSelf = true
+			continue
+		}
+
+		if centrals[member] {
+			plan.Sequential = append(plan.Sequential, member)
+		} else {
+			plan.Parallel = append(plan.Parallel, member)
+		}
+	}
+
+	if len(centrals) > 0 && removedCentrals >= len(centrals) && len(seen) < len(remotes) {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "removal of all members with 'central' service would leave remaining members without OVN databases")
+	}
+
+	if removeSelf {
+		plan.Sequential = append(plan.Sequential, s.Name())
+	}
+
+	return &plan, nil
+}
+
+// LeaveMany removes multiple members from the cluster in an order that preserves quorum of OVN NB/SB
+// clusters, as computed by planLeave. Members without "central" service are removed concurrently, members
+// with "central" service are then removed one by one. Failure to remove any member does not prevent
+// removal of the members without "central" service, but it stops sequential removal of the central
+// members, because the state of the OVN raft clusters is no longer known.
+func LeaveMany(s *state.State, members []string) error {
+	plan, err := planLeave(s, members)
+	if err != nil {
+		return err
+	}
+
+	leader, err := s.Leader()
+	if err != nil {
+		return fmt.Errorf("failed to get client for cluster leader: %w", err)
+	}
+
+	var errs []error
+	var errsMu sync.Mutex
+	var wg sync.WaitGroup
+	for _, member := range plan.Parallel {
+		wg.Add(1)
+		go func(member string) {
+			defer wg.Done()
+
+			logger.Infof("Removing member '%s' from the cluster", member)
+			err := leader.DeleteClusterMember(s.Context, member, false)
+			if err != nil {
+				errsMu.Lock()
+				errs = append(errs, fmt.Errorf("failed to remove member '%s': %w", member, err))
+				errsMu.Unlock()
+			}
+		}(member)
+	}
+	wg.Wait()
+
+	for _, member := range plan.Sequential {
+		// Removed member might have been the database leader, so the client needs to be refreshed.
+		leader, err = s.Leader()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get client for cluster leader: %w", err))
+			break
+		}
+
+		logger.Infof("Removing member '%s' from the cluster", member)
+		err = leader.DeleteClusterMember(s.Context, member, false)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove member '%s', stopping removal of central members: %w", member, err))
+			break
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,64 @@
+package ovn
+
+import (
+	"strings"
+
+	"github.com/canonical/microcluster/state"
+)
+
+// logicalFlowCounts returns number of logical flows in the OVN Southbound database for every datapath,
+// keyed by the name of the logical switch or router that the datapath implements. Datapaths without a name
+// are keyed by their UUID. Flows shared by a datapath group are counted towards every datapath in the group.
+//
+// This function must be executed on a member that runs the "central" service.
+func logicalFlowCounts(s *state.State) (map[string]int, error) {
+	datapathRows, err := listSBTable(s, "Datapath_Binding", "_uuid", "external_ids")
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(datapathRows))
+	names := make(map[string]string, len(datapathRows))
+	for _, row := range datapathRows {
+		names[row[0]] = row[0]
+		for _, pair := range strings.Fields(row[1]) {
+			key, value, found := strings.Cut(pair, "=")
+			if found && key == "name" {
+				names[row[0]] = value
+			}
+		}
+
+		counts[names[row[0]]] = 0
+	}
+
+	groupRows, err := listSBTable(s, "Logical_DP_Group", "_uuid", "datapaths")
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string][]string, len(groupRows))
+	for _, row := range groupRows {
+		groups[row[0]] = strings.Fields(row[1])
+	}
+
+	flowRows, err := listSBTable(s, "Logical_Flow", "logical_datapath", "logical_dp_group")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range flowRows {
+		datapaths := groups[row[1]]
+		if row[0] != "" {
+			datapaths = []string{row[0]}
+		}
+
+		for _, datapath := range datapaths {
+			name, ok := names[datapath]
+			if ok {
+				counts[name]++
+			}
+		}
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,79 @@
+package ovn
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/logger"
+)
+
+// localServiceOrder lists MicroOVN services in the order in which they need to be started. Services are
+// stopped in the reverse order. OVS switch needs to be running before OVN chassis connects to it and
+// OVN central databases should be available before chassis starts connecting to them.
+var localServiceOrder = []string{"switch", "central", "chassis"}
+
+// localServices returns services that are enabled on this member according to the services "snapshot", in
+// the order defined by localServiceOrder.
+func localServices(snapshot *servicesSnapshot) []string {
+	services := []string{}
+	for _, service := range localServiceOrder {
+		if snapshot.LocalServiceActive(service) {
+			services = append(services, service)
+		}
+	}
+
+	return services
+}
+
+// StopLocal stops all OVN services that are enabled on this member, for example to perform host maintenance.
+// Unlike Leave, the member remains part of the MicroOVN and OVN clusters and all its data is preserved.
+// Services are also disabled, so they are not started automatically if the host reboots before StartLocal
+// is called. Failure to stop any of the services does not prevent attempts to stop the others.
+func StopLocal(s *state.State) error {
+	muHook.Lock()
+	defer muHook.Unlock()
+
+	snapshot, err := loadServicesSnapshot(s)
+	if err != nil {
+		return err
+	}
+
+	services := localServices(snapshot)
+	var errs []error
+	for i := len(services) - 1; i >= 0; i-- {
+		logger.Infof("Stopping local service '%s'", services[i])
+		err = snapStop(services[i], true)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop %s service: %w", services[i], err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// StartLocal starts all OVN services that are enabled on this member, reverting the effect of StopLocal.
+// The ovn.env file is regenerated before the services are started, so they pick up any changes in the
+// cluster that happened while they were stopped. Services are started in the order of their dependencies,
+// see startLocalOrdered.
+func StartLocal(s *state.State) error {
+	muHook.Lock()
+	defer muHook.Unlock()
+
+	snapshot, err := loadServicesSnapshot(s)
+	if err != nil {
+		return err
+	}
+
+	err = createPaths()
+	if err != nil {
+		return err
+	}
+
+	err = generateEnvironment(s, snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to generate the daemon configuration: %w", err)
+	}
+
+	return startLocalOrdered(s, snapshot)
+}
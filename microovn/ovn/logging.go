@@ -0,0 +1,47 @@
+package ovn
+
+import (
+	"fmt"
+
+	"github.com/canonical/microcluster/state"
+)
+
+// Logging targets of OVN daemons, configured by ConfigKeyLogTarget.
+const (
+	LogTargetFile   = "file"   // Daemons log into files in paths.LogsDir
+	LogTargetSyslog = "syslog" // Daemons log to syslog, which is collected by journald
+)
+
+const defaultSyslogFacility = "daemon" // Syslog facility used when ConfigKeyLogFacility is not set
+
+// syslogFacilities lists syslog facilities accepted by OVN daemons.
+var syslogFacilities = map[string]bool{
+	"kern": true, "user": true, "mail": true, "daemon": true, "auth": true, "syslog": true, "lpr": true,
+	"news": true, "uucp": true, "clock": true, "ftp": true, "ntp": true, "audit": true, "alert": true,
+	"clock2": true, "local0": true, "local1": true, "local2": true, "local3": true, "local4": true,
+	"local5": true, "local6": true, "local7": true,
+}
+
+// ovnLogArgs returns logging options of OVN daemons that correspond to ConfigKeyLogTarget and
+// ConfigKeyLogFacility. Empty string is returned if daemons log into files, which is their default.
+func ovnLogArgs(s *state.State) (string, error) {
+	target, err := GetConfig(s, ConfigKeyLogTarget)
+	if err != nil {
+		return "", err
+	}
+
+	if target != LogTargetSyslog {
+		return "", nil
+	}
+
+	facility, err := GetConfig(s, ConfigKeyLogFacility)
+	if err != nil {
+		return "", err
+	}
+
+	if facility == "" {
+		facility = defaultSyslogFacility
+	}
+
+	return fmt.Sprintf("-vconsole:off -vfile:off -vsyslog:info -vFACILITY:%s", facility), nil
+}
@@ -0,0 +1,92 @@
+package ovn
+
+import (
+	"fmt"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/logger"
+)
+
+// maintenanceMode returns true if cluster-wide maintenance mode is enabled via ConfigKeyMaintenanceMode.
+// While in maintenance mode, MicroOVN does not automatically regenerate ovn.env or reconfigure OVN
+// services, so that it doesn't interfere with manual changes made by operators.
+func maintenanceMode(s *state.State) (bool, error) {
+	value, err := GetConfig(s, ConfigKeyMaintenanceMode)
+	if err != nil {
+		return false, err
+	}
+
+	return value == "true", nil
+}
+
+// skipInMaintenance returns true if automatic "action" should be skipped because the cluster is in
+// maintenance mode. Skipped action is logged. If the mode can't be determined, the action is not skipped.
+func skipInMaintenance(s *state.State, action string) bool {
+	inMaintenance, err := maintenanceMode(s)
+	if err != nil {
+		logger.Warnf("Failed to determine maintenance mode: %s", err)
+		return false
+	}
+
+	if inMaintenance {
+		logger.Infof("Cluster is in maintenance mode, skipping %s.", action)
+	}
+
+	return inMaintenance
+}
+
+// reconcile brings configuration of all local OVN services in line with the current cluster state. It's
+// used to catch up on changes that were not applied while the cluster was in maintenance mode, so it does
+// nothing if the maintenance mode is still enabled.
+func reconcile(s *state.State) error {
+	if skipInMaintenance(s, "reconciliation") {
+		return nil
+	}
+
+	muHook.Lock()
+	defer muHook.Unlock()
+
+	snapshot, err := loadServicesSnapshot(s)
+	if err != nil {
+		return err
+	}
+
+	err = generateEnvironment(s, snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to generate the daemon configuration: %w", err)
+	}
+
+	centralActive := snapshot.LocalServiceActive("central")
+	switchActive := snapshot.LocalServiceActive("switch")
+
+	if centralActive {
+		err = updateOvnListenConfig(s)
+		if err != nil {
+			return fmt.Errorf("failed to update OVN listening configs: %w", err)
+		}
+
+		err = applyGlobalOptions(s)
+		if err != nil {
+			return fmt.Errorf("failed to apply NB_Global options: %w", err)
+		}
+	}
+
+	if switchActive {
+		err = updateChassisConfig(s)
+		if err != nil {
+			return fmt.Errorf("failed to apply OVN chassis configuration: %w", err)
+		}
+
+		err = applyGatewayPriority(s)
+		if err != nil {
+			return fmt.Errorf("failed to apply gateway priority: %w", err)
+		}
+
+		err = updateSwitchConfig(s)
+		if err != nil {
+			return fmt.Errorf("failed to apply OVS database configuration: %w", err)
+		}
+	}
+
+	return nil
+}
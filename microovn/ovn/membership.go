@@ -0,0 +1,182 @@
+package ovn
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared"
+	"github.com/lxc/lxd/shared/api"
+	"github.com/lxc/lxd/shared/logger"
+
+	"github.com/canonical/microovn/microovn/database"
+	"github.com/canonical/microovn/microovn/ovn/paths"
+)
+
+// Keys under which IDs of OVN NB and SB raft clusters are stored in the config DB table.
+const nbClusterIDRecordName = "ovn.nb-cluster-id"
+const sbClusterIDRecordName = "ovn.sb-cluster-id"
+
+// clusterIDRecordName returns key under which ID of the raft cluster of database "dbType" is stored.
+func clusterIDRecordName(dbType OvsdbType) string {
+	if dbType == OvsdbTypeNBLocal {
+		return nbClusterIDRecordName
+	}
+
+	return sbClusterIDRecordName
+}
+
+// localClusterID returns ID of the raft cluster that local database file of clustered OVN database "dbType"
+// belongs to. Empty string is returned if the database file does not exist.
+func localClusterID(s *state.State, dbType OvsdbType) (string, error) {
+	dbFile, err := databaseFile(dbType)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = os.Stat(dbFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	output, err := shared.RunCommandContext(s.Context, "ovsdb-tool", "db-cid", dbFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read cluster ID from '%s': %w", dbFile, err)
+	}
+
+	return strings.TrimSpace(output), nil
+}
+
+// storedClusterID returns ID of the raft cluster of database "dbType" recorded in the shared database. Empty
+// string is returned if the ID was not recorded yet.
+func storedClusterID(s *state.State, dbType OvsdbType) (string, error) {
+	var clusterID string
+	err := s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		item, err := database.GetConfigItem(ctx, tx, clusterIDRecordName(dbType))
+		if err != nil {
+			if api.StatusErrorCheck(err, http.StatusNotFound) {
+				return nil
+			}
+
+			return err
+		}
+
+		clusterID = item.Value
+		return nil
+	})
+
+	return clusterID, err
+}
+
+// verifyClusterMembership checks that local OVN NB and SB database files, if they exist, belong to the same
+// raft clusters as the rest of the MicroOVN cluster. Database files left behind from a previous incarnation
+// of the cluster prevent the member from joining the current one, so an error describing the required
+// cleanup is returned in such case.
+func verifyClusterMembership(s *state.State) error {
+	for _, dbType := range []OvsdbType{OvsdbTypeNBLocal, OvsdbTypeSBLocal} {
+		expected, err := storedClusterID(s, dbType)
+		if err != nil {
+			return fmt.Errorf("failed to get expected cluster ID: %w", err)
+		}
+
+		local, err := localClusterID(s, dbType)
+		if err != nil {
+			return err
+		}
+
+		if expected == "" || local == "" || expected == local {
+			continue
+		}
+
+		dbFile, _ := databaseFile(dbType)
+		return api.StatusErrorf(
+			http.StatusConflict,
+			"local database '%s' belongs to raft cluster %s, but this MicroOVN cluster uses %s. It was likely left behind by a previous cluster membership. Move stale database files out of '%s' and try again",
+			dbFile,
+			local,
+			expected,
+			paths.CentralDBDir(),
+		)
+	}
+
+	return nil
+}
+
+// recordClusterIDs stores IDs of OVN NB and SB raft clusters in the shared database, if they are not
+// recorded yet. IDs are recorded only from databases that are connected to their cluster, to avoid
+// recording ID of a stale database. This function must be executed on a member that runs the "central"
+// service.
+func recordClusterIDs(s *state.State) error {
+	for _, dbType := range []OvsdbType{OvsdbTypeNBLocal, OvsdbTypeSBLocal} {
+		expected, err := storedClusterID(s, dbType)
+		if err != nil {
+			return err
+		}
+
+		if expected != "" {
+			continue
+		}
+
+		status, err := getClusterStatus(s, dbType)
+		if err != nil {
+			return err
+		}
+
+		if !status.HasLeader() {
+			continue
+		}
+
+		local, err := localClusterID(s, dbType)
+		if err != nil {
+			return err
+		}
+
+		if local == "" {
+			continue
+		}
+
+		err = s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+			_, err := database.CreateConfigItem(ctx, tx, database.ConfigItem{Key: clusterIDRecordName(dbType), Value: local})
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to record cluster ID: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// recordClusterIDsOnStart records IDs of OVN NB and SB raft clusters with recordClusterIDs once the local
+// databases connect to their clusters. It's used right after the "central" service starts on a new member,
+// when the databases are still being created, so the wait happens in background and failures are only
+// logged.
+func recordClusterIDsOnStart(s *state.State) {
+	go func() {
+		for _, dbType := range []OvsdbType{OvsdbTypeNBLocal, OvsdbTypeSBLocal} {
+			dbSpec, err := newOvsdbSpec(dbType)
+			if err == nil {
+				err = waitForDBState(s, dbSpec, OvsdbConnected, defaultDBConnectWait)
+			}
+
+			if err != nil {
+				logger.Warnf("Failed to record OVN cluster IDs: %s", err)
+				return
+			}
+		}
+
+		err := recordClusterIDs(s)
+		if err != nil {
+			logger.Warnf("Failed to record OVN cluster IDs: %s", err)
+		}
+	}()
+}
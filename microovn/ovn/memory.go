@@ -0,0 +1,214 @@
+package ovn
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/logger"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	microovnClient "github.com/canonical/microovn/microovn/client"
+	"github.com/canonical/microovn/microovn/ovn/paths"
+)
+
+// dbPidFile returns path to the pid file of ovsdb-server that serves local clustered OVN database "dbType".
+func dbPidFile(dbType OvsdbType) (string, error) {
+	switch dbType {
+	case OvsdbTypeNBLocal:
+		return filepath.Join(paths.CentralRuntimeDir(), "ovnnb_db.pid"), nil
+	case OvsdbTypeSBLocal:
+		return filepath.Join(paths.CentralRuntimeDir(), "ovnsb_db.pid"), nil
+	default:
+		return "", fmt.Errorf("unknown DB type. Memory usage is available only for NB or SB database")
+	}
+}
+
+// readMemInfoBytes returns value, in bytes, of the field "field" from the file "path" that uses the format
+// of /proc/meminfo or /proc/<pid>/status (e.g. "VmRSS:     1234 kB").
+func readMemInfoBytes(path string, field string) (int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != field+":" {
+			continue
+		}
+
+		value, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse %s in '%s': %w", field, path, err)
+		}
+
+		if len(fields) > 2 && fields[2] == "kB" {
+			value *= 1024
+		}
+
+		return value, nil
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return 0, err
+	}
+
+	return 0, fmt.Errorf("field %s not found in '%s'", field, path)
+}
+
+// totalMemory returns amount of RAM, in bytes, available on this host.
+func totalMemory() (int64, error) {
+	return readMemInfoBytes("/proc/meminfo", "MemTotal")
+}
+
+// checkMemoryLimit verifies that memory limit "value", in MiB, does not exceed amount of RAM on any central
+// member, where the limit applies. Local RAM is checked directly, other central members report it in their
+// status.
+func checkMemoryLimit(s *state.State, value string) error {
+	limit, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	centrals, err := centralMembers(s)
+	if err != nil {
+		return fmt.Errorf("failed to query central services: %w", err)
+	}
+
+	leader, err := s.Leader()
+	if err != nil {
+		return err
+	}
+
+	for _, member := range centrals {
+		var total int64
+		if member == s.Name() {
+			total, err = totalMemory()
+		} else {
+			var status types.MemberStatus
+			status, err = microovnClient.GetStatus(s.Context, leader.UseTarget(member))
+			if err == nil {
+				total = status.Central.MemoryTotal
+			}
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to determine available memory on member '%s': %w", member, err)
+		}
+
+		// Members running older versions don't report their memory.
+		if total == 0 {
+			continue
+		}
+
+		if limit*bytesInMiB > total {
+			return fmt.Errorf("memory limit %d MiB exceeds available memory on member '%s' (%d MiB)", limit, member, total/bytesInMiB)
+		}
+	}
+
+	return nil
+}
+
+// databaseRSS returns resident set size, in bytes, of ovsdb-server process that serves local clustered OVN
+// database "dbType".
+func databaseRSS(dbType OvsdbType) (int64, error) {
+	pidFile, err := dbPidFile(dbType)
+	if err != nil {
+		return 0, err
+	}
+
+	content, err := os.ReadFile(pidFile)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read pid file '%s': %w", pidFile, err)
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(content)))
+	if err != nil {
+		return 0, fmt.Errorf("invalid pid in '%s': %w", pidFile, err)
+	}
+
+	return readMemInfoBytes(fmt.Sprintf("/proc/%d/status", pid), "VmRSS")
+}
+
+// applyDBMemoryTrim enables release of freed memory back to the OS after each compaction in local OVN NB
+// and SB database servers, if ConfigKeyDBMemoryLimit is set. It has no effect until the servers compact
+// their databases.
+func applyDBMemoryTrim(s *state.State) error {
+	limit, err := GetConfig(s, ConfigKeyDBMemoryLimit)
+	if err != nil || limit == "" {
+		return err
+	}
+
+	for _, dbType := range []OvsdbType{OvsdbTypeNBLocal, OvsdbTypeSBLocal} {
+		ctlSock, err := ovsdbControlSock(dbType)
+		if err != nil {
+			return err
+		}
+
+		_, err = AppCtl(s, ctlSock, "ovsdb-server/memory-trim-on-compaction", "on")
+		if err != nil {
+			return fmt.Errorf("failed to enable memory trimming of OVN database server: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// compactIfOverMemoryLimit triggers compaction of local OVN NB or SB database whose ovsdb-server process
+// uses more memory than ConfigKeyDBMemoryLimit. Together with memory trimming enabled by applyDBMemoryTrim,
+// compaction returns memory held by the database history to the OS. OVSDB can't cap its own memory usage,
+// so a warning is logged if the usage stays above the limit.
+func compactIfOverMemoryLimit(s *state.State) error {
+	value, err := GetConfig(s, ConfigKeyDBMemoryLimit)
+	if err != nil || value == "" {
+		return err
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil {
+		return err
+	}
+
+	centralActive, err := localServiceActive(s, "central")
+	if err != nil || !centralActive {
+		return err
+	}
+
+	err = applyDBMemoryTrim(s)
+	if err != nil {
+		return err
+	}
+
+	for _, dbType := range []OvsdbType{OvsdbTypeNBLocal, OvsdbTypeSBLocal} {
+		rss, err := databaseRSS(dbType)
+		if err != nil {
+			return err
+		}
+
+		if rss < int64(limit)*bytesInMiB {
+			continue
+		}
+
+		logger.Infof("OVN database server memory usage (%d bytes) exceeds %d MiB, triggering compaction.", rss, limit)
+		err = compactDatabase(s, dbType)
+		if err != nil {
+			return err
+		}
+
+		rss, err = databaseRSS(dbType)
+		if err == nil && rss >= int64(limit)*bytesInMiB {
+			logger.Warnf("OVN database server memory usage (%d bytes) remains above %d MiB after compaction.", rss, limit)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package ovn
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/logger"
+
+	"github.com/canonical/microovn/microovn/database"
+)
+
+// Metric is a single measurement gathered from the local cluster member. Metric exporters render it in
+// the format of their monitoring system.
+type Metric struct {
+	Name   string            // Name of the metric, e.g. "cluster_members"
+	Labels map[string]string // Labels that distinguish multiple values of the same metric
+	Value  float64           // Current value of the metric
+}
+
+// GatherMetrics collects metrics describing the local cluster member and the cluster as seen by it:
+//   - cluster_members: number of MicroOVN cluster members
+//   - service_members: number of members that run each OVN service (label "service")
+//   - raft_leader: 1 if the local OVN database server is the raft leader, 0 otherwise (label "database")
+//   - database_size_bytes: on-disk size of the local OVN database (label "database")
+//   - certificate_expiry_seconds: time until the certificate expires (label "certificate")
+//   - logical_flows: number of SB logical flows of a logical switch or router (label "datapath")
+//   - connect_endpoints: number of endpoints in the NB or SB connection string of ovn.env (label "database")
+//
+// Database metrics are gathered only on members that run the "central" service. This function is the
+// single source of metrics for every exporter.
+func GatherMetrics(s *state.State) ([]Metric, error) {
+	metrics := []Metric{
+		{Name: "cluster_members", Value: float64(len(s.Remotes().RemotesByName()))},
+	}
+
+	serviceCounts := map[string]int{"central": 0, "chassis": 0, "switch": 0}
+	err := s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		services, err := database.GetServices(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for _, srv := range services {
+			serviceCounts[srv.Service]++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for service, count := range serviceCounts {
+		metrics = append(metrics, Metric{Name: "service_members", Labels: map[string]string{"service": service}, Value: float64(count)})
+	}
+
+	connect := lastConnectStrings()
+	if connect != (ConnectStrings{}) {
+		metrics = append(
+			metrics,
+			Metric{Name: "connect_endpoints", Labels: map[string]string{"database": "nb"}, Value: float64(connectEndpointCount(connect.NB))},
+			Metric{Name: "connect_endpoints", Labels: map[string]string{"database": "sb"}, Value: float64(connectEndpointCount(connect.SB))},
+		)
+	}
+
+	snapshot, err := loadServicesSnapshot(s)
+	if err != nil {
+		return nil, err
+	}
+
+	centralActive := snapshot.LocalServiceActive("central")
+	switchActive := snapshot.LocalServiceActive("switch")
+
+	if centralActive {
+		for dbType, dbName := range map[OvsdbType]string{OvsdbTypeNBLocal: "nb", OvsdbTypeSBLocal: "sb"} {
+			labels := map[string]string{"database": dbName}
+
+			status, err := getClusterStatus(s, dbType)
+			if err != nil {
+				logger.Warnf("Failed to get cluster status of %s database: %s", dbName, err)
+			} else {
+				leader := 0.0
+				if status.IsLeader() {
+					leader = 1
+				}
+
+				metrics = append(metrics, Metric{Name: "raft_leader", Labels: labels, Value: leader})
+			}
+
+			size, err := databaseSize(dbType)
+			if err != nil {
+				return nil, err
+			}
+
+			metrics = append(metrics, Metric{Name: "database_size_bytes", Labels: labels, Value: float64(size)})
+		}
+
+		flowCounts, err := logicalFlowCounts(s)
+		if err != nil {
+			logger.Warnf("Failed to count logical flows: %s", err)
+		}
+
+		for datapath, count := range flowCounts {
+			metrics = append(metrics, Metric{Name: "logical_flows", Labels: map[string]string{"datapath": datapath}, Value: float64(count)})
+		}
+	}
+
+	caCert, _, err := getCA(s)
+	if err != nil {
+		logger.Warnf("Failed to load CA certificate: %s", err)
+	} else {
+		metrics = append(metrics, certificateExpiryMetric("ca", caCert.NotAfter))
+	}
+
+	for _, service := range localCertificateServices(centralActive, switchActive) {
+		cert, err := readServiceCertificate(service)
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				logger.Warnf("Failed to load %s certificate: %s", service, err)
+			}
+
+			continue
+		}
+
+		metrics = append(metrics, certificateExpiryMetric(service, cert.NotAfter))
+	}
+
+	return metrics, nil
+}
+
+// certificateExpiryMetric returns metric with number of seconds remaining until "notAfter".
+func certificateExpiryMetric(name string, notAfter time.Time) Metric {
+	return Metric{
+		Name:   "certificate_expiry_seconds",
+		Labels: map[string]string{"certificate": name},
+		Value:  time.Until(notAfter).Seconds(),
+	}
+}
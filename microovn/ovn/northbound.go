@@ -0,0 +1,262 @@
+package ovn
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/api"
+	"github.com/lxc/lxd/shared/logger"
+
+	"github.com/canonical/microovn/microovn/database"
+)
+
+// globalOptionRecordPrefix is a prefix of keys under which desired NB_Global options are stored in the
+// config DB table. Full key has format "<prefix><option_name>".
+const globalOptionRecordPrefix = "nb_global.options."
+
+// nbReadOnlyRecordName is a key under which desired read-only mode of OVN NB database is stored in the config
+// DB table.
+const nbReadOnlyRecordName = "nb.read-only"
+
+// globalOptionConfigKeys maps configuration keys applied with configApplyGlobalOption to names of the
+// NB_Global options that they control.
+var globalOptionConfigKeys = map[string]string{
+	ConfigKeyFDBRemovalLimit:        "fdb_removal_limit",
+	ConfigKeyMACBindingRemovalLimit: "mac_binding_removal_limit",
+}
+
+// globalOptionName is a pattern that names of NB_Global options must match.
+var globalOptionName = regexp.MustCompile(`^[A-Za-z0-9_.:-]+$`)
+
+// GetGlobalOption returns current value of option "key" from NB_Global table in OVN Northbound database.
+// Empty string is returned if the option is not set. This function must be executed on a member that
+// runs the "central" service.
+func GetGlobalOption(s *state.State, key string) (string, error) {
+	if !globalOptionName.MatchString(key) {
+		return "", api.StatusErrorf(http.StatusBadRequest, "invalid NB_Global option name '%s'", key)
+	}
+
+	value, err := localNBCtl(s, "--if-exists", "get", "NB_Global", ".", fmt.Sprintf("options:%s", key))
+	if err != nil {
+		return "", fmt.Errorf("failed to get NB_Global option '%s': %w", key, err)
+	}
+
+	return strings.Trim(strings.TrimSpace(value), "\""), nil
+}
+
+// SetGlobalOption sets option "key" in NB_Global table in OVN Northbound database to "value" and stores
+// it in the shared MicroOVN database, so it can be reapplied if the Northbound database is rebuilt.
+// Empty value removes the option. This function must be executed on a member that runs the "central"
+// service and that can reach the Northbound cluster leader.
+func SetGlobalOption(s *state.State, key string, value string) error {
+	if !globalOptionName.MatchString(key) {
+		return api.StatusErrorf(http.StatusBadRequest, "invalid NB_Global option name '%s'", key)
+	}
+
+	err := ensureNBLeaderReachable(s)
+	if err != nil {
+		return err
+	}
+
+	err = applyGlobalOption(s, key, value)
+	if err != nil {
+		return err
+	}
+
+	recordKey := globalOptionRecordPrefix + key
+	return s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		exists, err := database.ConfigItemExists(ctx, tx, recordKey)
+		if err != nil {
+			return err
+		}
+
+		if value == "" {
+			if exists {
+				return database.DeleteConfigItem(ctx, tx, recordKey)
+			}
+
+			return nil
+		}
+
+		item := database.ConfigItem{Key: recordKey, Value: value}
+		if exists {
+			return database.UpdateConfigItem(ctx, tx, recordKey, item)
+		}
+
+		_, err = database.CreateConfigItem(ctx, tx, item)
+		return err
+	})
+}
+
+// applyGlobalOptions reapplies all NB_Global options stored by SetGlobalOption, or set via configuration
+// keys listed in globalOptionConfigKeys, to the OVN Northbound database. This function must be executed on
+// a member that runs the "central" service.
+func applyGlobalOptions(s *state.State) error {
+	options := map[string]string{}
+	err := s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		items, err := database.GetConfigItems(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			if strings.HasPrefix(item.Key, globalOptionRecordPrefix) {
+				options[strings.TrimPrefix(item.Key, globalOptionRecordPrefix)] = item.Value
+			}
+
+			option, ok := globalOptionConfigKeys[item.Key]
+			if ok {
+				options[option] = item.Value
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load NB_Global options: %w", err)
+	}
+
+	if len(options) == 0 {
+		return nil
+	}
+
+	var errs []error
+	for key, value := range options {
+		err = applyGlobalOption(s, key, value)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// applyGlobalOption sets option "key" in NB_Global table to "value", or removes it if the "value" is empty.
+func applyGlobalOption(s *state.State, key string, value string) error {
+	var err error
+	if value == "" {
+		_, err = localNBCtl(s, "remove", "NB_Global", ".", "options", key)
+	} else {
+		_, err = localNBCtl(s, "set", "NB_Global", ".", fmt.Sprintf("options:%s=%s", key, strconv.Quote(value)))
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to set NB_Global option '%s': %w", key, err)
+	}
+
+	logger.Debugf("NB_Global option '%s' set to '%s'", key, value)
+	return nil
+}
+
+// ensureNBLeaderReachable returns error if local OVN Northbound database server is not connected to
+// the cluster leader, and therefore is not able to commit changes.
+func ensureNBLeaderReachable(s *state.State) error {
+	status, err := getClusterStatus(s, OvsdbTypeNBLocal)
+	if err != nil {
+		return err
+	}
+
+	if !status.HasLeader() {
+		return api.StatusErrorf(http.StatusServiceUnavailable, "OVN Northbound cluster leader is not reachable from this member")
+	}
+
+	return nil
+}
+
+// SetNBReadOnly switches OVN Northbound database into read-only mode, or back into read-write mode. In the
+// read-only mode, remote clients (e.g. CMS) connecting to the database are not allowed to make any changes,
+// which protects logical configuration from accidental changes during maintenance. Local connections over
+// unix socket, used by MicroOVN itself, are not affected. The mode is stored in the shared MicroOVN database,
+// so it persists across restarts until it's explicitly reverted.
+//
+// ovn-northd connects to the Northbound database as a remote client as well, so its write-back into NB is
+// blocked too. Status that ovn-northd reports in NB (e.g. "up" state of logical switch ports, or nb_cfg
+// acknowledgements in NB_Global) is frozen until the read-write mode is restored.
+//
+// This function must be executed on a member that runs the "central" service and that can reach the
+// Northbound cluster leader.
+func SetNBReadOnly(s *state.State, readOnly bool) error {
+	err := ensureNBLeaderReachable(s)
+	if err != nil {
+		return err
+	}
+
+	err = s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		exists, err := database.ConfigItemExists(ctx, tx, nbReadOnlyRecordName)
+		if err != nil {
+			return err
+		}
+
+		if !readOnly {
+			if exists {
+				return database.DeleteConfigItem(ctx, tx, nbReadOnlyRecordName)
+			}
+
+			return nil
+		}
+
+		item := database.ConfigItem{Key: nbReadOnlyRecordName, Value: "true"}
+		if exists {
+			return database.UpdateConfigItem(ctx, tx, nbReadOnlyRecordName, item)
+		}
+
+		_, err = database.CreateConfigItem(ctx, tx, item)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store NB read-only mode: %w", err)
+	}
+
+	err = updateOvnListenConfig(s)
+	if err != nil {
+		return fmt.Errorf("failed to apply NB read-only mode: %w", err)
+	}
+
+	if readOnly {
+		logger.Warn("OVN Northbound database switched to read-only mode for remote clients, ovn-northd can't update status in NB until it's reverted.")
+	} else {
+		logger.Info("OVN Northbound database switched to read-write mode.")
+	}
+
+	return nil
+}
+
+// nbReadOnly returns true if OVN Northbound database is expected to be in read-only mode, as set by
+// SetNBReadOnly.
+func nbReadOnly(s *state.State) (bool, error) {
+	var readOnly bool
+	err := s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		exists, err := database.ConfigItemExists(ctx, tx, nbReadOnlyRecordName)
+		readOnly = exists
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to get NB read-only mode: %w", err)
+	}
+
+	return readOnly, nil
+}
+
+// getNBReadOnly returns true if any of the connections that OVN Northbound database currently listens on is
+// in read-only mode. This function must be executed on a member that runs the "central" service.
+func getNBReadOnly(s *state.State) (bool, error) {
+	output, err := localNBCtl(s, "--data=bare", "--no-headings", "--columns=read_only", "list", "Connection")
+	if err != nil {
+		return false, fmt.Errorf("failed to get NB connection mode: %w", err)
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		if strings.TrimSpace(line) == "true" {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,132 @@
+package ovn
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared"
+	"github.com/lxc/lxd/shared/logger"
+
+	"github.com/canonical/microovn/microovn/ovn/paths"
+)
+
+// NorthdCtl is a wrapper function that executes 'ovn-appctl' command specifically targeted at
+// running ovn-northd process. The '-t' argument of 'ovn-appctl' will be configured automatically.
+// Any arguments supplied in 'args' will be passed to the 'ovn-appctl' unchanged.
+func NorthdCtl(s *state.State, args ...string) (string, error) {
+	arguments := []string{"-t", "ovn-northd"}
+	arguments = append(arguments, args...)
+
+	stdout, _, err := shared.RunCommandSplit(
+		s.Context,
+		append(os.Environ(), fmt.Sprintf("OVN_RUNDIR=%s", paths.CentralRuntimeDir())),
+		nil,
+		"ovn-appctl",
+		arguments...,
+	)
+
+	return stdout, err
+}
+
+// northdExternal returns true if ovn-northd is managed by an external system, as configured by
+// ConfigKeyNorthdExternal. In such case, the "central" service does not start ovn-northd and MicroOVN does
+// not control it in any way.
+func northdExternal(s *state.State) (bool, error) {
+	value, err := GetConfig(s, ConfigKeyNorthdExternal)
+	if err != nil {
+		return false, err
+	}
+
+	external, _ := strconv.ParseBool(value)
+	return external, nil
+}
+
+// northdThreadCount returns number of threads that ovn-northd should use for parallel build, as
+// configured by ConfigKeyNorthdThreads. Zero is returned if the parallel build is not configured. The
+// configured value is capped at the number of CPUs available on this member, because the option is
+// shared by the whole cluster and members may differ in size.
+func northdThreadCount(s *state.State) (int, error) {
+	threads, err := getConfigInt(s, ConfigKeyNorthdThreads, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	if threads > runtime.NumCPU() {
+		logger.Warnf("Configured ovn-northd thread count (%d) exceeds number of available CPUs, using %d.", threads, runtime.NumCPU())
+		threads = runtime.NumCPU()
+	}
+
+	return threads, nil
+}
+
+// applyNorthdThreads configures running ovn-northd process to use number of threads returned by
+// northdThreadCount. Externally managed ovn-northd is left alone.
+func applyNorthdThreads(s *state.State) error {
+	external, err := northdExternal(s)
+	if err != nil || external {
+		return err
+	}
+
+	threads, err := northdThreadCount(s)
+	if err != nil {
+		return err
+	}
+
+	// Single thread disables parallel build.
+	if threads == 0 {
+		threads = 1
+	}
+
+	_, err = NorthdCtl(s, "parallel-build/set-n-threads", strconv.Itoa(threads))
+	if err != nil {
+		return fmt.Errorf("failed to set ovn-northd thread count: %w", err)
+	}
+
+	return nil
+}
+
+// getNorthdThreadCount returns number of threads currently used by the running ovn-northd process.
+func getNorthdThreadCount(s *state.State) (string, error) {
+	output, err := NorthdCtl(s, "parallel-build/get-n-threads")
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(output), nil
+}
+
+// northdLocalDatabases returns environment variables that point co-located ovn-northd to local unix
+// sockets of OVN NB and SB databases, followed by the cluster-wide connection strings "nbConnect" and
+// "sbConnect". ovn-northd only works with the raft leader, so the cluster remotes are kept for the case when
+// the local database server is a follower. Variables are returned only if ConfigKeyNorthdLocalDB is enabled
+// and this member runs the "central" service, i.e. ovn-northd runs next to the database servers. Remote
+// clients keep using the connection strings.
+func northdLocalDatabases(s *state.State, nbConnect string, sbConnect string) (map[string]string, error) {
+	value, err := GetConfig(s, ConfigKeyNorthdLocalDB)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled, _ := strconv.ParseBool(value)
+	if !enabled {
+		return nil, nil
+	}
+
+	colocated, err := localServiceActive(s, "central")
+	if err != nil {
+		return nil, err
+	}
+
+	if !colocated {
+		return nil, nil
+	}
+
+	return map[string]string{
+		"OVN_NORTHD_NB_DB": fmt.Sprintf("unix:%s,%s", paths.OvnNBDatabaseSock(), nbConnect),
+		"OVN_NORTHD_SB_DB": fmt.Sprintf("unix:%s,%s", paths.OvnSBDatabaseSock(), sbConnect),
+	}, nil
+}
@@ -0,0 +1,283 @@
+package ovn
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/canonical/microcluster/client"
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/api"
+	"github.com/lxc/lxd/shared/logger"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	microovnClient "github.com/canonical/microovn/microovn/client"
+)
+
+const northdStandbyCheckInterval = 10 * time.Second // Interval between checks of ovn-northd instances on other members
+const northdFailoverChecks = 3                      // Consecutive checks without active ovn-northd before held back instance takes over
+
+// States of ovn-northd instance, as reported by its "status" command.
+const (
+	NorthdStateActive  = "active"  // Instance holds the SB lock and processes NB changes
+	NorthdStateStandby = "standby" // Instance is ready to take over if the active one fails
+	NorthdStatePaused  = "paused"  // Instance does not compete for the SB lock
+
+	NorthdStateExternal = "external" // Instance is managed by an external system, see ConfigKeyNorthdExternal
+)
+
+// northdStandbyMonitorOnce ensures that only one monitor of held back ovn-northd is running.
+var northdStandbyMonitorOnce sync.Once
+
+// northdTakeover tracks whether ovn-northd held back by ConfigKeyNorthdStandby was resumed, because no
+// other member had an active ovn-northd.
+var northdTakeover struct {
+	sync.Mutex
+	active bool
+}
+
+// northdHeldBack returns true if local ovn-northd is configured, by ConfigKeyNorthdStandby, to never
+// become active, unless it's required for failover. Externally managed ovn-northd is never held back.
+func northdHeldBack(s *state.State) (bool, error) {
+	external, err := northdExternal(s)
+	if err != nil || external {
+		return false, err
+	}
+
+	value, err := GetConfig(s, ConfigKeyNorthdStandby)
+	if err != nil {
+		return false, err
+	}
+
+	heldBack, _ := strconv.ParseBool(value)
+	return heldBack, nil
+}
+
+// NorthdState returns state of the local ovn-northd instance, one of NorthdStateActive, NorthdStateStandby
+// or NorthdStatePaused.
+func NorthdState(s *state.State) (string, error) {
+	output, err := NorthdCtl(s, "status")
+	if err != nil {
+		return "", fmt.Errorf("failed to get ovn-northd status: %w", err)
+	}
+
+	_, status, found := strings.Cut(strings.TrimSpace(output), ":")
+	if !found {
+		return "", fmt.Errorf("unexpected ovn-northd status '%s'", strings.TrimSpace(output))
+	}
+
+	return strings.TrimSpace(status), nil
+}
+
+// LocalNorthdStatus returns state of the local ovn-northd instance along with its configured role. Error
+// is returned if this member does not run the "central" service. NorthdStateExternal is reported, without
+// contacting ovn-northd, if it's managed by an external system.
+func LocalNorthdStatus(s *state.State) (*types.NorthdStatus, error) {
+	centralActive, err := localServiceActive(s, "central")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query local services: %w", err)
+	}
+
+	if !centralActive {
+		return nil, api.StatusErrorf(http.StatusNotFound, "member '%s' does not run the 'central' service", s.Name())
+	}
+
+	external, err := northdExternal(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if external {
+		return &types.NorthdStatus{State: NorthdStateExternal}, nil
+	}
+
+	heldBack, err := northdHeldBack(s)
+	if err != nil {
+		return nil, err
+	}
+
+	northdState, err := NorthdState(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.NorthdStatus{State: northdState, HeldBack: heldBack}, nil
+}
+
+// applyNorthdStandby pauses local ovn-northd if it's held back by ConfigKeyNorthdStandby, so that it never
+// takes the SB lock and stays out of the active role, or resumes it otherwise. Paused ovn-northd is resumed
+// by startNorthdStandbyMonitor if no other member has an active ovn-northd. Externally managed ovn-northd is
+// left alone.
+func applyNorthdStandby(s *state.State) error {
+	external, err := northdExternal(s)
+	if err != nil || external {
+		return err
+	}
+
+	heldBack, err := northdHeldBack(s)
+	if err != nil {
+		return err
+	}
+
+	northdTakeover.Lock()
+	defer northdTakeover.Unlock()
+
+	northdTakeover.active = false
+
+	command := "resume"
+	if heldBack {
+		command = "pause"
+	}
+
+	_, err = NorthdCtl(s, command)
+	if err != nil {
+		return fmt.Errorf("failed to %s ovn-northd: %w", command, err)
+	}
+
+	return nil
+}
+
+// northdKeepPaused returns true if local ovn-northd should stay paused, i.e. it's held back by
+// ConfigKeyNorthdStandby and it did not take over after failure of the other instances.
+func northdKeepPaused(s *state.State) bool {
+	heldBack, err := northdHeldBack(s)
+	if err != nil || !heldBack {
+		return false
+	}
+
+	northdTakeover.Lock()
+	defer northdTakeover.Unlock()
+
+	return !northdTakeover.active
+}
+
+// startNorthdStandbyMonitor starts background goroutine that keeps OVN northbound processing running when
+// the local ovn-northd is held back by ConfigKeyNorthdStandby. If none of the other members reports
+// active ovn-northd in northdFailoverChecks consecutive checks, local ovn-northd is resumed. It's paused
+// again once another member, that is not held back, has ovn-northd ready to take over.
+func startNorthdStandbyMonitor(s *state.State) {
+	northdStandbyMonitorOnce.Do(func() {
+		go func() {
+			ticker := time.NewTicker(northdStandbyCheckInterval)
+			defer ticker.Stop()
+
+			missingActive := 0
+			for {
+				select {
+				case <-s.Context.Done():
+					return
+				case <-ticker.C:
+				}
+
+				err := reconcileNorthdStandby(s, &missingActive)
+				if err != nil {
+					logger.Warnf("Failed to check state of held back ovn-northd: %s", err)
+				}
+			}
+		}()
+	})
+}
+
+// reconcileNorthdStandby performs a single check of startNorthdStandbyMonitor. "missingActive" counts
+// consecutive checks that found no active ovn-northd on other members.
+func reconcileNorthdStandby(s *state.State, missingActive *int) error {
+	heldBack, err := northdHeldBack(s)
+	if err != nil || !heldBack {
+		*missingActive = 0
+		return err
+	}
+
+	centralActive, err := localServiceActive(s, "central")
+	if err != nil || !centralActive {
+		return err
+	}
+
+	remoteActive, remoteReady, err := remoteNorthdStates(s)
+	if err != nil {
+		return err
+	}
+
+	northdTakeover.Lock()
+	defer northdTakeover.Unlock()
+
+	if northdTakeover.active {
+		if !remoteReady {
+			return nil
+		}
+
+		logger.Infof("ovn-northd on another member is ready, returning local held back ovn-northd to standby")
+		_, err = NorthdCtl(s, "pause")
+		if err != nil {
+			return fmt.Errorf("failed to pause ovn-northd: %w", err)
+		}
+
+		northdTakeover.active = false
+		return nil
+	}
+
+	if remoteActive {
+		*missingActive = 0
+
+		// Restarted ovn-northd doesn't keep its paused state.
+		localState, err := NorthdState(s)
+		if err != nil || localState == NorthdStatePaused {
+			return err
+		}
+
+		_, err = NorthdCtl(s, "pause")
+		if err != nil {
+			return fmt.Errorf("failed to pause ovn-northd: %w", err)
+		}
+
+		return nil
+	}
+
+	*missingActive++
+	if *missingActive < northdFailoverChecks {
+		return nil
+	}
+
+	logger.Warnf("No member has active ovn-northd, resuming held back local ovn-northd")
+	_, err = NorthdCtl(s, "resume")
+	if err != nil {
+		return fmt.Errorf("failed to resume ovn-northd: %w", err)
+	}
+
+	northdTakeover.active = true
+	*missingActive = 0
+	return nil
+}
+
+// remoteNorthdStates queries ovn-northd instances on other central members. It returns whether any of
+// them is active, and whether any of them, that is not held back, is able to become active.
+func remoteNorthdStates(s *state.State) (bool, bool, error) {
+	var mu sync.Mutex
+	active := false
+	ready := false
+	err := QueryCluster(s, nil, func(ctx context.Context, c *client.Client) error {
+		status, err := microovnClient.GetNorthdStatus(ctx, c)
+		if err != nil {
+			// Members without "central" service, or unreachable ones, have no ovn-northd to consider.
+			return nil
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if status.State == NorthdStateActive {
+			active = true
+		}
+
+		if !status.HeldBack && status.State != NorthdStatePaused {
+			ready = true
+		}
+
+		return nil
+	})
+
+	return active, ready, err
+}
@@ -0,0 +1,106 @@
+package ovn
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/api"
+)
+
+const northdSyncPollInterval = 500 * time.Millisecond // Interval between checks of NB and SB sequence numbers
+
+// northdSyncState holds sequence numbers that track propagation of NB changes into SB by ovn-northd.
+type northdSyncState struct {
+	nbCfg   int // NB_Global:nb_cfg, requested sequence number
+	sbNbCfg int // SB_Global:nb_cfg, sequence number that ovn-northd translated into SB
+	sbCfg   int // NB_Global:sb_cfg, sequence number that ovn-northd confirmed as committed in SB
+}
+
+// WaitForNorthdSync waits until ovn-northd propagates all changes made to OVN NB database so far into OVN SB
+// database. It increments NB_Global:nb_cfg sequence number and waits until ovn-northd reports it, via
+// SB_Global:nb_cfg and NB_Global:sb_cfg, as processed. Waiting is interrupted when "timeout" expires or the
+// daemon shuts down, in which case the returned error reports the last seen sequence numbers.
+//
+// This function must be executed on a member that runs the "central" service.
+func WaitForNorthdSync(s *state.State, timeout time.Duration) error {
+	centralActive, err := localServiceActive(s, "central")
+	if err != nil {
+		return fmt.Errorf("failed to query local services: %w", err)
+	}
+
+	if !centralActive {
+		return api.StatusErrorf(http.StatusBadRequest, "waiting for ovn-northd requires local 'central' service")
+	}
+
+	current, err := getNorthdSyncState(s)
+	if err != nil {
+		return err
+	}
+
+	target := current.nbCfg + 1
+	_, err = localNBCtl(s, "set", "NB_Global", ".", fmt.Sprintf("nb_cfg=%d", target))
+	if err != nil {
+		return fmt.Errorf("failed to increment NB sequence number: %w", err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		current, err = getNorthdSyncState(s)
+		if err == nil && current.sbNbCfg >= target && current.sbCfg >= target {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			if err != nil {
+				return api.StatusErrorf(http.StatusGatewayTimeout, "ovn-northd did not process NB sequence number %d within %s: %s", target, timeout, err)
+			}
+
+			return api.StatusErrorf(http.StatusGatewayTimeout, "ovn-northd did not process NB sequence number %d within %s (SB_Global:nb_cfg %d, NB_Global:sb_cfg %d)", target, timeout, current.sbNbCfg, current.sbCfg)
+		}
+
+		select {
+		case <-s.Context.Done():
+			return s.Context.Err()
+		case <-time.After(northdSyncPollInterval):
+		}
+	}
+}
+
+// getNorthdSyncState reads current NB and SB sequence numbers from the local OVN databases.
+func getNorthdSyncState(s *state.State) (*northdSyncState, error) {
+	output, err := localNBCtl(s, "get", "NB_Global", ".", "nb_cfg", "sb_cfg")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get NB sequence numbers: %w", err)
+	}
+
+	nbValues := strings.Fields(output)
+	if len(nbValues) != 2 {
+		return nil, fmt.Errorf("unexpected NB sequence numbers '%s'", strings.TrimSpace(output))
+	}
+
+	output, err = localSBCtl(s, "get", "SB_Global", ".", "nb_cfg")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SB sequence number: %w", err)
+	}
+
+	syncState := northdSyncState{}
+	for _, value := range []struct {
+		raw   string
+		field *int
+	}{
+		{raw: nbValues[0], field: &syncState.nbCfg},
+		{raw: nbValues[1], field: &syncState.sbCfg},
+		{raw: strings.TrimSpace(output), field: &syncState.sbNbCfg},
+	} {
+		*value.field, err = strconv.Atoi(value.raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sequence number '%s': %w", value.raw, err)
+		}
+	}
+
+	return &syncState, nil
+}
@@ -7,9 +7,14 @@ import (
 )
 
 var pathRoot = os.Getenv("SNAP_COMMON")
+var snapRoot = os.Getenv("SNAP")
 var runtimeDir = filepath.Join(pathRoot, "run")
 var dataDir = filepath.Join(pathRoot, "data")
 
+// systemIDFile is a location of the file that holds OVN chassis system-id. It can be overridden by
+// MICROOVN_SYSTEM_ID_FILE environment variable, e.g. to place it on storage that survives host re-imaging.
+var systemIDFile = os.Getenv("MICROOVN_SYSTEM_ID_FILE")
+
 // Root returns $SNAP_COMMON root of MicroOVN
 func Root() string {
 	return pathRoot
@@ -20,6 +25,11 @@ func LogsDir() string {
 	return filepath.Join(pathRoot, "logs")
 }
 
+// StatsDir returns path to the directory where periodically collected statistics of OVN daemons are stored
+func StatsDir() string {
+	return filepath.Join(LogsDir(), "stats")
+}
+
 // CentralRuntimeDir returns path to the directory where OVN Central creates its runtime files
 func CentralRuntimeDir() string {
 	return filepath.Join(runtimeDir, "central")
@@ -50,6 +60,15 @@ func SwitchDataDir() string {
 	return filepath.Join(dataDir, "switch", "openvswitch")
 }
 
+// SystemIDFile returns path to the file where OVN chassis system-id is persisted
+func SystemIDFile() string {
+	if systemIDFile != "" {
+		return systemIDFile
+	}
+
+	return filepath.Join(SwitchDataDir(), "system-id.conf")
+}
+
 // OvnEnvFile returns path to the file used to configure env variables for OVN commands
 func OvnEnvFile() string {
 	return filepath.Join(dataDir, "ovn.env")
@@ -65,6 +84,26 @@ func OvnSBDatabaseSock() string {
 	return filepath.Join(CentralRuntimeDir(), "ovnsb_db.sock")
 }
 
+// OvnNBDatabaseFile returns path to the file where Northbound OVN database stores its data
+func OvnNBDatabaseFile() string {
+	return filepath.Join(CentralDBDir(), "ovnnb_db.db")
+}
+
+// OvnNBSchemaFile returns path to the schema of Northbound OVN database shipped with the snap
+func OvnNBSchemaFile() string {
+	return filepath.Join(snapRoot, "share", "ovn", "ovn-nb.ovsschema")
+}
+
+// OvnSBSchemaFile returns path to the schema of Southbound OVN database shipped with the snap
+func OvnSBSchemaFile() string {
+	return filepath.Join(snapRoot, "share", "ovn", "ovn-sb.ovsschema")
+}
+
+// OvnSBDatabaseFile returns path to the file where Southbound OVN database stores its data
+func OvnSBDatabaseFile() string {
+	return filepath.Join(CentralDBDir(), "ovnsb_db.db")
+}
+
 // OvnNBControlSock returns path to the local control socket for Northbound OVN service
 func OvnNBControlSock() string {
 	return filepath.Join(CentralRuntimeDir(), "ovnnb_db.ctl")
@@ -90,6 +129,11 @@ func PkiCaCertFile() string {
 	return filepath.Join(PkiDir(), "cacert.pem")
 }
 
+// PkiCrlFile returns path to the list of revoked client certificates
+func PkiCrlFile() string {
+	return filepath.Join(PkiDir(), "crl.pem")
+}
+
 // PkiOvnNbCertFiles returns paths to certificate and private key used by OVN Northbound service
 func PkiOvnNbCertFiles() (string, string) {
 	return getServiceCertFiles("ovnnb")
@@ -134,6 +178,7 @@ func RequiredDirs() []string {
 		SwitchRuntimeDir(),
 		SwitchDataDir(),
 		LogsDir(),
+		StatsDir(),
 		PkiDir(),
 	}
 }
@@ -0,0 +1,269 @@
+package ovn
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/api"
+	"github.com/lxc/lxd/shared/logger"
+	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/canonical/microovn/microovn/database"
+)
+
+const pkiBundleVersion = 1        // Version of the PKI bundle format produced by ExportPKI
+const pkiKeyIterations = 600000   // Number of PBKDF2 iterations used to derive bundle encryption key
+const pkiMaxIterations = 10000000 // Maximal number of PBKDF2 iterations accepted from imported bundles
+const pkiMinPassphraseLength = 8  // Minimal length of the passphrase that protects PKI bundle
+const pkiSaltSize = 16            // Size of the random salt used to derive bundle encryption key
+const pkiKeySize = 32             // Size of the AES key that encrypts the bundle
+
+// pkiKeyPair holds PEM encoded certificate and private key.
+type pkiKeyPair struct {
+	Cert string `json:"cert"`
+	Key  string `json:"key"`
+}
+
+// pkiContent is the plaintext content of the PKI bundle. Service certificates are keyed by the member name
+// and the service name.
+type pkiContent struct {
+	CA       pkiKeyPair                       `json:"ca"`
+	Services map[string]map[string]pkiKeyPair `json:"services"`
+}
+
+// pkiBundle is the encrypted PKI bundle produced by ExportPKI.
+type pkiBundle struct {
+	Version    int    `json:"version"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Data       []byte `json:"data"`
+}
+
+// ExportPKI returns bundle with the cluster CA certificate and private key, together with certificates and
+// private keys of OVN services running on this member. Bundle is encrypted with AES-GCM, using key derived
+// from "passphrase", and can be restored on a different cluster with ImportPKI, so that clients that trust
+// the current CA keep trusting the new cluster.
+func ExportPKI(s *state.State, passphrase string) ([]byte, error) {
+	err := validatePKIPassphrase(passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	content := pkiContent{Services: map[string]map[string]pkiKeyPair{}}
+	err = s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		caCert, err := database.GetConfigItem(ctx, tx, CACertRecordName)
+		if err != nil {
+			return fmt.Errorf("failed to get CA certificate from the database: %w", err)
+		}
+
+		caKey, err := database.GetConfigItem(ctx, tx, CAKeyRecordName)
+		if err != nil {
+			return fmt.Errorf("failed to get CA private key from the database: %w", err)
+		}
+
+		content.CA = pkiKeyPair{Cert: caCert.Value, Key: caKey.Value}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	services, err := localPKIServices(s)
+	if err != nil {
+		return nil, err
+	}
+
+	local := map[string]pkiKeyPair{}
+	for _, service := range services {
+		certPath, keyPath, err := getServiceCertificatePaths(service)
+		if err != nil {
+			return nil, err
+		}
+
+		cert, err := os.ReadFile(certPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s certificate: %w", service, err)
+		}
+
+		key, err := os.ReadFile(keyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s private key: %w", service, err)
+		}
+
+		local[service] = pkiKeyPair{Cert: string(cert), Key: string(key)}
+	}
+
+	content.Services[s.Name()] = local
+
+	plaintext, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := pkiBundle{Version: pkiBundleVersion, Iterations: pkiKeyIterations, Salt: make([]byte, pkiSaltSize)}
+	_, err = rand.Read(bundle.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	aead, err := pkiCipher(passphrase, bundle.Salt, bundle.Iterations)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle.Nonce = make([]byte, aead.NonceSize())
+	_, err = rand.Read(bundle.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	bundle.Data = aead.Seal(nil, bundle.Nonce, plaintext, nil)
+	return json.Marshal(bundle)
+}
+
+// ImportPKI decrypts "bundle" produced by ExportPKI with "passphrase" and replaces the cluster CA with the
+// one from the bundle. Certificates of local OVN services are restored from the bundle if it contains them
+// for this member's name, otherwise they are issued anew by the imported CA. Other cluster members have to
+// reissue their certificates to use the imported CA.
+func ImportPKI(s *state.State, bundle []byte, passphrase string) error {
+	var envelope pkiBundle
+	err := json.Unmarshal(bundle, &envelope)
+	if err != nil {
+		return api.StatusErrorf(http.StatusBadRequest, "failed to parse PKI bundle: %s", err)
+	}
+
+	if envelope.Version != pkiBundleVersion {
+		return api.StatusErrorf(http.StatusBadRequest, "unsupported PKI bundle version %d", envelope.Version)
+	}
+
+	aead, err := pkiCipher(passphrase, envelope.Salt, envelope.Iterations)
+	if err != nil {
+		return err
+	}
+
+	if len(envelope.Nonce) != aead.NonceSize() {
+		return api.StatusErrorf(http.StatusBadRequest, "invalid PKI bundle nonce")
+	}
+
+	plaintext, err := aead.Open(nil, envelope.Nonce, envelope.Data, nil)
+	if err != nil {
+		return api.StatusErrorf(http.StatusBadRequest, "failed to decrypt PKI bundle, passphrase is incorrect or the bundle is damaged")
+	}
+
+	var content pkiContent
+	err = json.Unmarshal(plaintext, &content)
+	if err != nil {
+		return fmt.Errorf("failed to parse PKI bundle content: %w", err)
+	}
+
+	if content.CA.Cert == "" || content.CA.Key == "" {
+		return api.StatusErrorf(http.StatusBadRequest, "PKI bundle does not contain CA certificate and private key")
+	}
+
+	err = storeCA(s, []byte(content.CA.Cert), []byte(content.CA.Key))
+	if err != nil {
+		return err
+	}
+
+	err = DumpCA(s)
+	if err != nil {
+		return err
+	}
+
+	services, err := localPKIServices(s)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, service := range services {
+		pair, ok := content.Services[s.Name()][service]
+		if ok {
+			err = writeServiceCertificate(service, pair)
+		} else {
+			// Like on bootstrap and join, "client" certificate is a server type certificate, because OVS-based
+			// programs that use it can open both active and passive connections.
+			err = GenerateNewServiceCertificate(s, service, CertificateTypeServer)
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to restore %s certificate: %w", service, err))
+		}
+	}
+
+	logger.Info("PKI imported, other cluster members have to reissue their certificates.")
+	return errors.Join(errs...)
+}
+
+// localPKIServices returns names of services whose certificates are expected on this member.
+func localPKIServices(s *state.State) ([]string, error) {
+	snapshot, err := loadServicesSnapshot(s)
+	if err != nil {
+		return nil, err
+	}
+
+	centralActive := snapshot.LocalServiceActive("central")
+	switchActive := snapshot.LocalServiceActive("switch")
+
+	return localCertificateServices(centralActive, switchActive), nil
+}
+
+// writeServiceCertificate writes certificate and private key of the local "service".
+func writeServiceCertificate(service string, pair pkiKeyPair) error {
+	certPath, keyPath, err := getServiceCertificatePaths(service)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(certPath, []byte(pair.Cert), certFileMode)
+	if err != nil {
+		return fmt.Errorf("failed to write %s certificate into file %s: %w", service, certPath, err)
+	}
+
+	err = os.WriteFile(keyPath, []byte(pair.Key), certFileMode)
+	if err != nil {
+		return fmt.Errorf("failed to write %s private key into file %s: %w", service, keyPath, err)
+	}
+
+	return nil
+}
+
+// validatePKIPassphrase verifies that the passphrase protecting PKI bundle is long enough.
+func validatePKIPassphrase(passphrase string) error {
+	if len(passphrase) < pkiMinPassphraseLength {
+		return api.StatusErrorf(http.StatusBadRequest, "passphrase must be at least %d characters long", pkiMinPassphraseLength)
+	}
+
+	return nil
+}
+
+// pkiCipher returns AES-GCM cipher with key derived from "passphrase" and "salt".
+func pkiCipher(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	err := validatePKIPassphrase(passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	// Iterations come from the bundle, unbounded value would let a crafted bundle keep the member busy.
+	if len(salt) == 0 || iterations <= 0 || iterations > pkiMaxIterations {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "invalid PKI bundle key parameters")
+	}
+
+	block, err := aes.NewCipher(pbkdf2.Key([]byte(passphrase), salt, iterations, pkiKeySize, sha256.New))
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,59 @@
+package ovn
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const prometheusPrefix = "microovn_"
+
+// RenderPrometheusMetrics renders "metrics", as returned by GatherMetrics, in the Prometheus text
+// exposition format. Every metric is exported as a gauge.
+func RenderPrometheusMetrics(metrics []Metric) string {
+	byName := map[string][]Metric{}
+	names := []string{}
+	for _, metric := range metrics {
+		_, ok := byName[metric.Name]
+		if !ok {
+			names = append(names, metric.Name)
+		}
+
+		byName[metric.Name] = append(byName[metric.Name], metric)
+	}
+
+	sort.Strings(names)
+
+	var sb strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&sb, "# TYPE %s%s gauge\n", prometheusPrefix, name)
+		for _, metric := range byName[name] {
+			fmt.Fprintf(&sb, "%s%s%s %s\n", prometheusPrefix, name, prometheusLabels(metric.Labels), strconv.FormatFloat(metric.Value, 'f', -1, 64))
+		}
+	}
+
+	return sb.String()
+}
+
+// prometheusLabels renders "labels" as a Prometheus label set, ordered by label names. Empty string is
+// returned if there are no labels.
+func prometheusLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", name, strconv.Quote(labels[name])))
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
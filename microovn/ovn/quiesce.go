@@ -0,0 +1,396 @@
+package ovn
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/canonical/microcluster/client"
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/api"
+	"github.com/lxc/lxd/shared/logger"
+
+	microovnClient "github.com/canonical/microovn/microovn/client"
+	"github.com/canonical/microovn/microovn/database"
+	"github.com/canonical/microovn/microovn/ovn/paths"
+)
+
+const maxQuiesceDuration = 60 * time.Second // Upper bound for the time OVN central services can stay quiesced
+
+// quiesceRecordName is a key under which Quiesce stores, in the shared MicroOVN database, time until which
+// OVN databases are expected to stay read-only.
+const quiesceRecordName = "central.quiesced-until"
+
+// quiesceState tracks whether this member coordinates quiesce of OVN central services. Timer is used to
+// automatically resume the services if Unquiesce is not called in time.
+var quiesceState struct {
+	sync.Mutex
+	timer *time.Timer
+}
+
+// northdPauseState tracks whether local ovn-northd is paused by PauseNorthd. Timer is used to automatically
+// resume it if ResumeNorthd is not called in time.
+var northdPauseState struct {
+	sync.Mutex
+	timer *time.Timer
+}
+
+// Quiesce prepares OVN central services for a consistent snapshot of their data directory. It blocks writes
+// into OVN NB and SB databases cluster-wide: every connection that the databases listen on for remote
+// clients is switched into read-only mode, and ovn-northd is paused on every central member, so it stops
+// writing into the Southbound database. Then it compacts local NB and SB databases, so that their on-disk
+// state is contained in a single snapshot instead of a long raft log. Local connections over unix socket,
+// used by MicroOVN itself, are not affected.
+//
+// Services are automatically resumed after "duration", which is capped at maxQuiesceDuration. Unquiesce
+// should be called as soon as the snapshot is taken. This function must be executed on a member that runs
+// the "central" service.
+func Quiesce(s *state.State, duration time.Duration) error {
+	centralActive, err := localServiceActive(s, "central")
+	if err != nil {
+		return fmt.Errorf("failed to query local services: %w", err)
+	}
+
+	if !centralActive {
+		return fmt.Errorf("quiesce requires local 'central' service")
+	}
+
+	if duration <= 0 || duration > maxQuiesceDuration {
+		duration = maxQuiesceDuration
+	}
+
+	quiesceState.Lock()
+	defer quiesceState.Unlock()
+
+	if quiesceState.timer != nil {
+		return fmt.Errorf("OVN central services are already quiesced")
+	}
+
+	quiesceState.timer = time.AfterFunc(duration, func() {
+		logger.Warnf("OVN central services were quiesced for more than %s, resuming.", duration)
+		err := Unquiesce(s)
+		if err != nil {
+			logger.Errorf("Failed to resume OVN central services: %s", err)
+		}
+	})
+
+	err = quiesceCluster(s, duration)
+	if err == nil {
+		for _, dbType := range []OvsdbType{OvsdbTypeNBLocal, OvsdbTypeSBLocal} {
+			err = compactDatabase(s, dbType)
+			if err != nil {
+				break
+			}
+		}
+	}
+
+	if err != nil {
+		quiesceState.timer.Stop()
+		quiesceState.timer = nil
+		return errors.Join(err, unquiesceCluster(s))
+	}
+
+	return nil
+}
+
+// Unquiesce resumes OVN central services quiesced by Quiesce. Calling it when services are not quiesced
+// has no effect.
+func Unquiesce(s *state.State) error {
+	quiesceState.Lock()
+	defer quiesceState.Unlock()
+
+	if quiesceState.timer == nil {
+		return nil
+	}
+
+	quiesceState.timer.Stop()
+	quiesceState.timer = nil
+
+	return unquiesceCluster(s)
+}
+
+// quiesceCluster switches OVN databases into read-only mode for remote clients until "duration" elapses, and
+// pauses ovn-northd on every central member for the same time. Connection table is shared by all members of
+// the database cluster, so the read-only mode applied by this member is in effect on every central member.
+func quiesceCluster(s *state.State, duration time.Duration) error {
+	err := setQuiescedUntil(s, time.Now().Add(duration))
+	if err != nil {
+		return err
+	}
+
+	err = updateOvnListenConfig(s)
+	if err != nil {
+		return fmt.Errorf("failed to switch OVN databases into read-only mode: %w", err)
+	}
+
+	err = PauseNorthd(s, duration)
+	if err != nil {
+		return err
+	}
+
+	return QueryCluster(s, nil, func(ctx context.Context, c *client.Client) error {
+		return microovnClient.PauseNorthd(ctx, c, duration)
+	})
+}
+
+// unquiesceCluster reverts changes made by quiesceCluster. All steps are attempted even if some of them fail.
+func unquiesceCluster(s *state.State) error {
+	var errs []error
+	err := setQuiescedUntil(s, time.Time{})
+	if err == nil {
+		err = updateOvnListenConfig(s)
+	}
+
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to switch OVN databases back into read-write mode: %w", err))
+	}
+
+	errs = append(errs, ResumeNorthd(s))
+	errs = append(errs, QueryCluster(s, nil, func(ctx context.Context, c *client.Client) error {
+		return microovnClient.ResumeNorthd(ctx, c)
+	}))
+
+	return errors.Join(errs...)
+}
+
+// setQuiescedUntil stores time until which OVN databases are expected to stay read-only. Zero "until" removes
+// the record.
+func setQuiescedUntil(s *state.State, until time.Time) error {
+	err := s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		exists, err := database.ConfigItemExists(ctx, tx, quiesceRecordName)
+		if err != nil {
+			return err
+		}
+
+		if until.IsZero() {
+			if exists {
+				return database.DeleteConfigItem(ctx, tx, quiesceRecordName)
+			}
+
+			return nil
+		}
+
+		item := database.ConfigItem{Key: quiesceRecordName, Value: until.UTC().Format(time.RFC3339)}
+		if exists {
+			return database.UpdateConfigItem(ctx, tx, quiesceRecordName, item)
+		}
+
+		_, err = database.CreateConfigItem(ctx, tx, item)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store quiesce state: %w", err)
+	}
+
+	return nil
+}
+
+// databasesQuiesced returns true if OVN databases are expected to be read-only for remote clients, because
+// they were quiesced by Quiesce and the quiesce did not time out yet.
+func databasesQuiesced(s *state.State) (bool, error) {
+	var value string
+	err := s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		item, err := database.GetConfigItem(ctx, tx, quiesceRecordName)
+		if err != nil {
+			if api.StatusErrorCheck(err, http.StatusNotFound) {
+				return nil
+			}
+
+			return err
+		}
+
+		value = item.Value
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to get quiesce state: %w", err)
+	}
+
+	if value == "" {
+		return false, nil
+	}
+
+	until, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse quiesce state: %w", err)
+	}
+
+	return time.Now().Before(until), nil
+}
+
+// PauseNorthd pauses local ovn-northd for at most "duration", after which it's resumed automatically. Members
+// that don't run the "central" service, and members whose ovn-northd is managed externally, have no ovn-northd
+// to pause and the call has no effect.
+func PauseNorthd(s *state.State, duration time.Duration) error {
+	centralActive, err := localServiceActive(s, "central")
+	if err != nil {
+		return fmt.Errorf("failed to query local services: %w", err)
+	}
+
+	external, err := northdExternal(s)
+	if err != nil {
+		return err
+	}
+
+	if !centralActive || external {
+		return nil
+	}
+
+	northdPauseState.Lock()
+	defer northdPauseState.Unlock()
+
+	if northdPauseState.timer != nil {
+		northdPauseState.timer.Reset(duration)
+		return nil
+	}
+
+	_, err = NorthdCtl(s, "pause")
+	if err != nil {
+		return fmt.Errorf("failed to pause ovn-northd: %w", err)
+	}
+
+	northdPauseState.timer = time.AfterFunc(duration, func() {
+		logger.Warnf("ovn-northd was paused for more than %s, resuming.", duration)
+		err := ResumeNorthd(s)
+		if err != nil {
+			logger.Errorf("Failed to resume ovn-northd: %s", err)
+		}
+	})
+
+	return nil
+}
+
+// ResumeNorthd resumes local ovn-northd paused by PauseNorthd. Calling it when ovn-northd is not paused
+// has no effect.
+func ResumeNorthd(s *state.State) error {
+	northdPauseState.Lock()
+	defer northdPauseState.Unlock()
+
+	if northdPauseState.timer == nil {
+		return nil
+	}
+
+	northdPauseState.timer.Stop()
+	northdPauseState.timer = nil
+
+	// Held back ovn-northd was paused before and has to stay paused.
+	if northdKeepPaused(s) {
+		return nil
+	}
+
+	_, err := NorthdCtl(s, "resume")
+	if err != nil {
+		return fmt.Errorf("failed to resume ovn-northd: %w", err)
+	}
+
+	return nil
+}
+
+// compactDatabase triggers compaction of local clustered OVN database identified by "dbType".
+func compactDatabase(s *state.State, dbType OvsdbType) error {
+	dbSpec, err := newOvsdbSpec(dbType)
+	if err != nil {
+		return err
+	}
+
+	ctlSock, err := ovsdbControlSock(dbType)
+	if err != nil {
+		return err
+	}
+
+	// Compaction of a large database takes longer than usual unixctl commands.
+	_, err = AppCtlWithTimeout(s, ctlSock, maxQuiesceDuration, "ovsdb-server/compact", dbSpec.Name)
+	if err != nil {
+		return fmt.Errorf("failed to compact %s: %w", dbSpec.Name, err)
+	}
+
+	return nil
+}
+
+// SnapshotDatabases creates crash-consistent copy of local OVN central databases. Services are quiesced
+// for the duration of the copy. The snapshot is stored in a new backup directory, alongside backups created
+// by cleanupPaths, and its path is returned.
+func SnapshotDatabases(s *state.State) (string, error) {
+	err := Quiesce(s, maxQuiesceDuration)
+	if err != nil {
+		return "", err
+	}
+
+	backupPath := filepath.Join(backupRoot(s), backupDirName(time.Now()))
+	relPath, err := filepath.Rel(paths.Root(), paths.CentralDBDir())
+	if err == nil {
+		err = copyDir(paths.CentralDBDir(), filepath.Join(backupPath, relPath))
+	}
+
+	resumeErr := Unquiesce(s)
+	if err != nil {
+		return "", errors.Join(fmt.Errorf("failed to snapshot OVN databases: %w", err), resumeErr)
+	}
+
+	if resumeErr != nil {
+		return "", resumeErr
+	}
+
+	logger.Infof("OVN databases snapshot created in %s", backupPath)
+	return backupPath, nil
+}
+
+// copyDir recursively copies regular files and directories from "src" to "dst", preserving their permissions.
+func copyDir(src string, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(dst, relPath)
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return os.MkdirAll(target, info.Mode().Perm())
+		}
+
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		return copyFile(path, target, info.Mode().Perm())
+	})
+}
+
+// copyFile copies content of the file "src" into a new file "dst" with permissions "mode".
+func copyFile(src string, dst string, mode fs.FileMode) error {
+	source, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	destination, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+	defer destination.Close()
+
+	_, err = io.Copy(destination, source)
+	if err != nil {
+		return err
+	}
+
+	return destination.Sync()
+}
@@ -0,0 +1,249 @@
+package ovn
+
+import (
+	"fmt"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared"
+	"github.com/lxc/lxd/shared/api"
+	"github.com/lxc/lxd/shared/logger"
+
+	microovnClient "github.com/canonical/microovn/microovn/client"
+	"github.com/canonical/microovn/microovn/ovn/paths"
+)
+
+// RebuildSB replaces OVN Southbound database with a new, empty, raft cluster seeded on this member.
+// Content of the SB database is derived from NB database, so ovn-northd repopulates it once it reconnects,
+// and ovn-controllers register their chassis again when they reconnect. This function performs following
+// steps:
+//   - stops local OVN central service, including ovn-northd
+//   - moves current SB database file aside (".old" suffix) and creates new single-server SB cluster
+//   - starts OVN central and records ID of the new SB cluster
+//   - restarts local OVN chassis, if present, so that it registers with the new SB database
+//
+// Rebuild is refused while SB database servers of other central members are connected to the SB cluster, as
+// they would keep serving the old SB cluster next to the new one. Other central members must join the new cluster with
+// RejoinSB afterwards.
+func RebuildSB(s *state.State) error {
+	centralActive, err := localServiceActive(s, "central")
+	if err != nil {
+		return fmt.Errorf("failed to query local services: %w", err)
+	}
+
+	if !centralActive {
+		return api.StatusErrorf(http.StatusBadRequest, "SB database rebuild requires local 'central' service")
+	}
+
+	alive, err := remoteSBServersAlive(s)
+	if err != nil {
+		return err
+	}
+
+	if len(alive) > 0 {
+		return api.StatusErrorf(http.StatusConflict, "SB database servers of members %s are still connected to the SB cluster, stop their 'central' service before the rebuild", strings.Join(alive, ", "))
+	}
+
+	chassis, err := listSBChassis(s)
+	if err != nil {
+		logger.Warnf("Failed to list chassis registered in OVN SB database: %s", err)
+	} else {
+		logger.Infof("Rebuilding OVN SB database, %d chassis will register again after reconnecting", len(chassis))
+	}
+
+	address, err := serviceAddress(s)
+	if err != nil {
+		return err
+	}
+
+	addr, err := netip.ParseAddr(address)
+	if err != nil {
+		return fmt.Errorf("failed to parse member address: %w", err)
+	}
+
+	muHook.Lock()
+	defer muHook.Unlock()
+
+	err = snapStop("central", false)
+	if err != nil {
+		return fmt.Errorf("failed to stop OVN central: %w", err)
+	}
+
+	err = moveDatabaseFileAside(OvsdbTypeSBLocal)
+	if err != nil {
+		return err
+	}
+
+	_, err = shared.RunCommandContext(
+		s.Context,
+		"ovsdb-tool",
+		"create-cluster",
+		paths.OvnSBDatabaseFile(),
+		paths.OvnSBSchemaFile(),
+		raftAddress(addr, OvsdbTypeSBLocal),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create new SB database: %w", err)
+	}
+
+	err = startCentralWithSB(s)
+	if err != nil {
+		return err
+	}
+
+	err = forgetClusterID(s, OvsdbTypeSBLocal)
+	if err != nil {
+		return err
+	}
+
+	err = recordClusterIDs(s)
+	if err != nil {
+		return fmt.Errorf("failed to record ID of the new SB cluster: %w", err)
+	}
+
+	return restartLocalChassis(s)
+}
+
+// remoteSBServersAlive returns names of other central members whose SB database servers are running and
+// connected to the SB cluster. Members that can't be reached are considered to be down.
+func remoteSBServersAlive(s *state.State) ([]string, error) {
+	centrals, err := centralMembers(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query central services: %w", err)
+	}
+
+	leader, err := s.Leader()
+	if err != nil {
+		return nil, err
+	}
+
+	var alive []string
+	for _, member := range centrals {
+		if member == s.Name() {
+			continue
+		}
+
+		status, err := microovnClient.GetStatus(s.Context, leader.UseTarget(member))
+		if err != nil {
+			continue
+		}
+
+		if status.Central.SBState == DBStateJoined {
+			alive = append(alive, member)
+		}
+	}
+
+	return alive, nil
+}
+
+// RejoinSB makes this member follow SB database rebuilt by RebuildSB on the member "seed". Local SB database
+// file is moved aside (".old" suffix) and replaced by a new server that joins the raft cluster on the "seed"
+// member. Local OVN chassis, if present, is restarted to register with the new SB database.
+func RejoinSB(s *state.State, seed string) error {
+	centralActive, err := localServiceActive(s, "central")
+	if err != nil {
+		return fmt.Errorf("failed to query local services: %w", err)
+	}
+
+	if !centralActive || seed == s.Name() {
+		muHook.Lock()
+		defer muHook.Unlock()
+
+		return restartLocalChassis(s)
+	}
+
+	addresses, err := memberServiceAddresses(s)
+	if err != nil {
+		return err
+	}
+
+	seedAddr, ok := addresses[seed]
+	if !ok {
+		return fmt.Errorf("remote couldn't be found for %q", seed)
+	}
+
+	address, err := serviceAddress(s)
+	if err != nil {
+		return err
+	}
+
+	addr, err := netip.ParseAddr(address)
+	if err != nil {
+		return fmt.Errorf("failed to parse member address: %w", err)
+	}
+
+	muHook.Lock()
+	defer muHook.Unlock()
+
+	err = snapStop("central", false)
+	if err != nil {
+		return fmt.Errorf("failed to stop OVN central: %w", err)
+	}
+
+	err = moveDatabaseFileAside(OvsdbTypeSBLocal)
+	if err != nil {
+		return err
+	}
+
+	_, err = shared.RunCommandContext(
+		s.Context,
+		"ovsdb-tool",
+		"join-cluster",
+		paths.OvnSBDatabaseFile(),
+		"OVN_Southbound",
+		raftAddress(addr, OvsdbTypeSBLocal),
+		raftAddress(seedAddr, OvsdbTypeSBLocal),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to join new SB cluster: %w", err)
+	}
+
+	err = startCentralWithSB(s)
+	if err != nil {
+		return err
+	}
+
+	return restartLocalChassis(s)
+}
+
+// startCentralWithSB starts OVN central service and waits until local SB database connects to its cluster.
+func startCentralWithSB(s *state.State) error {
+	err := snapStart("central", false)
+	if err != nil {
+		return fmt.Errorf("failed to start OVN central: %w", err)
+	}
+
+	sbDatabase, err := newOvsdbSpec(OvsdbTypeSBLocal)
+	if err != nil {
+		return err
+	}
+
+	err = waitForDBState(s, sbDatabase, OvsdbConnected, defaultDBConnectWait)
+	if err != nil {
+		return err
+	}
+
+	// SB listen configuration is stored in the database itself.
+	return updateOvnListenConfig(s)
+}
+
+// restartLocalChassis restarts OVN chassis service, if it runs on this member.
+func restartLocalChassis(s *state.State) error {
+	switchActive, err := localServiceActive(s, "switch")
+	if err != nil {
+		return fmt.Errorf("failed to query local services: %w", err)
+	}
+
+	if !switchActive {
+		return nil
+	}
+
+	err = snapRestart("chassis")
+	if err != nil {
+		return fmt.Errorf("failed to restart OVN chassis: %w", err)
+	}
+
+	return nil
+}
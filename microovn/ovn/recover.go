@@ -0,0 +1,244 @@
+package ovn
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"os"
+	"path/filepath"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared"
+	"github.com/lxc/lxd/shared/api"
+	"github.com/lxc/lxd/shared/logger"
+
+	"github.com/canonical/microovn/microovn/database"
+	"github.com/canonical/microovn/microovn/ovn/paths"
+)
+
+// Recover reconstitutes OVN NB and SB databases on this member after data of every central member was
+// lost, using database files from the backup "fromBackup". It's the disaster recovery counterpart of
+// Bootstrap. Backup is either a name of a backup directory created by cleanupPaths, located in the
+// directory configured by ConfigKeyBackupPath, or an absolute path to such directory. This function
+// performs following steps:
+//   - stops local OVN central service
+//   - moves current database files aside (".old" suffix) and creates new single-server NB and SB clusters
+//     with the data from the backup. If the backup does not contain SB database, empty one is created and
+//     ovn-northd repopulates it from NB.
+//   - removes "central" service records of other members, as their databases belong to the lost clusters
+//   - starts OVN central and records IDs of the new clusters
+//   - reapplies static routes stored by EnsureStaticRoutes
+//
+// Recovery is refused if the local NB database is connected to a cluster with quorum. Other members have to
+// refresh their configuration afterwards, so that surviving chassis connect to the recovered SB database.
+// Other members can enable the "central" service again once the recovered cluster is running.
+func Recover(s *state.State, fromBackup string) error {
+	centralActive, err := localServiceActive(s, "central")
+	if err != nil {
+		return fmt.Errorf("failed to query local services: %w", err)
+	}
+
+	if !centralActive {
+		return api.StatusErrorf(http.StatusBadRequest, "recovery requires local 'central' service")
+	}
+
+	inQuorum, err := databaseInQuorum(s, OvsdbTypeNBLocal)
+	if err == nil && inQuorum {
+		return api.StatusErrorf(http.StatusConflict, "NB database is connected to a cluster with quorum, recovery is not needed")
+	}
+
+	sources, err := recoverySources(s, fromBackup)
+	if err != nil {
+		return err
+	}
+
+	address, err := serviceAddress(s)
+	if err != nil {
+		return err
+	}
+
+	addr, err := netip.ParseAddr(address)
+	if err != nil {
+		return fmt.Errorf("failed to parse member address: %w", err)
+	}
+
+	muHook.Lock()
+	defer muHook.Unlock()
+
+	logger.Infof("Recovering OVN databases from backup '%s'", fromBackup)
+	err = snapStop("central", false)
+	if err != nil {
+		return fmt.Errorf("failed to stop OVN central: %w", err)
+	}
+
+	for _, dbType := range []OvsdbType{OvsdbTypeNBLocal, OvsdbTypeSBLocal} {
+		err = restoreRaftDatabase(s, dbType, sources[dbType], addr)
+		if err != nil {
+			return err
+		}
+	}
+
+	var snapshot *servicesSnapshot
+	err = s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		serviceName := "central"
+		services, err := database.GetServices(ctx, tx, database.ServiceFilter{Service: &serviceName})
+		if err != nil {
+			return err
+		}
+
+		for _, srv := range services {
+			if srv.Member == s.Name() {
+				continue
+			}
+
+			err = database.DeleteService(ctx, tx, srv.Member, srv.Service)
+			if err != nil {
+				return err
+			}
+
+			logger.Infof("Removed record of 'central' service on member '%s'", srv.Member)
+		}
+
+		snapshot, err = servicesSnapshotTx(ctx, tx, s)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove central service records of other members: %w", err)
+	}
+
+	err = generateEnvironment(s, snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to generate the daemon configuration: %w", err)
+	}
+
+	err = snapStart("central", false)
+	if err != nil {
+		return fmt.Errorf("failed to start OVN central: %w", err)
+	}
+
+	for _, dbType := range []OvsdbType{OvsdbTypeNBLocal, OvsdbTypeSBLocal} {
+		dbSpec, err := newOvsdbSpec(dbType)
+		if err != nil {
+			return err
+		}
+
+		err = waitForDBState(s, dbSpec, OvsdbConnected, defaultDBConnectWait)
+		if err != nil {
+			return err
+		}
+
+		err = forgetClusterID(s, dbType)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = recordClusterIDs(s)
+	if err != nil {
+		return fmt.Errorf("failed to record IDs of the recovered clusters: %w", err)
+	}
+
+	err = updateOvnListenConfig(s)
+	if err != nil {
+		return err
+	}
+
+	err = ReapplyStaticRoutes(s)
+	if err != nil {
+		logger.Warnf("Failed to reapply static routes after recovery: %s", err)
+	}
+
+	return restartLocalChassis(s)
+}
+
+// recoverySources returns paths to NB and SB database files in the backup "fromBackup". SB database is
+// omitted if the backup does not contain it, NB database is required. Databases whose schema is not
+// compatible with the installed OVN are rejected, see checkBackupSchema.
+func recoverySources(s *state.State, fromBackup string) (map[OvsdbType]string, error) {
+	if fromBackup == "" {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "backup to recover from must be specified")
+	}
+
+	backupPath := fromBackup
+	if !filepath.IsAbs(backupPath) {
+		if filepath.Base(backupPath) != backupPath {
+			return nil, api.StatusErrorf(http.StatusBadRequest, "backup must be a name of backup directory or an absolute path")
+		}
+
+		backupPath = filepath.Join(backupRoot(s), backupPath)
+	}
+
+	sources := map[OvsdbType]string{}
+	for _, dbType := range []OvsdbType{OvsdbTypeNBLocal, OvsdbTypeSBLocal} {
+		source, err := backupDatabaseFile(backupPath, dbType)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = os.Stat(source)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) && dbType == OvsdbTypeSBLocal {
+				logger.Warnf("Backup '%s' does not contain SB database, empty one will be created", fromBackup)
+				continue
+			}
+
+			return nil, api.StatusErrorf(http.StatusBadRequest, "failed to find database in backup '%s': %s", fromBackup, err)
+		}
+
+		err = checkBackupSchema(s, dbType, source)
+		if err != nil {
+			return nil, api.StatusErrorf(http.StatusConflict, "backup '%s' can't be restored: %s", fromBackup, err)
+		}
+
+		sources[dbType] = source
+	}
+
+	return sources, nil
+}
+
+// restoreRaftDatabase replaces local database of "dbType" with a new single-server raft cluster, whose raft
+// server listens on address "addr", that contains data from the database file "source". Source can be
+// either clustered or standalone database. Empty "source" is accepted only for the SB database, which is
+// then created empty. Original file is kept with ".old" suffix.
+func restoreRaftDatabase(s *state.State, dbType OvsdbType, source string, addr netip.Addr) error {
+	dbFile, err := databaseFile(dbType)
+	if err != nil {
+		return err
+	}
+
+	seed := source
+	if seed == "" {
+		if dbType != OvsdbTypeSBLocal {
+			return fmt.Errorf("no source to restore database '%s' from", dbFile)
+		}
+
+		seed = paths.OvnSBSchemaFile()
+	} else {
+		// "db-is-clustered" exits with non-zero status for standalone databases.
+		_, err = shared.RunCommandContext(s.Context, "ovsdb-tool", "db-is-clustered", source)
+		if err == nil {
+			seed = dbFile + ".standalone"
+			_, err = shared.RunCommandContext(s.Context, "ovsdb-tool", "cluster-to-standalone", seed, source)
+			if err != nil {
+				return fmt.Errorf("failed to convert '%s' to standalone database: %w", source, err)
+			}
+
+			defer func() { _ = os.Remove(seed) }()
+		}
+	}
+
+	err = moveDatabaseFileAside(dbType)
+	if err != nil {
+		return err
+	}
+
+	_, err = shared.RunCommandContext(s.Context, "ovsdb-tool", "create-cluster", dbFile, seed, raftAddress(addr, dbType))
+	if err != nil {
+		return fmt.Errorf("failed to create cluster database '%s': %w", dbFile, err)
+	}
+
+	return nil
+}
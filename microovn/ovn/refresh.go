@@ -2,6 +2,7 @@ package ovn
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/lxc/lxd/shared/logger"
 	"github.com/pkg/errors"
@@ -23,6 +24,10 @@ func Refresh(s *state.State) error {
 }
 
 func refresh(s *state.State) error {
+	if skipInMaintenance(s, "configuration refresh") {
+		return nil
+	}
+
 	// Make sure we don't have any other hooks firing.
 	muHook.Lock()
 	defer muHook.Unlock()
@@ -34,43 +39,37 @@ func refresh(s *state.State) error {
 	}
 
 	// Query existing local services.
-	hasCentral, err := localServiceActive(s, "central")
+	snapshot, err := loadServicesSnapshot(s)
 	if err != nil {
 		return err
 	}
 
-	hasSwitch, err := localServiceActive(s, "switch")
-	if err != nil {
-		return err
-	}
+	hasCentral := snapshot.LocalServiceActive("central")
+	hasSwitch := snapshot.LocalServiceActive("switch")
 
 	// Generate the configuration.
-	err = generateEnvironment(s)
+	err = generateEnvironment(s, snapshot)
 	if err != nil {
 		return fmt.Errorf("Failed to generate the daemon configuration: %w", err)
 	}
 
-	// Enable OVN central (if needed).
+	// Reload OVN central (if needed).
 	if hasCentral {
-		err = snapRestart("central")
+		_, err = ReloadService(s, "central")
 		if err != nil {
-			return fmt.Errorf("Failed to start OVN central: %w", err)
+			return fmt.Errorf("Failed to reload OVN central: %w", err)
 		}
 	}
 
-	// Enable OVN chassis.
+	// Reload OVN chassis.
 	if hasSwitch {
-		err = snapRestart("chassis")
+		_, err = ReloadService(s, "chassis")
 		if err != nil {
-			return fmt.Errorf("Failed to restart OVN chassis: %w", err)
+			return fmt.Errorf("Failed to reload OVN chassis: %w", err)
 		}
 
 		// Reconfigure OVS to use OVN.
-		sbConnect, err := connectString(s, 6642)
-		if err != nil {
-			return fmt.Errorf("Failed to get OVN SB connect string: %w", err)
-		}
-
+		sbConnect := snapshot.ConnectString(networkProtocol(s), 6642)
 		_, err = VSCtl(
 			s,
 			"set", "open_vswitch", ".",
@@ -96,27 +95,87 @@ func updateOvnListenConfig(s *state.State) error {
 	}
 
 	protocol := networkProtocol(s)
-	_, err = NBCtl(
-		s,
-		"--no-leader-only",
-		fmt.Sprintf("--db=unix:%s", nbDB),
-		"set-connection",
-		fmt.Sprintf("p%s:6641:[::]", protocol),
-	)
+	nbArgs := []string{"--no-leader-only", fmt.Sprintf("--db=unix:%s", nbDB), "set-connection"}
+
+	sbArgs := []string{"--no-leader-only", fmt.Sprintf("--db=unix:%s", sbDB), "set-connection"}
+
+	readOnly, err := nbReadOnly(s)
+	if err != nil {
+		return err
+	}
+
+	// Quiesced databases are read-only for all remote clients, see Quiesce.
+	quiesced, err := databasesQuiesced(s)
+	if err != nil {
+		return err
+	}
+
+	if readOnly || quiesced {
+		nbArgs = append(nbArgs, "read-only")
+	}
+
+	if quiesced {
+		sbArgs = append(sbArgs, "read-only")
+	}
+
+	_, err = NBCtl(s, append(nbArgs, fmt.Sprintf("p%s:6641:[::]", protocol))...)
 	if err != nil {
 		return errors.Errorf("Error setting ovn NB connection string: %s", err)
 	}
 
-	_, err = SBCtl(
-		s,
-		"--no-leader-only",
-		fmt.Sprintf("--db=unix:%s", sbDB),
-		"set-connection",
-		fmt.Sprintf("p%s:6642:[::]", protocol),
-	)
+	_, err = SBCtl(s, append(sbArgs, fmt.Sprintf("p%s:6642:[::]", protocol))...)
 	if err != nil {
 		return errors.Errorf("Error setting ovn SB connection string: %s", err)
 	}
 
+	// "set-connection" replaces existing connections, so the probe intervals and backoffs have to be applied
+	// again.
+	connectionOptions := []struct {
+		ctl    func(*state.State, ...string) (string, error)
+		column string
+		key    string
+	}{
+		{ctl: localNBCtl, column: "inactivity_probe", key: ConfigKeyNBInactivityProbe},
+		{ctl: localSBCtl, column: "inactivity_probe", key: ConfigKeySBInactivityProbe},
+		{ctl: localNBCtl, column: "max_backoff", key: ConfigKeyNBMaxBackoff},
+		{ctl: localSBCtl, column: "max_backoff", key: ConfigKeySBMaxBackoff},
+	}
+
+	for _, option := range connectionOptions {
+		err = setConnectionOption(s, option.ctl, option.column, option.key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setConnectionOption sets "column" of every connection that the OVN database, accessed via "ctl" function,
+// listens on, to the value configured under "key". If the option is not set, the column is reset to the OVSDB
+// default.
+func setConnectionOption(s *state.State, ctl func(*state.State, ...string) (string, error), column string, key string) error {
+	value, err := GetConfig(s, key)
+	if err != nil {
+		return err
+	}
+
+	output, err := ctl(s, "--data=bare", "--no-headings", "--columns=_uuid", "list", "Connection")
+	if err != nil {
+		return fmt.Errorf("failed to list database connections: %w", err)
+	}
+
+	for _, uuid := range strings.Fields(output) {
+		if value == "" {
+			_, err = ctl(s, "clear", "Connection", uuid, column)
+		} else {
+			_, err = ctl(s, "set", "Connection", uuid, fmt.Sprintf("%s=%s", column, value))
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to apply %s: %w", key, err)
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,65 @@
+package ovn
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/canonical/microcluster/client"
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/logger"
+
+	microovnClient "github.com/canonical/microovn/microovn/client"
+)
+
+// RegenerateEnvironment recomputes ovn.env of this member from the current cluster state and rewrites it,
+// if its content changed. Services are not restarted.
+func RegenerateEnvironment(s *state.State) error {
+	muHook.Lock()
+	defer muHook.Unlock()
+
+	err := generateEnvironment(s, nil)
+	if err != nil {
+		return fmt.Errorf("failed to generate the daemon configuration: %w", err)
+	}
+
+	return nil
+}
+
+// RegenerateEnvironmentAll regenerates ovn.env on every cluster member, including this one. Failure on
+// any member does not stop regeneration on the others. Members that failed are listed in the returned
+// error, along with their individual errors.
+func RegenerateEnvironmentAll(s *state.State) error {
+	var mu sync.Mutex
+	failed := []string{}
+
+	err := RegenerateEnvironment(s)
+	if err != nil {
+		logger.Errorf("Failed to regenerate ovn.env on cluster member %q: %s", s.Name(), err)
+		failed = append(failed, fmt.Sprintf("%s: %s", s.Name(), err))
+	}
+
+	err = QueryCluster(s, nil, func(ctx context.Context, c *client.Client) error {
+		err := microovnClient.RegenerateEnvironment(ctx, c)
+		if err != nil {
+			clientURL := c.URL()
+			logger.Errorf("Failed to regenerate ovn.env on cluster member %q: %s", clientURL.String(), err)
+
+			mu.Lock()
+			failed = append(failed, fmt.Sprintf("%s: %s", clientURL.String(), err))
+			mu.Unlock()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to regenerate ovn.env on some members: %s", strings.Join(failed, "; "))
+	}
+
+	return nil
+}
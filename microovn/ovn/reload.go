@@ -0,0 +1,139 @@
+package ovn
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/logger"
+
+	"github.com/canonical/microovn/microovn/ovn/paths"
+)
+
+// ReloadMethod describes how ReloadService applied configuration to a service.
+type ReloadMethod string
+
+const (
+	ReloadMethodLive    ReloadMethod = "live"    // Configuration was applied to the running service
+	ReloadMethodRestart ReloadMethod = "restart" // Service was restarted
+)
+
+// serviceAppliedEnvFiles maps snap services to copies of ovn.env that their start scripts make when the
+// service starts. The copy holds environment that the running daemons were started with.
+var serviceAppliedEnvFiles = map[string]func() string{
+	"central": func() string { return filepath.Join(paths.CentralRuntimeDir(), "ovn.env.applied") },
+	"chassis": func() string { return filepath.Join(paths.ChassisRuntimeDir(), "ovn.env.applied") },
+	"switch":  func() string { return filepath.Join(paths.SwitchRuntimeDir(), "ovn.env.applied") },
+}
+
+// ReloadService applies current configuration to the local snap "service" ("central", "chassis" or
+// "switch"), preferring to reconfigure the running daemons over restarting them. Restart drops connections
+// of the service, e.g. ovn-controller reconnects to OVN SB and recomputes its flows.
+//
+// Options passed to the daemons through ovn.env can't be changed in place, so the service is restarted if
+// content of ovn.env differs from the one the service was started with, if any of its daemons is not
+// running, or if the live reconfiguration fails. Returned ReloadMethod reports which path was taken.
+func ReloadService(s *state.State, service string) (ReloadMethod, error) {
+	_, ok := serviceAppliedEnvFiles[service]
+	if !ok {
+		return "", fmt.Errorf("unknown service '%s'", service)
+	}
+
+	live, err := liveReloadPossible(s, service)
+	if err != nil {
+		logger.Warnf("Failed to determine whether %s service can be reloaded in place: %s", service, err)
+	}
+
+	if live {
+		err = reconfigureService(s, service)
+		if err == nil {
+			logger.Infof("Configuration of %s service was applied without restart", service)
+			return ReloadMethodLive, nil
+		}
+
+		logger.Warnf("Failed to reconfigure running %s service, restarting it: %s", service, err)
+	}
+
+	err = snapRestart(service)
+	if err != nil {
+		return "", fmt.Errorf("failed to restart %s service: %w", service, err)
+	}
+
+	logger.Infof("Service %s was restarted to apply its configuration", service)
+	return ReloadMethodRestart, nil
+}
+
+// liveReloadPossible returns true if all daemons of the "service" are running and the service was started
+// with the current content of ovn.env. Externally managed ovn-northd is not considered to be a daemon of the
+// "central" service.
+func liveReloadPossible(s *state.State, service string) (bool, error) {
+	external, err := northdExternal(s)
+	if err != nil {
+		return false, err
+	}
+
+	for _, pidFile := range serviceDaemonPidFiles[service]() {
+		if external && filepath.Base(pidFile) == "ovn-northd.pid" {
+			continue
+		}
+
+		if !daemonRunning(pidFile) {
+			return false, nil
+		}
+	}
+
+	applied, err := os.ReadFile(serviceAppliedEnvFiles[service]())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	current, err := os.ReadFile(paths.OvnEnvFile())
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(applied, current), nil
+}
+
+// daemonRunning returns true if process with PID stored in "pidFile" exists.
+func daemonRunning(pidFile string) bool {
+	content, err := os.ReadFile(pidFile)
+	if err != nil {
+		return false
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(content)))
+	if err != nil || pid <= 1 {
+		return false
+	}
+
+	_, err = os.Stat(fmt.Sprintf("/proc/%d", pid))
+	return err == nil
+}
+
+// reconfigureService applies configuration stored in the shared database to the running "service".
+func reconfigureService(s *state.State, service string) error {
+	switch service {
+	case "central":
+		err := updateOvnListenConfig(s)
+		if err != nil {
+			return err
+		}
+
+		return applyNorthdThreads(s)
+	case "chassis":
+		return updateChassisConfig(s)
+	case "switch":
+		return updateSwitchConfig(s)
+	}
+
+	return fmt.Errorf("unknown service '%s'", service)
+}
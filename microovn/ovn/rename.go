@@ -0,0 +1,162 @@
+package ovn
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/api"
+	"github.com/lxc/lxd/shared/logger"
+
+	"github.com/canonical/microovn/microovn/database"
+)
+
+// RenameMember reconciles OVN identifiers of this member after it was renamed from "oldName" to "newName".
+// Member names are owned by microcluster and rows in the services table reference members by their ID, so
+// the services follow the rename automatically. This function updates the rest of the state that is keyed
+// by the member name:
+//   - per-member configuration options and member labels stored in the shared database
+//   - chassis "system-id" in the local OVS database (stale chassis record is removed from OVN SB)
+//   - CN of the local service certificates, if TLS is used
+//   - ovn.env file
+//
+// OVN central raft servers are identified by their addresses and IDs, not by member names, so the raft
+// cluster membership does not need to change. This function must be executed on the renamed member.
+func RenameMember(s *state.State, oldName string, newName string) error {
+	if s.Name() != newName {
+		return api.StatusErrorf(http.StatusBadRequest, "member must be renamed to '%s' before OVN identifiers can be updated (current name: '%s')", newName, s.Name())
+	}
+
+	if oldName == newName {
+		return nil
+	}
+
+	muHook.Lock()
+	defer muHook.Unlock()
+
+	err := renameMemberConfig(s, oldName, newName)
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := loadServicesSnapshot(s)
+	if err != nil {
+		return err
+	}
+
+	centralActive := snapshot.LocalServiceActive("central")
+	switchActive := snapshot.LocalServiceActive("switch")
+
+	var errs []error
+	if networkProtocol(s) == "ssl" {
+		for _, service := range localCertificateServices(centralActive, switchActive) {
+			err = GenerateNewServiceCertificate(s, service, CertificateTypeServer)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to reissue %s certificate: %w", service, err))
+			}
+		}
+	}
+
+	err = generateEnvironment(s, snapshot)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to generate the daemon configuration: %w", err))
+	}
+
+	if centralActive {
+		err = snapRestart("central")
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to restart OVN central: %w", err))
+		}
+	}
+
+	if switchActive {
+		err = renameChassis(s, oldName, newName, centralActive)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// renameMemberConfig moves per-member configuration options stored in the shared database from "oldName"
+// to "newName".
+func renameMemberConfig(s *state.State, oldName string, newName string) error {
+	oldSuffix := "@" + oldName
+	err := s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		items, err := database.GetConfigItems(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			if !strings.HasSuffix(item.Key, oldSuffix) {
+				continue
+			}
+
+			key := strings.TrimSuffix(item.Key, oldSuffix)
+			if !configKeys[key].perMember && !strings.HasPrefix(key, labelRecordPrefix) {
+				continue
+			}
+
+			newKey := fmt.Sprintf("%s@%s", key, newName)
+			_, err = database.CreateConfigItem(ctx, tx, database.ConfigItem{Key: newKey, Value: item.Value})
+			if err != nil {
+				return err
+			}
+
+			err = database.DeleteConfigItem(ctx, tx, item.Key)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to rename per-member configuration options: %w", err)
+	}
+
+	return nil
+}
+
+// renameChassis changes "system-id" of the local OVN chassis to "newName" and restarts ovn-controller, so it
+// registers in OVN SB under the new name. Chassis record registered under "oldName" is removed if the local
+// member runs the "central" service, otherwise it's left to be cleaned up by RemoveOrphanedChassis.
+func renameChassis(s *state.State, oldName string, newName string, centralActive bool) error {
+	err := storeSystemID(s, newName, newName)
+	if err != nil {
+		return err
+	}
+
+	err = deleteSystemID(s, oldName)
+	if err != nil {
+		return fmt.Errorf("failed to remove chassis system-id of '%s': %w", oldName, err)
+	}
+
+	err = setChassisExternalID(s, "system-id", newName)
+	if err != nil {
+		return fmt.Errorf("failed to update chassis system-id: %w", err)
+	}
+
+	err = snapRestart("chassis")
+	if err != nil {
+		return fmt.Errorf("failed to restart OVN chassis: %w", err)
+	}
+
+	if !centralActive {
+		logger.Infof("Chassis '%s' can be removed from OVN SB by orphaned chassis cleanup.", oldName)
+		return nil
+	}
+
+	_, err = localSBCtl(s, "--if-exists", "chassis-del", oldName)
+	if err != nil {
+		return fmt.Errorf("failed to remove chassis '%s' from OVN SB: %w", oldName, err)
+	}
+
+	return nil
+}
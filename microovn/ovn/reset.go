@@ -0,0 +1,54 @@
+package ovn
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/api"
+	"github.com/lxc/lxd/shared/logger"
+
+	"github.com/canonical/microovn/microovn/ovn/paths"
+)
+
+// ResetNode returns member, whose attempt to join the cluster failed, to a clean pre-join state, so the join
+// can be retried without removing the snap. All OVN services are stopped and disabled, and local OVN state
+// (raft databases, certificates, ovn.env, OVS database with chassis registration, logs) is backed up and
+// removed with cleanupPaths. System-id file configured outside of MicroOVN data directory is kept, so the
+// chassis identity survives the reset.
+//
+// Reset is refused if the member is an active cluster member, such member has to leave the cluster instead.
+func ResetNode(s *state.State) error {
+	if s.Database != nil && s.Database.IsOpen() {
+		return api.StatusErrorf(http.StatusConflict, "member is an active cluster member, remove it from the cluster instead")
+	}
+
+	muHook.Lock()
+	defer muHook.Unlock()
+
+	var errs []error
+	for _, service := range snapServices {
+		logger.Infof("Stopping %s service", service)
+		err := snapStop(service, true)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop %s service: %w", service, err))
+		}
+	}
+
+	// Shared database is not available, so the configured backup path can't be read.
+	logger.Info("Cleaning up runtime and data directories.")
+	err := cleanupPaths(paths.Root())
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	invalidateNetworkProtocol()
+
+	if len(errs) > 0 {
+		return fmt.Errorf("member reset completed with errors: %w", errors.Join(errs...))
+	}
+
+	logger.Info("Member was reset to pre-join state.")
+	return nil
+}
@@ -0,0 +1,301 @@
+package ovn
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/api"
+	"github.com/lxc/lxd/shared/logger"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/database"
+)
+
+// staticRoutesRecordPrefix is a prefix of keys under which desired static routes are stored in the config DB
+// table. Full key has format "<prefix><router_name>".
+const staticRoutesRecordPrefix = "static-routes."
+
+// staticRouteKey returns string that uniquely identifies the route within a logical router.
+func staticRouteKey(route types.StaticRoute) string {
+	return fmt.Sprintf("%s|%s|%s|%s", route.Policy, route.Prefix, route.NextHop, route.OutputPort)
+}
+
+// normalizeStaticRoute verifies that the "route" can be applied to OVN NB database and returns it in the
+// form that OVN stores it in, so that it can be compared with existing routes.
+func normalizeStaticRoute(route types.StaticRoute) (types.StaticRoute, error) {
+	if route.Policy == "" {
+		route.Policy = "dst-ip"
+	}
+
+	if route.Policy != "dst-ip" && route.Policy != "src-ip" {
+		return route, fmt.Errorf("invalid route policy '%s'", route.Policy)
+	}
+
+	prefix, err := netip.ParsePrefix(route.Prefix)
+	if err != nil {
+		addr, addrErr := netip.ParseAddr(route.Prefix)
+		if addrErr != nil {
+			return route, fmt.Errorf("invalid route prefix '%s'", route.Prefix)
+		}
+
+		prefix = netip.PrefixFrom(addr, addr.BitLen())
+	}
+
+	prefix = prefix.Masked()
+	if prefix.IsSingleIP() {
+		route.Prefix = prefix.Addr().String()
+	} else {
+		route.Prefix = prefix.String()
+	}
+
+	if route.NextHop != "discard" {
+		nextHop, err := netip.ParseAddr(route.NextHop)
+		if err != nil {
+			return route, fmt.Errorf("invalid route next hop '%s'", route.NextHop)
+		}
+
+		if nextHop.Is4() != prefix.Addr().Is4() {
+			return route, fmt.Errorf("address family of next hop '%s' does not match prefix '%s'", route.NextHop, route.Prefix)
+		}
+
+		route.NextHop = nextHop.String()
+	} else if route.OutputPort != "" {
+		return route, fmt.Errorf("discard route to '%s' can't have output port", route.Prefix)
+	}
+
+	return route, nil
+}
+
+// EnsureStaticRoutes makes sure that static routes of logical router "router" in OVN Northbound database
+// match exactly the desired set "routes". Routes with the same prefix and policy, but different next hops,
+// are added as ECMP routes. If the router already has the desired routes, the database is not modified, so
+// re-running this function does not cause duplicates or unnecessary churn. All changes are applied in a
+// single transaction.
+//
+// Desired routes are also stored in the shared MicroOVN database, so that they can be reapplied by
+// ReapplyStaticRoutes after OVN databases are recovered. Empty set of routes removes the stored record.
+//
+// This function must be executed on a member that runs the "central" service and that can reach the
+// Northbound cluster leader.
+func EnsureStaticRoutes(s *state.State, router string, routes []types.StaticRoute) error {
+	if router == "" {
+		return api.StatusErrorf(http.StatusBadRequest, "logical router name can't be empty")
+	}
+
+	desired := map[string]types.StaticRoute{}
+	for _, route := range routes {
+		route, err := normalizeStaticRoute(route)
+		if err != nil {
+			return api.StatusErrorf(http.StatusBadRequest, "%s", err)
+		}
+
+		desired[staticRouteKey(route)] = route
+	}
+
+	err := ensureNBLeaderReachable(s)
+	if err != nil {
+		return err
+	}
+
+	err = applyStaticRoutes(s, router, desired)
+	if err != nil {
+		return err
+	}
+
+	return storeStaticRoutes(s, router, desired)
+}
+
+// applyStaticRoutes replaces static routes of logical router "router" with "desired" routes, keyed by
+// staticRouteKey, unless the router already has exactly these routes.
+func applyStaticRoutes(s *state.State, router string, desired map[string]types.StaticRoute) error {
+	current, err := ListStaticRoutes(s, router)
+	if err != nil {
+		return err
+	}
+
+	if len(current) == len(desired) {
+		inSync := true
+		for _, route := range current {
+			_, ok := desired[staticRouteKey(route)]
+			if !ok {
+				inSync = false
+				break
+			}
+		}
+
+		if inSync {
+			return nil
+		}
+	}
+
+	keys := make([]string, 0, len(desired))
+	nextHops := map[string]int{}
+	for key, route := range desired {
+		keys = append(keys, key)
+		nextHops[route.Policy+"|"+route.Prefix]++
+	}
+	sort.Strings(keys)
+
+	args := []string{"lr-route-del", router}
+	for _, key := range keys {
+		route := desired[key]
+		args = append(args, "--", fmt.Sprintf("--policy=%s", route.Policy))
+		if nextHops[route.Policy+"|"+route.Prefix] > 1 {
+			args = append(args, "--ecmp")
+		}
+
+		args = append(args, "lr-route-add", router, route.Prefix, route.NextHop)
+		if route.OutputPort != "" {
+			args = append(args, route.OutputPort)
+		}
+	}
+
+	_, err = localNBCtl(s, args...)
+	if err != nil {
+		return fmt.Errorf("failed to apply static routes of logical router '%s': %w", router, err)
+	}
+
+	return nil
+}
+
+// ListStaticRoutes returns static routes currently configured on logical router "router".
+func ListStaticRoutes(s *state.State, router string) ([]types.StaticRoute, error) {
+	output, err := localNBCtl(s, "--data=bare", "--no-headings", "get", "Logical_Router", router, "static_routes")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get static routes of logical router '%s': %w", router, err)
+	}
+
+	routes := []types.StaticRoute{}
+	for _, uuid := range strings.Fields(output) {
+		output, err = localNBCtl(s, "--format=csv", "--data=bare", "--no-headings", "--columns=ip_prefix,nexthop,output_port,policy", "list", "Logical_Router_Static_Route", uuid)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get static route %s: %w", uuid, err)
+		}
+
+		records, err := csv.NewReader(strings.NewReader(output)).ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse static route %s: %w", uuid, err)
+		}
+
+		for _, record := range records {
+			if len(record) != 4 {
+				continue
+			}
+
+			route := types.StaticRoute{Prefix: record[0], NextHop: record[1], OutputPort: record[2], Policy: record[3]}
+			if route.Policy == "" {
+				route.Policy = "dst-ip"
+			}
+
+			routes = append(routes, route)
+		}
+	}
+
+	return routes, nil
+}
+
+// storeStaticRoutes stores "desired" static routes of logical router "router" in the shared database.
+func storeStaticRoutes(s *state.State, router string, desired map[string]types.StaticRoute) error {
+	recordKey := staticRoutesRecordPrefix + router
+	routes := make([]types.StaticRoute, 0, len(desired))
+	for _, route := range desired {
+		routes = append(routes, route)
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		return staticRouteKey(routes[i]) < staticRouteKey(routes[j])
+	})
+
+	value, err := json.Marshal(routes)
+	if err != nil {
+		return err
+	}
+
+	return s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		exists, err := database.ConfigItemExists(ctx, tx, recordKey)
+		if err != nil {
+			return err
+		}
+
+		if len(routes) == 0 {
+			if !exists {
+				return nil
+			}
+
+			return database.DeleteConfigItem(ctx, tx, recordKey)
+		}
+
+		if exists {
+			return database.UpdateConfigItem(ctx, tx, recordKey, database.ConfigItem{Key: recordKey, Value: string(value)})
+		}
+
+		_, err = database.CreateConfigItem(ctx, tx, database.ConfigItem{Key: recordKey, Value: string(value)})
+		return err
+	})
+}
+
+// ReapplyStaticRoutes applies static routes stored by EnsureStaticRoutes to every logical router that exists
+// in OVN Northbound database. Routers that don't exist are skipped. Failure to apply routes of any router
+// does not stop the others, but it is reported in the returned error.
+func ReapplyStaticRoutes(s *state.State) error {
+	stored := map[string][]types.StaticRoute{}
+	err := s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		items, err := database.GetConfigItems(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			if !strings.HasPrefix(item.Key, staticRoutesRecordPrefix) {
+				continue
+			}
+
+			routes := []types.StaticRoute{}
+			err = json.Unmarshal([]byte(item.Value), &routes)
+			if err != nil {
+				return fmt.Errorf("failed to parse stored static routes '%s': %w", item.Key, err)
+			}
+
+			stored[strings.TrimPrefix(item.Key, staticRoutesRecordPrefix)] = routes
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	failed := []string{}
+	for router, routes := range stored {
+		output, err := localNBCtl(s, "--data=bare", "--no-headings", "--columns=name", "find", "Logical_Router", fmt.Sprintf("name=%s", strconv.Quote(router)))
+		if err != nil || strings.TrimSpace(output) == "" {
+			logger.Infof("Skipping static routes of logical router '%s', it does not exist", router)
+			continue
+		}
+
+		desired := map[string]types.StaticRoute{}
+		for _, route := range routes {
+			desired[staticRouteKey(route)] = route
+		}
+
+		err = applyStaticRoutes(s, router, desired)
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %s", router, err))
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to reapply static routes: %s", strings.Join(failed, "; "))
+	}
+
+	return nil
+}
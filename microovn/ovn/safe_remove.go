@@ -0,0 +1,107 @@
+package ovn
+
+import (
+	"fmt"
+
+	"github.com/canonical/microcluster/state"
+
+	microovnClient "github.com/canonical/microovn/microovn/client"
+)
+
+// SafeToRemove reports, for every member that runs the "central" service, whether it can be removed at this
+// moment without OVN NB or SB cluster losing quorum. Member is healthy if both its NB and SB database servers
+// are joined to their clusters. Members that can't be reached are considered unhealthy.
+//
+// Removal of a member shrinks the clusters, and removal of a healthy member also reduces number of healthy
+// servers. Member is safe to remove if healthy servers still form a majority of the shrunk clusters. The
+// last member with the "central" service is never safe to remove. If ConfigKeyExpectedCentrals declares more
+// central members than there currently are, e.g. during a rolling rebuild, the majority is computed from the
+// expected count instead.
+func SafeToRemove(s *state.State) (map[string]bool, error) {
+	centrals, err := centralMembers(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query central services: %w", err)
+	}
+
+	clusterSize, err := quorumClusterSize(s, len(centrals))
+	if err != nil {
+		return nil, err
+	}
+
+	healthy := map[string]bool{}
+	healthyCount := 0
+	for _, member := range centrals {
+		healthy[member] = centralMemberHealthy(s, member)
+		if healthy[member] {
+			healthyCount++
+		}
+	}
+
+	safe := make(map[string]bool, len(centrals))
+	for _, member := range centrals {
+		remaining := clusterSize - 1
+		remainingHealthy := healthyCount
+		if healthy[member] {
+			remainingHealthy--
+		}
+
+		safe[member] = len(centrals) > 1 && remainingHealthy > remaining/2
+	}
+
+	return safe, nil
+}
+
+// centralMemberHealthy returns true if both OVN NB and SB database servers of the "central" service running
+// on "member" are joined to their clusters.
+func centralMemberHealthy(s *state.State, member string) bool {
+	var nbState, sbState string
+	if member == s.Name() {
+		var err error
+		nbState, err = DBState(s, OvsdbTypeNBLocal)
+		if err != nil {
+			return false
+		}
+
+		sbState, err = DBState(s, OvsdbTypeSBLocal)
+		if err != nil {
+			return false
+		}
+	} else {
+		leader, err := s.Leader()
+		if err != nil {
+			return false
+		}
+
+		status, err := microovnClient.GetStatus(s.Context, leader.UseTarget(member))
+		if err != nil {
+			return false
+		}
+
+		nbState = status.Central.NBState
+		sbState = status.Central.SBState
+	}
+
+	return nbState == DBStateJoined && sbState == DBStateJoined
+}
+
+// expectedCentralCount returns number of members expected to run the "central" service, as declared by
+// ConfigKeyExpectedCentrals. Zero is returned if it's not declared.
+func expectedCentralCount(s *state.State) (int, error) {
+	return getConfigInt(s, ConfigKeyExpectedCentrals, 0)
+}
+
+// quorumClusterSize returns size of OVN NB/SB clusters that quorum calculations should be based on. It's the
+// "actual" number of central members, or the expected number from ConfigKeyExpectedCentrals if it's larger,
+// as members missing from the services table (e.g. being rebuilt) still count towards the quorum.
+func quorumClusterSize(s *state.State, actual int) (int, error) {
+	expected, err := expectedCentralCount(s)
+	if err != nil {
+		return 0, err
+	}
+
+	if expected > actual {
+		return expected, nil
+	}
+
+	return actual, nil
+}
@@ -0,0 +1,230 @@
+package ovn
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/api"
+	"github.com/lxc/lxd/shared/logger"
+
+	microovnClient "github.com/canonical/microovn/microovn/client"
+	"github.com/canonical/microovn/microovn/database"
+)
+
+const minHACentralMembers = 3 // Smallest number of central members whose raft clusters tolerate failure of one member
+
+// centralMembers returns names of MicroOVN cluster members that run the "central" service.
+func centralMembers(s *state.State) ([]string, error) {
+	snapshot, err := loadServicesSnapshot(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot.ServiceMembers("central"), nil
+}
+
+// ScaleCentral reduces number of members that run the "central" service to "target". Members to demote
+// are chosen so that current NB/SB raft leaders, preferred leaders and this member, which coordinates the
+// operation, are kept whenever possible. Members are demoted one at a time with DemoteCentral, and only
+// while both NB and SB clusters have a leader, so the clusters keep quorum at every step. Once done, ovn.env
+// is regenerated on every member and every OVN chassis is pointed at the remaining "central" members. Names
+// of demoted members are returned.
+//
+// Targets below minHACentralMembers, which don't tolerate a member failure, are refused unless "force" is
+// true. This function must be executed on a member that runs the "central" service.
+func ScaleCentral(s *state.State, target int, force bool) ([]string, error) {
+	snapshot, err := loadServicesSnapshot(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if !snapshot.LocalServiceActive("central") {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "scaling of central service must be executed on a member that runs it")
+	}
+
+	centrals := snapshot.ServiceMembers("central")
+
+	if target < 1 {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "at least one member has to run the 'central' service")
+	}
+
+	if target >= len(centrals) {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "cluster has %d central members, target %d would not shrink it", len(centrals), target)
+	}
+
+	if target < minHACentralMembers && !force {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "%d central members can't tolerate failure of a member, at least %d are required for high availability", target, minHACentralMembers)
+	}
+
+	demote, err := pickCentralDemotions(s, centrals, len(centrals)-target)
+	if err != nil {
+		return nil, err
+	}
+
+	leader, err := s.Leader()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client for cluster leader: %w", err)
+	}
+
+	demoted := []string{}
+	for _, member := range demote {
+		for _, dbType := range []OvsdbType{OvsdbTypeNBLocal, OvsdbTypeSBLocal} {
+			status, err := getClusterStatus(s, dbType)
+			if err != nil || !status.HasLeader() {
+				return demoted, fmt.Errorf("OVN database cluster lost its leader, stopping before demotion of '%s'", member)
+			}
+		}
+
+		logger.Infof("Removing 'central' service from member '%s'", member)
+		if member == s.Name() {
+			err = DemoteCentral(s)
+		} else {
+			err = microovnClient.DemoteCentral(s.Context, leader.UseTarget(member))
+		}
+
+		if err != nil {
+			return demoted, fmt.Errorf("failed to remove 'central' service from member '%s': %w", member, err)
+		}
+
+		demoted = append(demoted, member)
+	}
+
+	err = RegenerateEnvironmentAll(s)
+	if err != nil {
+		return demoted, err
+	}
+
+	return demoted, ReconfigureChassisRemoteAll(s)
+}
+
+// pickCentralDemotions chooses "count" members from "centrals" that should stop running the "central"
+// service. Members are ranked by the disruption their demotion causes: leader of NB or SB database, this
+// member and preferred leaders are demoted only if there are not enough other members. This member is
+// always placed at the end of the returned list.
+func pickCentralDemotions(s *state.State, centrals []string, count int) ([]string, error) {
+	preferred, err := preferredLeaders(s)
+	if err != nil {
+		return nil, err
+	}
+
+	leaders := map[string]bool{}
+	for _, dbType := range []OvsdbType{OvsdbTypeNBLocal, OvsdbTypeSBLocal} {
+		leader, err := leaderMember(s, dbType)
+		if err != nil {
+			return nil, err
+		}
+
+		leaders[leader] = true
+	}
+
+	cost := func(member string) int {
+		c := 0
+		if leaders[member] {
+			c += 4
+		}
+
+		if member == s.Name() {
+			c += 2
+		}
+
+		if isPreferredLeader(member, preferred) {
+			c++
+		}
+
+		return c
+	}
+
+	candidates := append([]string{}, centrals...)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return cost(candidates[i]) < cost(candidates[j])
+	})
+
+	// Raft status is queried through the local database servers before each demotion, so this member, if
+	// chosen, has to be demoted last.
+	demote := candidates[:count]
+	sort.SliceStable(demote, func(i, j int) bool {
+		return demote[j] == s.Name() && demote[i] != s.Name()
+	})
+
+	return demote, nil
+}
+
+// DemoteCentral stops "central" service on this member while the member stays in the cluster. Local NB
+// and SB servers gracefully leave their raft clusters, central service is stopped and disabled and the
+// database files are moved aside (".old" suffix). The service is then removed from the services table and
+// ovn.env, along with "ovn-remote" of the local chassis, is updated to point at the remaining members.
+func DemoteCentral(s *state.State) error {
+	centralActive, err := localServiceActive(s, "central")
+	if err != nil {
+		return fmt.Errorf("failed to query local services: %w", err)
+	}
+
+	if !centralActive {
+		return nil
+	}
+
+	centralCount, err := centralMemberCount(s)
+	if err != nil {
+		return fmt.Errorf("failed to count central members: %w", err)
+	}
+
+	if centralCount <= 1 {
+		return api.StatusErrorf(http.StatusBadRequest, "member '%s' is the last member running the 'central' service", s.Name())
+	}
+
+	muHook.Lock()
+	defer muHook.Unlock()
+
+	for _, dbType := range []OvsdbType{OvsdbTypeNBLocal, OvsdbTypeSBLocal} {
+		err = leaveRaftCluster(s, dbType)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = snapStop("central", true)
+	if err != nil {
+		return fmt.Errorf("failed to stop OVN central: %w", err)
+	}
+
+	for _, dbType := range []OvsdbType{OvsdbTypeNBLocal, OvsdbTypeSBLocal} {
+		err = moveDatabaseFileAside(dbType)
+		if err != nil {
+			return err
+		}
+	}
+
+	var snapshot *servicesSnapshot
+	err = s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		err := database.DeleteService(ctx, tx, s.Name(), "central")
+		if err != nil {
+			return err
+		}
+
+		snapshot, err = servicesSnapshotTx(ctx, tx, s)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove central service record: %w", err)
+	}
+
+	err = generateEnvironment(s, snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to generate the daemon configuration: %w", err)
+	}
+
+	if !snapshot.LocalServiceActive("switch") {
+		return nil
+	}
+
+	err = setChassisExternalID(s, "ovn-remote", snapshot.ConnectString(networkProtocol(s), 6642))
+	if err != nil {
+		return fmt.Errorf("failed to update OVS's 'ovn-remote' configuration: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package ovn
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/logger"
+
+	"github.com/canonical/microovn/microovn/database"
+)
+
+// serviceAddress returns IP address under which OVN services of this member are reachable. It's used in
+// connection strings, raft addresses and certificate SANs. Address configured in ConfigKeyServiceAddress
+// takes precedence, otherwise management address of the member is used.
+func serviceAddress(s *state.State) (string, error) {
+	addr, err := GetConfig(s, ConfigKeyServiceAddress)
+	if err != nil {
+		return "", err
+	}
+
+	if addr != "" {
+		return addr, nil
+	}
+
+	return s.Address().Hostname(), nil
+}
+
+// memberServiceAddresses returns IP addresses under which OVN services of every cluster member are
+// reachable, keyed by member name. See serviceAddress.
+func memberServiceAddresses(s *state.State) (map[string]netip.Addr, error) {
+	var addresses map[string]netip.Addr
+	err := s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		addresses, err = memberServiceAddressesTx(ctx, tx, s)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service addresses of members: %w", err)
+	}
+
+	return addresses, nil
+}
+
+// memberServiceAddressesTx returns service addresses of every cluster member read within an already open
+// transaction "tx". See memberServiceAddresses.
+func memberServiceAddressesTx(ctx context.Context, tx *sql.Tx, s *state.State) (map[string]netip.Addr, error) {
+	addresses := map[string]netip.Addr{}
+	for name, remote := range s.Remotes().RemotesByName() {
+		addresses[name] = remote.Address.Addr().Unmap()
+	}
+
+	items, err := database.GetConfigItems(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		if !strings.HasPrefix(item.Key, ConfigKeyServiceAddress+"@") {
+			continue
+		}
+
+		member := strings.TrimPrefix(item.Key, ConfigKeyServiceAddress+"@")
+
+		addr, err := netip.ParseAddr(item.Value)
+		if err != nil {
+			logger.Warnf("Ignoring invalid service address '%s' of member '%s'", item.Value, member)
+			continue
+		}
+
+		addresses[member] = addr.Unmap()
+	}
+
+	return addresses, nil
+}
+
+// applyServiceAddress moves local OVN NB and SB raft servers to the service address "addr", if they use
+// a different one. Must be called with muHook held, on a member that runs the "central" service.
+func applyServiceAddress(s *state.State, addr netip.Addr) error {
+	addr = addr.Unmap()
+	for _, dbType := range []OvsdbType{OvsdbTypeNBLocal, OvsdbTypeSBLocal} {
+		status, err := getClusterStatus(s, dbType)
+		if err != nil {
+			return err
+		}
+
+		if status.Address != raftAddress(addr, dbType) {
+			logger.Infof("Moving OVN raft servers from %s to service address %s", status.Address, addr)
+			return moveCentralAddress(s, addr)
+		}
+	}
+
+	return nil
+}
+
+// validateLocalIPAddress verifies that the value is an IP address assigned to a network interface of this
+// host.
+func validateLocalIPAddress(value string) error {
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return fmt.Errorf("expected IP address, got '%s'", value)
+	}
+
+	ifaceAddrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return fmt.Errorf("failed to list local addresses: %w", err)
+	}
+
+	for _, ifaceAddr := range ifaceAddrs {
+		ipNet, ok := ifaceAddr.(*net.IPNet)
+		if !ok {
+			continue
+		}
+
+		local, ok := netip.AddrFromSlice(ipNet.IP)
+		if ok && local.Unmap() == addr.Unmap() {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("address '%s' is not assigned to any local interface", value)
+}
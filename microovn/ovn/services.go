@@ -4,8 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"net/http"
 
 	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/api"
+	"github.com/lxc/lxd/shared/logger"
 
 	"github.com/canonical/microovn/microovn/api/types"
 	"github.com/canonical/microovn/microovn/database"
@@ -36,3 +39,102 @@ func ListServices(s *state.State) (types.Services, error) {
 
 	return services, nil
 }
+
+// RemoveServiceEntry removes record of "service" on cluster "member" from the services table, without
+// touching the member itself. It is meant to fix inconsistencies of the table, e.g. a "central" record
+// of a member that never became central. Removal of the last "central" record is refused. If the service
+// seems to be actually running, a warning is logged, because the record is likely correct. Removal of
+// "central" record changes connection strings, so ovn.env is regenerated and OVN chassis are pointed at the
+// remaining "central" members afterwards.
+func RemoveServiceEntry(s *state.State, member string, service string) error {
+	snapshot, err := loadServicesSnapshot(s)
+	if err != nil {
+		return err
+	}
+
+	members := snapshot.ServiceMembers(service)
+	found := false
+	for _, name := range members {
+		if name == member {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return api.StatusErrorf(http.StatusNotFound, "member '%s' has no '%s' service record", member, service)
+	}
+
+	if service == "central" && len(members) <= 1 {
+		return api.StatusErrorf(http.StatusBadRequest, "refusing to remove the last 'central' service record")
+	}
+
+	running, err := serviceEntryRunning(s, snapshot, member, service)
+	if err != nil {
+		logger.Warnf("Failed to check whether '%s' service runs on member '%s': %s", service, member, err)
+	} else if running {
+		logger.Warnf("Removing record of '%s' service on member '%s', although the service seems to be running", service, member)
+	}
+
+	err = s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		return database.DeleteService(ctx, tx, member, service)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove '%s' service record of member '%s': %w", service, member, err)
+	}
+
+	logger.Infof("Removed record of '%s' service on member '%s'", service, member)
+	if service != "central" {
+		return nil
+	}
+
+	err = RegenerateEnvironmentAll(s)
+	if err != nil {
+		return err
+	}
+
+	return ReconfigureChassisRemoteAll(s)
+}
+
+// serviceEntryRunning returns true if "service" seems to run on cluster "member". Central service is
+// considered running if the member's raft server is part of OVN NB cluster, chassis and switch services
+// if the member owns a chassis registered in OVN SB database. Checks require local "central" service,
+// false is returned without it.
+func serviceEntryRunning(s *state.State, snapshot *servicesSnapshot, member string, service string) (bool, error) {
+	if !snapshot.LocalServiceActive("central") {
+		return false, nil
+	}
+
+	if service == "central" {
+		addr, ok := snapshot.addresses[member]
+		if !ok {
+			return false, nil
+		}
+
+		status, err := getClusterStatus(s, OvsdbTypeNBLocal)
+		if err != nil {
+			return false, err
+		}
+
+		for _, server := range status.Servers {
+			if server.Address == raftAddress(addr, OvsdbTypeNBLocal) {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	chassis, err := ListChassis(s)
+	if err != nil {
+		return false, err
+	}
+
+	for _, info := range chassis {
+		if info.Member == member {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,151 @@
+package ovn
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/canonical/microcluster/state"
+
+	"github.com/canonical/microovn/microovn/database"
+)
+
+// servicesSnapshot is a point-in-time copy of the services table, along with addresses of cluster members.
+// It allows deriving several pieces of information about the cluster from a single database transaction.
+type servicesSnapshot struct {
+	member    string                // Name of the local member
+	services  []database.Service    // All records of the services table
+	addresses map[string]netip.Addr // Service addresses of the cluster members, keyed by member name
+}
+
+// loadServicesSnapshot reads the whole services table in a single transaction and returns its snapshot.
+func loadServicesSnapshot(s *state.State) (*servicesSnapshot, error) {
+	var snapshot *servicesSnapshot
+	err := s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		snapshot, err = servicesSnapshotTx(ctx, tx, s)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query services: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// ensureServicesSnapshot returns "snapshot" if it's not nil, otherwise a fresh snapshot is loaded. It allows
+// functions to optionally accept a snapshot already held by their caller.
+func ensureServicesSnapshot(s *state.State, snapshot *servicesSnapshot) (*servicesSnapshot, error) {
+	if snapshot != nil {
+		return snapshot, nil
+	}
+
+	return loadServicesSnapshot(s)
+}
+
+// servicesSnapshotTx returns snapshot of the services table read within an already open transaction "tx".
+func servicesSnapshotTx(ctx context.Context, tx *sql.Tx, s *state.State) (*servicesSnapshot, error) {
+	services, err := database.GetServices(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses, err := memberServiceAddressesTx(ctx, tx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	return &servicesSnapshot{member: s.Name(), services: services, addresses: addresses}, nil
+}
+
+// LocalServices returns names of services that run on the local member.
+func (snap *servicesSnapshot) LocalServices() []string {
+	services := []string{}
+	for _, srv := range snap.services {
+		if srv.Member == snap.member {
+			services = append(services, srv.Service)
+		}
+	}
+
+	return services
+}
+
+// LocalServiceActive returns true if "service" runs on the local member.
+func (snap *servicesSnapshot) LocalServiceActive(service string) bool {
+	for _, srv := range snap.LocalServices() {
+		if srv == service {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ServiceMembers returns names of members that run "service", in the order of the services table.
+func (snap *servicesSnapshot) ServiceMembers(service string) []string {
+	members := []string{}
+	for _, srv := range snap.services {
+		if srv.Service == service {
+			members = append(members, srv.Member)
+		}
+	}
+
+	return members
+}
+
+// CentralAddresses returns service addresses of members that run the "central" service. Members whose
+// address is not known are skipped.
+func (snap *servicesSnapshot) CentralAddresses() []netip.Addr {
+	addresses := []netip.Addr{}
+	for _, member := range snap.ServiceMembers("central") {
+		addr, ok := snap.addresses[member]
+		if ok {
+			addresses = append(addresses, addr)
+		}
+	}
+
+	return addresses
+}
+
+// ConnectString returns OVN connection string that lists servers on "port" of all "central" members, using
+// "protocol". Empty string is returned if no member runs the "central" service.
+func (snap *servicesSnapshot) ConnectString(protocol string, port int) string {
+	endpoints := []string{}
+	for _, addr := range snap.CentralAddresses() {
+		endpoints = append(endpoints, fmt.Sprintf("%s:%s", protocol, netip.AddrPortFrom(addr, uint16(port)).String()))
+	}
+
+	return strings.Join(endpoints, ",")
+}
+
+// InitialCentral returns address of the "central" member whose raft servers are used to join OVN NB and
+// SB clusters. Other member is preferred, so that a member without local database files, e.g. after its
+// address changed, joins the existing cluster instead of creating new one. "localAddr" is returned if no
+// member runs the "central" service yet, or if only the local member does and its address is not known.
+func (snap *servicesSnapshot) InitialCentral(localAddr string) (string, error) {
+	centrals := snap.ServiceMembers("central")
+	if len(centrals) == 0 {
+		return localAddr, nil
+	}
+
+	member := centrals[0]
+	for _, candidate := range centrals {
+		if candidate != snap.member {
+			member = candidate
+			break
+		}
+	}
+
+	addr, ok := snap.addresses[member]
+	if !ok && member == snap.member {
+		return localAddr, nil
+	}
+
+	if !ok {
+		return "", fmt.Errorf("Remote couldn't be found for %q", member)
+	}
+
+	return bracketAddress(addr.String()), nil
+}
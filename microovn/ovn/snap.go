@@ -1,9 +1,20 @@
 package ovn
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/lxc/lxd/shared"
+	"github.com/lxc/lxd/shared/logger"
+
+	"github.com/canonical/microovn/microovn/ovn/paths"
 )
 
 func snapStart(service string, enable bool) error {
@@ -24,9 +35,53 @@ func snapStart(service string, enable bool) error {
 	return nil
 }
 
+const snapStopTimeout = 60 * time.Second // Maximum time to wait for a snap service to stop
+
+// serviceDaemonPidFiles maps snap services to pid files of all daemons they run. They are used to kill
+// daemons of a service that fails to stop in time.
+var serviceDaemonPidFiles = map[string]func() []string{
+	"central": func() []string {
+		return []string{
+			filepath.Join(paths.CentralRuntimeDir(), "ovn-northd.pid"),
+			filepath.Join(paths.CentralRuntimeDir(), "ovnnb_db.pid"),
+			filepath.Join(paths.CentralRuntimeDir(), "ovnsb_db.pid"),
+		}
+	},
+	"chassis": func() []string {
+		return []string{filepath.Join(paths.ChassisRuntimeDir(), "ovn-controller.pid")}
+	},
+	"switch": func() []string {
+		return []string{
+			filepath.Join(paths.SwitchRuntimeDir(), "ovs-vswitchd.pid"),
+			filepath.Join(paths.SwitchRuntimeDir(), "ovsdb-server.pid"),
+		}
+	},
+}
+
+// snapStopResult describes outcome of an attempt to stop snap service.
+type snapStopResult struct {
+	Output   string // Output of the stop command
+	TimedOut bool   // Service did not stop within the timeout
+	Killed   []int  // PIDs of service daemons that were killed after the timeout
+}
+
 // snapStop stops specified snap service. Service can be optionally also disabled, ensuring
-// that it won't be automatically started on system reboot.
+// that it won't be automatically started on system reboot. Service that does not stop within
+// snapStopTimeout is killed if it is also being disabled, see snapStopWithTimeout.
 func snapStop(service string, disable bool) error {
+	result, err := snapStopWithTimeout(service, disable, snapStopTimeout, disable)
+	if err != nil {
+		logger.Warnf("Failed to stop %s service (timed out: %t, killed: %v): %s", service, result.TimedOut, result.Killed, err)
+	}
+
+	return err
+}
+
+// snapStopWithTimeout stops specified snap service, optionally disabling it, and waits at most "timeout"
+// for the stop to finish. If the service does not stop in time and "force" is true, its daemons are
+// killed and the stop is attempted once more. Returned result describes what happened, even if an error
+// is returned.
+func snapStopWithTimeout(service string, disable bool, timeout time.Duration, force bool) (*snapStopResult, error) {
 	args := []string{
 		"stop",
 		fmt.Sprintf("microovn.%s", service),
@@ -36,9 +91,101 @@ func snapStop(service string, disable bool) error {
 		args = append(args, "--disable")
 	}
 
-	_, err := shared.RunCommand("snapctl", args...)
+	result := &snapStopResult{}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	output, err := shared.RunCommandContext(ctx, "snapctl", args...)
+	result.Output = output
+	if err == nil {
+		return result, nil
+	}
+
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return result, err
+	}
+
+	result.TimedOut = true
+	if !force {
+		return result, fmt.Errorf("service %s did not stop within %s: %w", service, timeout, err)
+	}
+
+	logger.Warnf("Service %s did not stop within %s, killing its daemons", service, timeout)
+	result.Killed = killServiceDaemons(service)
+
+	retryCtx, retryCancel := context.WithTimeout(context.Background(), timeout)
+	defer retryCancel()
+
+	output, err = shared.RunCommandContext(retryCtx, "snapctl", args...)
+	result.Output = output
 	if err != nil {
-		return err
+		return result, fmt.Errorf("service %s did not stop after its daemons were killed: %w", service, err)
+	}
+
+	return result, nil
+}
+
+// killServiceDaemons sends SIGKILL to all running daemons of the snap "service" and returns their PIDs.
+func killServiceDaemons(service string) []int {
+	killed := []int{}
+	pidFiles, ok := serviceDaemonPidFiles[service]
+	if !ok {
+		return killed
+	}
+
+	for _, pidFile := range pidFiles() {
+		content, err := os.ReadFile(pidFile)
+		if err != nil {
+			continue
+		}
+
+		pid, err := strconv.Atoi(strings.TrimSpace(string(content)))
+		if err != nil || pid <= 1 {
+			continue
+		}
+
+		err = syscall.Kill(pid, syscall.SIGKILL)
+		if err != nil {
+			logger.Warnf("Failed to kill process %d from '%s': %s", pid, pidFile, err)
+			continue
+		}
+
+		killed = append(killed, pid)
+	}
+
+	return killed
+}
+
+// snapServices lists snap services that MicroOVN manages with snapctl.
+var snapServices = []string{"central", "chassis", "switch"}
+
+// verifySnapServices confirms that every service from snapServices is defined in the snap. Misnamed or missing
+// service would otherwise only show up as failures of snapctl calls, some of which (e.g. when the member
+// leaves the cluster) are tolerated and merely logged.
+func verifySnapServices() error {
+	output, err := shared.RunCommand("snapctl", "services")
+	if err != nil {
+		return fmt.Errorf("failed to list snap services: %w", err)
+	}
+
+	defined := map[string]bool{}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) > 0 {
+			defined[fields[0]] = true
+		}
+	}
+
+	missing := []string{}
+	for _, service := range snapServices {
+		name := fmt.Sprintf("microovn.%s", service)
+		if !defined[name] {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("snap services %s are not defined in the snap", strings.Join(missing, ", "))
 	}
 
 	return nil
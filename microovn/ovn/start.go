@@ -8,6 +8,19 @@ import (
 	"github.com/canonical/microcluster/state"
 )
 
+// startMonitors starts background loops of the local member. Each of them is started at most once, so it's
+// safe to call this function from Start as well as from Bootstrap and Join, which run on members whose
+// database wasn't open yet when the daemon started.
+func startMonitors(s *state.State) {
+	startCompactionMonitor(s)
+	startStatsdEmitter(s)
+	startStatsCollector(s)
+	startLeadershipMonitor(s)
+	startNorthdStandbyMonitor(s)
+	startHealthServer(s)
+	startDBWatchdog(s)
+}
+
 // Start will update the existing OVN central and OVS switch configs.
 func Start(s *state.State) error {
 	// Skip if the database isn't ready.
@@ -21,27 +34,68 @@ func Start(s *state.State) error {
 		return err
 	}
 
-	// Re-generate the configuration.
-	err = generateEnvironment(s)
+	err = verifySnapServices()
 	if err != nil {
-		return fmt.Errorf("Failed to generate the daemon configuration: %w", err)
+		logger.Errorf("MicroOVN won't be able to manage OVN services: %s", err)
+	}
+
+	startMonitors(s)
+	checkAddressFamilies(s)
+
+	if skipInMaintenance(s, "reconfiguration of OVN services on start") {
+		return nil
+	}
+
+	snapshot, err := loadServicesSnapshot(s)
+	if err != nil {
+		return err
 	}
 
-	centralActive, err := localServiceActive(s, "central")
+	// Re-generate the configuration.
+	err = generateEnvironment(s, snapshot)
 	if err != nil {
-		return fmt.Errorf("failed to query local services: %w", err)
+		return fmt.Errorf("Failed to generate the daemon configuration: %w", err)
 	}
 
-	if centralActive {
+	if snapshot.LocalServiceActive("central") {
+		err = verifyClusterMembership(s)
+		if err != nil {
+			logger.Errorf("OVN central databases won't be able to join the cluster: %s", err)
+		}
+
+		err = recordClusterIDs(s)
+		if err != nil {
+			logger.Warnf("Failed to record OVN cluster IDs: %s", err)
+		}
+
 		err = updateOvnListenConfig(s)
 		if err != nil {
 			logger.Warnf("Failed to update OVN listening configs. There might be connectivity issues.")
 		}
+
+		err = applyGlobalOptions(s)
+		if err != nil {
+			logger.Warnf("Failed to apply NB_Global options: %s", err)
+		}
+
+		err = applyNorthdStandby(s)
+		if err != nil {
+			logger.Warnf("Failed to apply ovn-northd standby mode: %s", err)
+		}
+
+		err = applyDBMemoryTrim(s)
+		if err != nil {
+			logger.Warnf("Failed to apply OVN database memory settings: %s", err)
+		}
 	}
 	// Reconfigure OVS to use OVN.
-	sbConnect, err := connectString(s, 6642)
+	sbConnect := snapshot.ConnectString(networkProtocol(s), 6642)
+
+	// Give central services a chance to come up before chassis is reconfigured. OVN chassis
+	// keeps trying to connect on its own, so an unreachable database is not fatal.
+	err = waitForSouthbound(s, sbConnect)
 	if err != nil {
-		return fmt.Errorf("Failed to get OVN SB connect string: %w", err)
+		logger.Warnf("Chassis will keep trying to connect in the background: %s", err)
 	}
 
 	_, err = VSCtl(
@@ -54,5 +108,21 @@ func Start(s *state.State) error {
 		return fmt.Errorf("Failed to update OVS's 'ovn-remote' configuration")
 	}
 
+	err = updateChassisConfig(s)
+	if err != nil {
+		return fmt.Errorf("Failed to apply OVN chassis configuration: %w", err)
+	}
+
+	// Gateway records may already exist for this chassis, e.g. when it rejoins the cluster.
+	err = applyGatewayPriority(s)
+	if err != nil {
+		logger.Warnf("Failed to apply gateway priority: %s", err)
+	}
+
+	err = updateSwitchConfig(s)
+	if err != nil {
+		return fmt.Errorf("Failed to apply OVS database configuration: %w", err)
+	}
+
 	return nil
 }
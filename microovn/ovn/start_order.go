@@ -0,0 +1,148 @@
+package ovn
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/logger"
+)
+
+const startStageWait = 60 // Maximum time (s) to wait for a local service to become ready before its dependents start
+
+// serviceDependency describes service that must be ready before a dependent service is started.
+type serviceDependency struct {
+	service  string // Name of the service that has to be ready
+	anywhere bool   // Service can run on any cluster member, not necessarily the local one
+}
+
+// serviceDependencies maps snap services to services that must be ready before they start. OVN chassis
+// needs local OVS to configure the integration bridge, and reachable OVN SB database to get its flows.
+var serviceDependencies = map[string][]serviceDependency{
+	"chassis": {{service: "switch"}, {service: "central", anywhere: true}},
+}
+
+// serviceReadiness maps services to functions that wait, for a bounded time, until the service is ready
+// to be used by the services that depend on it.
+var serviceReadiness = map[string]func(s *state.State) error{
+	"central": waitForCentralReady,
+	"switch":  waitForSwitchReady,
+}
+
+// startLocalOrdered starts and enables snap services that run on this member according to the services
+// "snapshot", in the order given by serviceDependencies. Before a service is started, every service it
+// depends on has to become ready, otherwise the dependent service is not started and an error is returned.
+// Waiting for each dependency is bounded and is interrupted when the daemon shuts down.
+//
+// Ordering can be enforced only for services that are disabled, e.g. after StopLocal, as snapd starts
+// enabled services on its own. For those, the order is declared in the snap and chassis.start waits for
+// the local OVS database.
+func startLocalOrdered(s *state.State, snapshot *servicesSnapshot) error {
+	order, err := startOrder(snapshot.LocalServices())
+	if err != nil {
+		return err
+	}
+
+	ready := map[string]bool{}
+	for _, service := range order {
+		for _, dependency := range serviceDependencies[service] {
+			if ready[dependency.service] {
+				continue
+			}
+
+			if !dependency.anywhere && !snapshot.LocalServiceActive(dependency.service) {
+				return fmt.Errorf("%s service requires local %s service", service, dependency.service)
+			}
+
+			err = serviceReadiness[dependency.service](s)
+			if err != nil {
+				return fmt.Errorf("%s service not started, %s service is not ready: %w", service, dependency.service, err)
+			}
+
+			ready[dependency.service] = true
+		}
+
+		logger.Infof("Starting local service '%s'", service)
+		err = snapStart(service, true)
+		if err != nil {
+			return fmt.Errorf("failed to start %s service: %w", service, err)
+		}
+	}
+
+	return nil
+}
+
+// startOrder sorts "services" so that every service comes after the local services it depends on.
+// Services without mutual dependencies are ordered by name.
+func startOrder(services []string) ([]string, error) {
+	pending := map[string]bool{}
+	for _, service := range services {
+		pending[service] = true
+	}
+
+	order := make([]string, 0, len(services))
+	for len(pending) > 0 {
+		stage := []string{}
+		for service := range pending {
+			blocked := false
+			for _, dependency := range serviceDependencies[service] {
+				if pending[dependency.service] {
+					blocked = true
+					break
+				}
+			}
+
+			if !blocked {
+				stage = append(stage, service)
+			}
+		}
+
+		if len(stage) == 0 {
+			return nil, fmt.Errorf("services have circular start dependencies")
+		}
+
+		sort.Strings(stage)
+		for _, service := range stage {
+			delete(pending, service)
+		}
+
+		order = append(order, stage...)
+	}
+
+	return order, nil
+}
+
+// waitForCentralReady waits until OVN SB database can be reached by the local chassis. If the "central"
+// service runs on this member, its NB and SB databases have to connect to their clusters first.
+func waitForCentralReady(s *state.State) error {
+	snapshot, err := loadServicesSnapshot(s)
+	if err != nil {
+		return err
+	}
+
+	if snapshot.LocalServiceActive("central") {
+		for _, dbType := range []OvsdbType{OvsdbTypeNBLocal, OvsdbTypeSBLocal} {
+			dbSpec, err := newOvsdbSpec(dbType)
+			if err != nil {
+				return err
+			}
+
+			err = waitForDBState(s, dbSpec, OvsdbConnected, startStageWait)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return waitForSouthbound(s, snapshot.ConnectString(networkProtocol(s), 6642))
+}
+
+// waitForSwitchReady waits until local OVS database accepts connections.
+func waitForSwitchReady(s *state.State) error {
+	dbSpec, err := newOvsdbSpec(OvsdbTypeSwitchLocal)
+	if err != nil {
+		return err
+	}
+
+	return waitForDBState(s, dbSpec, OvsdbConnected, startStageWait)
+}
@@ -0,0 +1,158 @@
+package ovn
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/logger"
+
+	"github.com/canonical/microovn/microovn/ovn/paths"
+)
+
+const statsDisabledPollInterval = time.Minute // How often is ConfigKeyStatsInterval checked while collection is disabled
+const statsMaxFileSize = 10 * bytesInMiB      // Size of the stats file after which it's rotated
+
+// statsCollectorOnce ensures that only one stats collector is running.
+var statsCollectorOnce sync.Once
+
+// statsTarget describes OVN daemon whose statistics are collected by the stats collector.
+type statsTarget struct {
+	name    string                                               // Name of the daemon, used as a name of the stats file
+	service string                                               // Local service that has to run for the daemon to exist
+	ctl     func(s *state.State, args ...string) (string, error) // Command that sends unixctl commands to the daemon
+}
+
+// statsTargets returns list of daemons whose statistics are collected by the stats collector.
+func statsTargets() []statsTarget {
+	return []statsTarget{
+		{name: "ovnnb_db", service: "central", ctl: func(s *state.State, args ...string) (string, error) {
+			return AppCtl(s, paths.OvnNBControlSock(), args...)
+		}},
+		{name: "ovnsb_db", service: "central", ctl: func(s *state.State, args ...string) (string, error) {
+			return AppCtl(s, paths.OvnSBControlSock(), args...)
+		}},
+		{name: "ovn-controller", service: "switch", ctl: ControllerCtl},
+	}
+}
+
+// startStatsCollector starts background goroutine that periodically captures output of "coverage/show" and
+// "memory/show" commands of local OVN daemons and appends it to their stats files in paths.StatsDir. Files
+// that grow over statsMaxFileSize are rotated, keeping one previous file. Collection runs every
+// ConfigKeyStatsInterval seconds and is disabled while the option is not set.
+func startStatsCollector(s *state.State) {
+	statsCollectorOnce.Do(func() {
+		go func() {
+			for {
+				interval, err := getConfigInt(s, ConfigKeyStatsInterval, 0)
+				if err != nil {
+					logger.Warnf("Failed to get stats collection interval: %s", err)
+					interval = 0
+				}
+
+				wait := time.Duration(interval) * time.Second
+				if interval <= 0 {
+					wait = statsDisabledPollInterval
+				}
+
+				select {
+				case <-s.Context.Done():
+					return
+				case <-time.After(wait):
+				}
+
+				if interval <= 0 {
+					continue
+				}
+
+				err = collectStats(s)
+				if err != nil {
+					logger.Warnf("Failed to collect OVN statistics: %s", err)
+				}
+			}
+		}()
+	})
+}
+
+// collectStats appends current coverage and memory statistics of every local OVN daemon to its stats file.
+func collectStats(s *state.State) error {
+	var errs []error
+	for _, target := range statsTargets() {
+		active, err := localServiceActive(s, target.service)
+		if err != nil {
+			return fmt.Errorf("failed to query local services: %w", err)
+		}
+
+		if !active {
+			continue
+		}
+
+		err = appendStats(s, target)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", target.name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// appendStats captures statistics of a single daemon "target" and appends them to its stats file.
+func appendStats(s *state.State, target statsTarget) error {
+	coverage, err := target.ctl(s, "coverage/show")
+	if err != nil {
+		return fmt.Errorf("failed to get coverage statistics: %w", err)
+	}
+
+	memory, err := target.ctl(s, "memory/show")
+	if err != nil {
+		return fmt.Errorf("failed to get memory statistics: %w", err)
+	}
+
+	statsFile := filepath.Join(paths.StatsDir(), fmt.Sprintf("%s.stats", target.name))
+	err = rotateStatsFile(statsFile)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(statsFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open stats file: %w", err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	_, err = fmt.Fprintf(file, "=== %s\n--- memory/show\n%s\n--- coverage/show\n%s\n", time.Now().UTC().Format(time.RFC3339), memory, coverage)
+	if err != nil {
+		return fmt.Errorf("failed to write stats file: %w", err)
+	}
+
+	return nil
+}
+
+// rotateStatsFile moves "statsFile" aside, replacing previously rotated file, if it's larger than
+// statsMaxFileSize.
+func rotateStatsFile(statsFile string) error {
+	info, err := os.Stat(statsFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		return err
+	}
+
+	if info.Size() < statsMaxFileSize {
+		return nil
+	}
+
+	err = os.Rename(statsFile, statsFile+".1")
+	if err != nil {
+		return fmt.Errorf("failed to rotate stats file: %w", err)
+	}
+
+	return nil
+}
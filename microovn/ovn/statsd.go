@@ -0,0 +1,120 @@
+package ovn
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/logger"
+)
+
+const defaultStatsdInterval = 60 // Default interval (s) between metrics pushed to statsd server
+const statsdPrefix = "microovn"
+
+// statsdEmitterOnce ensures that only one statsd emitter is running.
+var statsdEmitterOnce sync.Once
+
+// startStatsdEmitter starts background goroutine that periodically pushes metrics returned by GatherMetrics
+// to the statsd server configured in ConfigKeyStatsdAddress. Configuration is read before each push, so
+// changes take effect without restart. Nothing is sent while the address is not configured.
+func startStatsdEmitter(s *state.State) {
+	statsdEmitterOnce.Do(func() {
+		go func() {
+			for {
+				interval, err := getConfigInt(s, ConfigKeyStatsdInterval, defaultStatsdInterval)
+				if err != nil {
+					interval = defaultStatsdInterval
+				}
+
+				select {
+				case <-s.Context.Done():
+					return
+				case <-time.After(time.Duration(interval) * time.Second):
+				}
+
+				err = pushStatsdMetrics(s)
+				if err != nil {
+					logger.Warnf("Failed to push metrics to statsd: %s", err)
+				}
+			}
+		}()
+	})
+}
+
+// pushStatsdMetrics sends current metrics of the local member as statsd gauges.
+func pushStatsdMetrics(s *state.State) error {
+	address, err := GetConfig(s, ConfigKeyStatsdAddress)
+	if err != nil {
+		return err
+	}
+
+	if address == "" {
+		return nil
+	}
+
+	metrics, err := GatherMetrics(s)
+	if err != nil {
+		return err
+	}
+
+	conn, err := net.Dial("udp", address)
+	if err != nil {
+		return fmt.Errorf("failed to connect to statsd server '%s': %w", address, err)
+	}
+
+	defer func() { _ = conn.Close() }()
+
+	for _, metric := range metrics {
+		_, err = conn.Write([]byte(statsdLine(s.Name(), metric)))
+		if err != nil {
+			return fmt.Errorf("failed to send metric to statsd server '%s': %w", address, err)
+		}
+	}
+
+	return nil
+}
+
+// statsdLine renders the "metric" of the "member" as a statsd gauge. Label values, ordered by label
+// names, are appended to the metric name as additional path segments, e.g.
+// "microovn.node1.database_size_bytes.nb:1024|g".
+func statsdLine(member string, metric Metric) string {
+	segments := []string{statsdPrefix, statsdSegment(member), metric.Name}
+
+	names := make([]string, 0, len(metric.Labels))
+	for name := range metric.Labels {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	for _, name := range names {
+		segments = append(segments, statsdSegment(metric.Labels[name]))
+	}
+
+	name := strings.Join(segments, ".")
+	line := fmt.Sprintf("%s:%s|g", name, strconv.FormatFloat(metric.Value, 'f', -1, 64))
+
+	// Gauge value with a sign is interpreted as a change of the current value, so negative values
+	// need to be sent as a decrement from zero.
+	if metric.Value < 0 {
+		line = fmt.Sprintf("%s:0|g\n%s", name, line)
+	}
+
+	return line
+}
+
+// statsdSegment replaces characters with special meaning in statsd protocol, or in metric paths, by "_".
+func statsdSegment(value string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '.', ':', '|', '@', ' ', '\n':
+			return '_'
+		}
+
+		return r
+	}, value)
+}
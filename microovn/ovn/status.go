@@ -0,0 +1,171 @@
+package ovn
+
+import (
+	"fmt"
+
+	"github.com/canonical/microcluster/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+)
+
+// Status gathers state of OVN components running on this MicroOVN cluster member. Failure of a single probe
+// does not fail the whole status, it's recorded in the Errors field and the fields it was supposed to fill
+// are left empty. Error is returned only if local services can't be determined.
+func Status(s *state.State) (*types.MemberStatus, error) {
+	status := types.MemberStatus{}
+
+	snapshot, err := loadServicesSnapshot(s)
+	if err != nil {
+		return nil, err
+	}
+
+	centralActive := snapshot.LocalServiceActive("central")
+	switchActive := snapshot.LocalServiceActive("switch")
+
+	status.Environment.InSync, status.Environment.Diff, err = VerifyEnvironment(s)
+	if err != nil {
+		recordStatusError(&status, fmt.Errorf("failed to verify ovn.env: %w", err))
+	}
+
+	status.Environment.Stale, err = VerifyEnvApplied(s)
+	if err != nil {
+		recordStatusError(&status, fmt.Errorf("failed to verify configuration of running daemons: %w", err))
+	}
+
+	status.Labels, err = GetMemberLabels(s, s.Name())
+	if err != nil {
+		recordStatusError(&status, err)
+	}
+
+	status.TLS.Protocol = networkProtocol(s)
+	if status.TLS.Protocol == "ssl" {
+		status.TLS.MissingCertificates, err = missingCertificates(centralActive, switchActive)
+		if err != nil {
+			recordStatusError(&status, err)
+		}
+
+		status.TLS.CertificateProblems, err = certChainProblems(s, centralActive, switchActive)
+		if err != nil {
+			recordStatusError(&status, fmt.Errorf("failed to verify certificate chain: %w", err))
+		}
+	}
+
+	if centralActive {
+		status.TLS.ListenProtocol, err = listenProtocol(s)
+		if err != nil {
+			recordStatusError(&status, fmt.Errorf("failed to get OVN NB listening protocol: %w", err))
+		}
+
+		status.Central.NBReadOnly, err = getNBReadOnly(s)
+		if err != nil {
+			recordStatusError(&status, err)
+		}
+
+		northd, err := LocalNorthdStatus(s)
+		if err != nil {
+			recordStatusError(&status, err)
+		} else {
+			if northd.State != NorthdStateExternal {
+				status.Central.NorthdThreads, err = getNorthdThreadCount(s)
+				if err != nil {
+					recordStatusError(&status, fmt.Errorf("failed to get ovn-northd thread count: %w", err))
+				}
+			}
+
+			status.Central.NorthdState = northd.State
+			status.Central.NorthdStandby = northd.HeldBack
+		}
+
+		status.Central.NBDatabaseSize, err = databaseSize(OvsdbTypeNBLocal)
+		if err != nil {
+			recordStatusError(&status, err)
+		}
+
+		status.Central.SBDatabaseSize, err = databaseSize(OvsdbTypeSBLocal)
+		if err != nil {
+			recordStatusError(&status, err)
+		}
+
+		// Database servers may not be running, memory usage is reported only when available.
+		status.Central.NBMemoryRSS, _ = databaseRSS(OvsdbTypeNBLocal)
+		status.Central.SBMemoryRSS, _ = databaseRSS(OvsdbTypeSBLocal)
+
+		status.Central.MemoryLimit, err = GetConfig(s, ConfigKeyDBMemoryLimit)
+		if err != nil {
+			recordStatusError(&status, err)
+		}
+
+		status.Central.MemoryTotal, err = totalMemory()
+		if err != nil {
+			recordStatusError(&status, err)
+		}
+
+		status.Central.NBState, err = DBState(s, OvsdbTypeNBLocal)
+		if err != nil {
+			recordStatusError(&status, err)
+		}
+
+		status.Central.SBState, err = DBState(s, OvsdbTypeSBLocal)
+		if err != nil {
+			recordStatusError(&status, err)
+		}
+
+		status.Central.PreferredLeaders, err = preferredLeaders(s)
+		if err != nil {
+			recordStatusError(&status, err)
+		}
+
+		if status.Central.NBState == DBStateJoined {
+			status.Central.NBLeader, err = leaderMember(s, OvsdbTypeNBLocal)
+			if err != nil {
+				recordStatusError(&status, err)
+			}
+		}
+
+		if status.Central.SBState == DBStateJoined {
+			status.Central.SBLeader, err = leaderMember(s, OvsdbTypeSBLocal)
+			if err != nil {
+				recordStatusError(&status, err)
+			}
+		}
+	}
+
+	if switchActive {
+		status.Switch = switchStatus(s)
+
+		status.Chassis.EncapIP, err = getChassisExternalID(s, "ovn-encap-ip")
+		if err != nil {
+			recordStatusError(&status, fmt.Errorf("failed to get OVN tunnel IP: %w", err))
+		}
+
+		status.Chassis.EncapTos, err = getChassisExternalID(s, "ovn-encap-tos")
+		if err != nil {
+			recordStatusError(&status, fmt.Errorf("failed to get OVN tunnel ToS: %w", err))
+		}
+
+		status.Chassis.RemoteProbeInterval, err = getChassisExternalID(s, "ovn-remote-probe-interval")
+		if err != nil {
+			recordStatusError(&status, fmt.Errorf("failed to get OVN SB probe interval: %w", err))
+		}
+
+		status.Chassis.MonitorAll, err = getChassisExternalID(s, "ovn-monitor-all")
+		if err != nil {
+			recordStatusError(&status, fmt.Errorf("failed to get OVN SB monitoring mode: %w", err))
+		}
+
+		status.Chassis.GatewayPriority, err = GetConfig(s, ConfigKeyGatewayPriority)
+		if err != nil {
+			recordStatusError(&status, fmt.Errorf("failed to get gateway priority: %w", err))
+		}
+
+		// ovn-controller that does not respond, e.g. while the "chassis" service restarts, is not paused.
+		status.Chassis.Paused, _ = chassisPaused(s)
+	}
+
+	return &status, nil
+}
+
+// recordStatusError adds "err" to the list of probes that failed while gathering "status".
+func recordStatusError(status *types.MemberStatus, err error) {
+	status.Errors = append(status.Errors, err.Error())
+}
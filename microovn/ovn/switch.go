@@ -0,0 +1,177 @@
+package ovn
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/canonical/microcluster/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+)
+
+// Traffic sampling protocols, configured by ConfigKeySamplingProtocol.
+const (
+	SamplingProtocolSFlow = "sflow"
+	SamplingProtocolIPFIX = "ipfix"
+)
+
+const defaultSamplingRate = "400" // Default value for ConfigKeySamplingRate
+
+// Datapath types of the integration bridge, configured by ConfigKeyDatapathType.
+const (
+	DatapathTypeSystem = "system" // Kernel datapath
+	DatapathTypeNetdev = "netdev" // Userspace datapath, e.g. for DPDK
+)
+
+// defaultIntegrationBridge is a name of the OVS bridge managed by OVN chassis, unless configured
+// otherwise by ConfigKeyBridge.
+const defaultIntegrationBridge = "br-int"
+
+// integrationBridge returns name of the OVS bridge managed by OVN chassis.
+func integrationBridge(s *state.State) (string, error) {
+	bridge, err := GetConfig(s, ConfigKeyBridge)
+	if err != nil {
+		return "", err
+	}
+
+	if bridge == "" {
+		return defaultIntegrationBridge, nil
+	}
+
+	return bridge, nil
+}
+
+// configuredDatapathType returns datapath type that new integration bridge is created with.
+func configuredDatapathType(s *state.State) (string, error) {
+	datapathType, err := GetConfig(s, ConfigKeyDatapathType)
+	if err != nil {
+		return "", err
+	}
+
+	if datapathType == "" {
+		return DatapathTypeSystem, nil
+	}
+
+	return datapathType, nil
+}
+
+// bridgeDatapathType returns datapath type of the existing OVS "bridge". Empty string is returned if the
+// bridge does not exist.
+func bridgeDatapathType(s *state.State, bridge string) (string, error) {
+	// Output is empty if the bridge does not exist, or quoted datapath type otherwise. Empty datapath
+	// type means default "system" datapath.
+	output, err := VSCtl(s, "--if-exists", "get", "bridge", bridge, "datapath_type")
+	output = strings.TrimSpace(output)
+	if err != nil || output == "" {
+		return "", err
+	}
+
+	datapathType := strings.Trim(output, "\"")
+	if datapathType == "" {
+		return DatapathTypeSystem, nil
+	}
+
+	return datapathType, nil
+}
+
+// switchStatus gathers state of the local OVS instance run by the "switch" service. Failures to query
+// individual components are reported as the component being down, rather than as an error, so that
+// OVS problems can be distinguished from OVN ones.
+func switchStatus(s *state.State) types.SwitchStatus {
+	status := types.SwitchStatus{Enabled: true}
+
+	_, err := SwitchCtl(s, "ovsdb-server", "version")
+	status.OvsdbRunning = err == nil
+
+	_, err = SwitchCtl(s, "ovs-vswitchd", "version")
+	status.VswitchdRunning = err == nil
+
+	if !status.OvsdbRunning {
+		return status
+	}
+
+	bridge, err := integrationBridge(s)
+	if err != nil {
+		return status
+	}
+
+	status.ConfiguredDatapathType, _ = configuredDatapathType(s)
+
+	datapathType, err := bridgeDatapathType(s, bridge)
+	if err != nil || datapathType == "" {
+		return status
+	}
+
+	status.IntegrationBridge = bridge
+	status.DatapathType = datapathType
+
+	var output string
+	for _, protocol := range []string{SamplingProtocolSFlow, SamplingProtocolIPFIX} {
+		output, err = VSCtl(s, "get", "bridge", bridge, protocol)
+		if err == nil && strings.TrimSpace(output) != "[]" {
+			status.Sampling = protocol
+		}
+	}
+
+	return status
+}
+
+// updateSwitchConfig applies user-configurable settings of the local OVS instance, stored in the shared
+// MicroOVN database, to the local OVS database.
+func updateSwitchConfig(s *state.State) error {
+	return updateSampling(s)
+}
+
+// updateSampling configures traffic sampling on the integration bridge according to
+// ConfigKeySamplingProtocol, ConfigKeySamplingTarget and ConfigKeySamplingRate. Sampling is disabled if
+// the protocol or the collector address is not set. Sampling records are recreated on every call, records
+// that are no longer referenced by the bridge are garbage collected by OVS.
+func updateSampling(s *state.State) error {
+	bridge, err := integrationBridge(s)
+	if err != nil {
+		return err
+	}
+
+	_, err = VSCtl(s, "br-exists", bridge)
+	if err != nil {
+		// Bridge is created together with OVN chassis configuration, there's nothing to sample yet.
+		return nil
+	}
+
+	protocol, err := GetConfig(s, ConfigKeySamplingProtocol)
+	if err != nil {
+		return err
+	}
+
+	target, err := GetConfig(s, ConfigKeySamplingTarget)
+	if err != nil {
+		return err
+	}
+
+	rate, err := GetConfig(s, ConfigKeySamplingRate)
+	if err != nil {
+		return err
+	}
+
+	if rate == "" {
+		rate = defaultSamplingRate
+	}
+
+	args := []string{"clear", "bridge", bridge, SamplingProtocolSFlow, SamplingProtocolIPFIX}
+	if protocol != "" && target != "" {
+		args = append(args,
+			"--", "--id=@sampling", "create", protocol,
+			fmt.Sprintf("targets=%s", strconv.Quote(target)),
+			fmt.Sprintf("sampling=%s", rate),
+			"--", "set", "bridge", bridge, fmt.Sprintf("%s=@sampling", protocol),
+		)
+	}
+
+	_, err = VSCtl(s, args...)
+	if err != nil {
+		return fmt.Errorf("failed to configure traffic sampling on bridge '%s': %w", bridge, err)
+	}
+
+	return nil
+}
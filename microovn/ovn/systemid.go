@@ -0,0 +1,131 @@
+package ovn
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/api"
+
+	"github.com/canonical/microovn/microovn/database"
+	"github.com/canonical/microovn/microovn/ovn/paths"
+)
+
+// systemIDRecordPrefix is a prefix of keys under which chassis system-id of each member is stored in the
+// config DB table. Full key has format "<prefix><member_name>".
+const systemIDRecordPrefix = "chassis.system-id@"
+
+// getSystemID returns chassis system-id assigned to this member. Value stored in the shared database takes
+// precedence, then the value persisted in paths.SystemIDFile. If neither is available, member name is used.
+func getSystemID(s *state.State) (string, error) {
+	var systemID string
+	recordKey := systemIDRecordPrefix + s.Name()
+	err := s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		item, err := database.GetConfigItem(ctx, tx, recordKey)
+		if err != nil {
+			if api.StatusErrorCheck(err, http.StatusNotFound) {
+				return nil
+			}
+
+			return err
+		}
+
+		systemID = item.Value
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get chassis system-id: %w", err)
+	}
+
+	if systemID != "" {
+		return systemID, nil
+	}
+
+	content, err := os.ReadFile(paths.SystemIDFile())
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("failed to read chassis system-id: %w", err)
+	}
+
+	systemID = strings.TrimSpace(string(content))
+	if systemID != "" {
+		return systemID, nil
+	}
+
+	return s.Name(), nil
+}
+
+// ensureSystemID makes sure that chassis system-id of this member is persisted both in the shared database
+// and in paths.SystemIDFile, and that it's applied to the local OVS database. Since the shared database
+// outlives individual hosts, a re-imaged host that rejoins under the same member name reclaims its previous
+// chassis identity instead of registering a new chassis.
+func ensureSystemID(s *state.State) error {
+	systemID, err := getSystemID(s)
+	if err != nil {
+		return err
+	}
+
+	err = storeSystemID(s, s.Name(), systemID)
+	if err != nil {
+		return err
+	}
+
+	return setChassisExternalID(s, "system-id", systemID)
+}
+
+// storeSystemID persists chassis system-id "systemID" of member "member" in the shared database. If "member"
+// is the local member, the value is also written to paths.SystemIDFile.
+func storeSystemID(s *state.State, member string, systemID string) error {
+	recordKey := systemIDRecordPrefix + member
+	err := s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		exists, err := database.ConfigItemExists(ctx, tx, recordKey)
+		if err != nil {
+			return err
+		}
+
+		item := database.ConfigItem{Key: recordKey, Value: systemID}
+		if exists {
+			return database.UpdateConfigItem(ctx, tx, recordKey, item)
+		}
+
+		_, err = database.CreateConfigItem(ctx, tx, item)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store chassis system-id: %w", err)
+	}
+
+	if member != s.Name() {
+		return nil
+	}
+
+	err = os.MkdirAll(filepath.Dir(paths.SystemIDFile()), requiredDirMode)
+	if err != nil {
+		return fmt.Errorf("failed to create directory for chassis system-id: %w", err)
+	}
+
+	err = os.WriteFile(paths.SystemIDFile(), []byte(systemID+"\n"), 0600)
+	if err != nil {
+		return fmt.Errorf("failed to write chassis system-id: %w", err)
+	}
+
+	return nil
+}
+
+// deleteSystemID removes chassis system-id of member "member" from the shared database.
+func deleteSystemID(s *state.State, member string) error {
+	recordKey := systemIDRecordPrefix + member
+	return s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		exists, err := database.ConfigItemExists(ctx, tx, recordKey)
+		if err != nil || !exists {
+			return err
+		}
+
+		return database.DeleteConfigItem(ctx, tx, recordKey)
+	})
+}
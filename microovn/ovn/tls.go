@@ -0,0 +1,177 @@
+package ovn
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/canonical/microcluster/state"
+
+	"github.com/canonical/microovn/microovn/ovn/paths"
+)
+
+// localCertificateServices returns names of services that are expected to have certificate issued on this
+// member, based on which OVN services are running locally.
+func localCertificateServices(centralActive bool, switchActive bool) []string {
+	services := []string{"client"}
+	if centralActive {
+		services = append(services, "ovnnb", "ovnsb", "ovn-northd")
+	}
+
+	if switchActive {
+		services = append(services, "ovn-controller")
+	}
+
+	return services
+}
+
+// missingCertificates returns paths to certificate files that are expected to be present on this member, but
+// can't be found. Expected files are CA certificate and certificate/key pairs of services returned by
+// localCertificateServices.
+func missingCertificates(centralActive bool, switchActive bool) ([]string, error) {
+	expected := []string{paths.PkiCaCertFile()}
+	for _, service := range localCertificateServices(centralActive, switchActive) {
+		certPath, keyPath, err := getServiceCertificatePaths(service)
+		if err != nil {
+			return nil, err
+		}
+
+		expected = append(expected, certPath, keyPath)
+	}
+
+	missing := []string{}
+	for _, path := range expected {
+		_, err := os.Stat(path)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				missing = append(missing, path)
+				continue
+			}
+
+			return nil, fmt.Errorf("failed to check certificate file '%s': %w", path, err)
+		}
+	}
+
+	return missing, nil
+}
+
+// listenProtocol returns protocol ("ssl" or "tcp") that local OVN Northbound database is currently
+// configured to listen on. This function must be called on a member that runs the "central" service.
+func listenProtocol(s *state.State) (string, error) {
+	output, err := localNBCtl(s, "get-connection")
+	if err != nil {
+		return "", err
+	}
+
+	connection, _, _ := strings.Cut(strings.TrimSpace(output), "\n")
+	protocol, _, found := strings.Cut(connection, ":")
+	if !found {
+		return "", fmt.Errorf("unexpected OVN NB connection '%s'", connection)
+	}
+
+	return strings.TrimPrefix(protocol, "p"), nil
+}
+
+// VerifyCertChain checks that local OVN service certificates can be used for TLS connections within the
+// cluster. CA certificate stored in the shared database is compared with the local copy of the CA and every
+// local service certificate is verified to chain to this CA, to be currently valid and to include addresses
+// of the member in its SAN. Mismatched CA, e.g. after partially completed CA rotation, breaks SSL handshakes
+// between members. All found problems are returned joined in a single error.
+func VerifyCertChain(s *state.State) error {
+	snapshot, err := loadServicesSnapshot(s)
+	if err != nil {
+		return err
+	}
+
+	centralActive := snapshot.LocalServiceActive("central")
+	switchActive := snapshot.LocalServiceActive("switch")
+
+	problems, err := certChainProblems(s, centralActive, switchActive)
+	if err != nil {
+		return err
+	}
+
+	errs := make([]error, 0, len(problems))
+	for _, problem := range problems {
+		errs = append(errs, errors.New(problem))
+	}
+
+	return errors.Join(errs...)
+}
+
+// certChainProblems returns human-readable descriptions of problems found by VerifyCertChain in
+// certificates of services returned by localCertificateServices. Missing certificate files are not
+// reported, see missingCertificates.
+func certChainProblems(s *state.State, centralActive bool, switchActive bool) ([]string, error) {
+	caCert, _, err := getCA(s)
+	if err != nil {
+		return nil, err
+	}
+
+	problems := []string{}
+
+	localCA, err := os.ReadFile(paths.PkiCaCertFile())
+	if err == nil {
+		block, _ := pem.Decode(localCA)
+		if block == nil || !bytes.Equal(block.Bytes, caCert.Raw) {
+			problems = append(problems, fmt.Sprintf("local CA certificate %s does not match cluster CA", paths.PkiCaCertFile()))
+		}
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(caCert)
+
+	address, err := serviceAddress(s)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := []string{address}
+	encapAddr, err := encapAddress(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if encapAddr != addresses[0] {
+		addresses = append(addresses, encapAddr)
+	}
+
+	for _, service := range localCertificateServices(centralActive, switchActive) {
+		cert, err := readServiceCertificate(service)
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				problems = append(problems, err.Error())
+			}
+
+			continue
+		}
+
+		_, err = cert.Verify(x509.VerifyOptions{
+			Roots:     roots,
+			KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		})
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s certificate is not valid for cluster CA: %s", service, err))
+			continue
+		}
+
+		// Certificates issued before member addresses were added to SAN don't carry any IP address.
+		if len(cert.IPAddresses) == 0 {
+			problems = append(problems, fmt.Sprintf("%s certificate was issued without addresses in SAN, reissue it with 'microovn certificates reissue %s'", service, service))
+			continue
+		}
+
+		for _, address := range addresses {
+			err = cert.VerifyHostname(address)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s certificate does not cover address %s, reissue it with 'microovn certificates reissue %s'", service, address, service))
+			}
+		}
+	}
+
+	return problems, nil
+}
@@ -0,0 +1,122 @@
+package ovn
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/api"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/database"
+)
+
+// ExportTopology returns descriptor of the current layout of OVN services in the cluster, i.e. which
+// services run on which member and addresses of the members. Descriptor is a types.ReportKindTopology
+// report, that can be later passed to ValidateTopology.
+func ExportTopology(s *state.State) ([]byte, error) {
+	topology, err := currentTopology(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.MarshalReport(types.ReportKindTopology, topology)
+}
+
+// ValidateTopology compares current layout of OVN services in the cluster with the topology "descriptor",
+// as produced by ExportTopology, and returns list of differences. Empty list is returned if the cluster
+// matches the descriptor. Addresses are compared only for members whose address is set in the descriptor.
+func ValidateTopology(s *state.State, descriptor []byte) ([]types.TopologyDiff, error) {
+	expected := types.Topology{}
+	err := types.UnmarshalReport(descriptor, types.ReportKindTopology, &expected)
+	if err != nil {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "invalid topology descriptor: %s", err)
+	}
+
+	actual, err := currentTopology(s)
+	if err != nil {
+		return nil, err
+	}
+
+	actualMembers := map[string]types.TopologyMember{}
+	for _, member := range actual.Members {
+		actualMembers[member.Name] = member
+	}
+
+	diffs := []types.TopologyDiff{}
+	expectedMembers := map[string]bool{}
+	for _, member := range expected.Members {
+		expectedMembers[member.Name] = true
+
+		current, ok := actualMembers[member.Name]
+		if !ok {
+			diffs = append(diffs, types.TopologyDiff{Member: member.Name, Field: "member", Expected: "present", Actual: "missing"})
+			continue
+		}
+
+		if member.Address != "" && member.Address != current.Address {
+			diffs = append(diffs, types.TopologyDiff{Member: member.Name, Field: "address", Expected: member.Address, Actual: current.Address})
+		}
+
+		services := append([]string{}, member.Services...)
+		sort.Strings(services)
+		if strings.Join(services, ",") != strings.Join(current.Services, ",") {
+			diffs = append(diffs, types.TopologyDiff{Member: member.Name, Field: "services", Expected: strings.Join(services, ","), Actual: strings.Join(current.Services, ",")})
+		}
+	}
+
+	for _, member := range actual.Members {
+		if !expectedMembers[member.Name] {
+			diffs = append(diffs, types.TopologyDiff{Member: member.Name, Field: "member", Expected: "missing", Actual: "present"})
+		}
+	}
+
+	return diffs, nil
+}
+
+// currentTopology returns current layout of OVN services in the cluster, built from the services table and
+// the list of cluster remotes. Members without any service are included as well.
+func currentTopology(s *state.State) (*types.Topology, error) {
+	members := map[string]*types.TopologyMember{}
+	for name, remote := range s.Remotes().RemotesByName() {
+		members[name] = &types.TopologyMember{Name: name, Address: remote.Address.String(), Services: []string{}}
+	}
+
+	err := s.Database.Transaction(s.Context, func(ctx context.Context, tx *sql.Tx) error {
+		services, err := database.GetServices(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for _, srv := range services {
+			member, ok := members[srv.Member]
+			if !ok {
+				member = &types.TopologyMember{Name: srv.Member, Services: []string{}}
+				members[srv.Member] = member
+			}
+
+			member.Services = append(member.Services, srv.Service)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query services: %w", err)
+	}
+
+	topology := types.Topology{Members: make([]types.TopologyMember, 0, len(members))}
+	for _, member := range members {
+		sort.Strings(member.Services)
+		topology.Members = append(topology.Members, *member)
+	}
+
+	sort.Slice(topology.Members, func(i, j int) bool {
+		return topology.Members[i].Name < topology.Members[j].Name
+	})
+
+	return &topology, nil
+}
@@ -0,0 +1,178 @@
+package ovn
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/logger"
+)
+
+const watchdogInterval = 30 * time.Second   // Interval between pings of local OVN database servers
+const watchdogPingTimeout = 5 * time.Second // Time that database server has to respond to a ping
+const watchdogMinBackoff = 5 * time.Minute  // Minimum time between restarts triggered by the watchdog
+const watchdogMaxBackoff = 60 * time.Minute // Maximum time between restarts triggered by the watchdog
+const watchdogCheckInterval = time.Minute   // How often is ConfigKeyDBWatchdogFailures checked while disabled
+
+// dbWatchdogOnce ensures that only one database watchdog is running.
+var dbWatchdogOnce sync.Once
+
+// dbWatchdogState tracks consecutive ping failures and restarts performed by the database watchdog.
+type dbWatchdogState struct {
+	failures    int           // Number of consecutive failed pings
+	lastRestart time.Time     // Time of the last restart triggered by the watchdog
+	backoff     time.Duration // Minimum time before the next restart
+}
+
+// startDBWatchdog starts background goroutine that periodically pings control sockets of local OVN NB and
+// SB database servers. Server that is alive, but stopped responding, is detected after
+// ConfigKeyDBWatchdogFailures consecutive failed pings and the "central" service is restarted. Watchdog is
+// disabled while the option is not set.
+//
+// Restarts are rate limited by a backoff that doubles after each restart, from watchdogMinBackoff up to
+// watchdogMaxBackoff, and resets once the servers stay responsive for the duration of the backoff. Service
+// is never restarted if the rest of the cluster would not keep quorum without this member.
+func startDBWatchdog(s *state.State) {
+	dbWatchdogOnce.Do(func() {
+		go func() {
+			watchdog := &dbWatchdogState{backoff: watchdogMinBackoff}
+			for {
+				threshold, err := getConfigInt(s, ConfigKeyDBWatchdogFailures, 0)
+				if err != nil {
+					logger.Warnf("Failed to get database watchdog configuration: %s", err)
+					threshold = 0
+				}
+
+				wait := watchdogInterval
+				if threshold <= 0 {
+					watchdog.failures = 0
+					wait = watchdogCheckInterval
+				}
+
+				select {
+				case <-s.Context.Done():
+					return
+				case <-time.After(wait):
+				}
+
+				if threshold <= 0 {
+					continue
+				}
+
+				err = watchdog.check(s, threshold)
+				if err != nil {
+					logger.Warnf("OVN database watchdog: %s", err)
+				}
+			}
+		}()
+	})
+}
+
+// check pings local OVN database servers and restarts the "central" service if they failed to respond
+// "threshold" times in a row and the restart is allowed.
+func (w *dbWatchdogState) check(s *state.State, threshold int) error {
+	if skipInMaintenance(s, "database watchdog") {
+		w.failures = 0
+		return nil
+	}
+
+	centralActive, err := localServiceActive(s, "central")
+	if err != nil || !centralActive {
+		w.failures = 0
+		return err
+	}
+
+	err = pingDatabases(s)
+	if err == nil {
+		w.failures = 0
+		if w.backoff > watchdogMinBackoff && time.Since(w.lastRestart) > w.backoff {
+			w.backoff = watchdogMinBackoff
+		}
+
+		return nil
+	}
+
+	w.failures++
+	logger.Warnf("OVN database server did not respond (%d/%d): %s", w.failures, threshold, err)
+	if w.failures < threshold {
+		return nil
+	}
+
+	if !w.lastRestart.IsZero() && time.Since(w.lastRestart) < w.backoff {
+		return fmt.Errorf("restart of central service postponed, last restart was less than %s ago", w.backoff)
+	}
+
+	safe, err := restartKeepsQuorum(s)
+	if err != nil {
+		return err
+	}
+
+	if !safe {
+		return fmt.Errorf("refusing to restart central service, rest of the cluster would not keep quorum")
+	}
+
+	logger.Errorf("OVN database server is not responding, restarting central service")
+	w.failures = 0
+	w.lastRestart = time.Now()
+	if w.backoff < watchdogMaxBackoff {
+		w.backoff *= 2
+	}
+
+	if w.backoff > watchdogMaxBackoff {
+		w.backoff = watchdogMaxBackoff
+	}
+
+	err = snapRestart("central")
+	if err != nil {
+		return fmt.Errorf("failed to restart central service: %w", err)
+	}
+
+	return nil
+}
+
+// pingDatabases returns error if local OVN NB or SB database server fails to respond to a unixctl command
+// within watchdogPingTimeout.
+func pingDatabases(s *state.State) error {
+	for _, dbType := range []OvsdbType{OvsdbTypeNBLocal, OvsdbTypeSBLocal} {
+		ctlSock, err := ovsdbControlSock(dbType)
+		if err != nil {
+			return err
+		}
+
+		_, err = AppCtlWithTimeout(s, ctlSock, watchdogPingTimeout, "version")
+		if err != nil {
+			return fmt.Errorf("failed to ping %s: %w", ctlSock, err)
+		}
+	}
+
+	return nil
+}
+
+// restartKeepsQuorum returns true if members other than this one, whose OVN NB and SB servers are healthy,
+// form a majority of the central members, so the clusters keep quorum while the local service restarts. The
+// only central member can always restart, as there's no quorum to keep.
+func restartKeepsQuorum(s *state.State) (bool, error) {
+	centrals, err := centralMembers(s)
+	if err != nil {
+		return false, fmt.Errorf("failed to query central services: %w", err)
+	}
+
+	if len(centrals) <= 1 {
+		return true, nil
+	}
+
+	clusterSize, err := quorumClusterSize(s, len(centrals))
+	if err != nil {
+		return false, err
+	}
+
+	healthyOthers := 0
+	for _, member := range centrals {
+		if member != s.Name() && centralMemberHealthy(s, member) {
+			healthyOthers++
+		}
+	}
+
+	return healthyOthers > clusterSize/2, nil
+}
@@ -0,0 +1,85 @@
+package ovn
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/canonical/microcluster/state"
+	"github.com/lxc/lxd/shared/api"
+
+	microovnClient "github.com/canonical/microovn/microovn/client"
+)
+
+// writableDatabases maps database names accepted by CanWrite to their types and command wrappers.
+var writableDatabases = map[string]struct {
+	dbType OvsdbType
+	ctl    func(*state.State, ...string) (string, error)
+}{
+	"nb": {dbType: OvsdbTypeNBLocal, ctl: localNBCtl},
+	"sb": {dbType: OvsdbTypeSBLocal, ctl: localSBCtl},
+}
+
+// CanWrite returns true if OVN database "db" ("nb" or "sb") currently accepts writes from remote clients.
+// That requires the raft cluster of the database to have a leader, i.e. quorum of its servers, and none of
+// the connections the database listens on to be in read-only mode.
+//
+// The check is performed by the local database server if this member runs the "central" service.
+// Otherwise, it is forwarded to one of the members that run it.
+func CanWrite(s *state.State, db string) (bool, error) {
+	dbInfo, ok := writableDatabases[strings.ToLower(db)]
+	if !ok {
+		return false, api.StatusErrorf(http.StatusBadRequest, "unknown database '%s', expected 'nb' or 'sb'", db)
+	}
+
+	centralActive, err := localServiceActive(s, "central")
+	if err != nil {
+		return false, fmt.Errorf("failed to query local services: %w", err)
+	}
+
+	if !centralActive {
+		return canWriteRemote(s, db)
+	}
+
+	inQuorum, err := databaseInQuorum(s, dbInfo.dbType)
+	if err != nil || !inQuorum {
+		return false, err
+	}
+
+	output, err := dbInfo.ctl(s, "--data=bare", "--no-headings", "--columns=read_only", "list", "Connection")
+	if err != nil {
+		return false, fmt.Errorf("failed to get connection mode: %w", err)
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		if strings.TrimSpace(line) == "true" {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// canWriteRemote asks members that run the "central" service, one by one, whether OVN database "db"
+// accepts writes. The first answer is returned. If no central member answers, the database is considered
+// not writable.
+func canWriteRemote(s *state.State, db string) (bool, error) {
+	centrals, err := centralMembers(s)
+	if err != nil {
+		return false, fmt.Errorf("failed to query central services: %w", err)
+	}
+
+	leader, err := s.Leader()
+	if err != nil {
+		return false, fmt.Errorf("failed to get client for cluster leader: %w", err)
+	}
+
+	for _, member := range centrals {
+		writable, err := microovnClient.CanWrite(s.Context, leader.UseTarget(member), db)
+		if err == nil {
+			return writable, nil
+		}
+	}
+
+	return false, nil
+}